@@ -6,7 +6,7 @@ _oci_tf_bootstrap() {
     cur="${COMP_WORDS[COMP_CWORD]}"
     prev="${COMP_WORDS[COMP_CWORD-1]}"
 
-    opts="--profile --config --config-file --output --region --always-free --json --version --help"
+    opts="--profile --config --config-file --output --region --always-free --oke-virtual-nodes --observability --budget --price-catalog --instances --instance-pool --max-retries --max-in-flight --rps --timeout --request-timeout --cache-ttl --cache-dir --refresh --progress --backup-policy --policy --policy-group --tags --include --exclude --filter-tag --redact --redact-key --json --version --help"
 
     case "${prev}" in
         --profile)
@@ -27,7 +27,7 @@ _oci_tf_bootstrap() {
             COMPREPLY=( $(compgen -f -- ${cur}) )
             return 0
             ;;
-        --output)
+        --output|--cache-dir)
             # Complete with directories
             COMPREPLY=( $(compgen -d -- ${cur}) )
             return 0
@@ -38,6 +38,27 @@ _oci_tf_bootstrap() {
             COMPREPLY=( $(compgen -W "${regions}" -- ${cur}) )
             return 0
             ;;
+        --include|--exclude)
+            # Complete with resource kinds
+            local kinds="tenancy compartments availability-domains a1-capacity shapes images vcns limits block-volumes boot-volumes backup-policies volume-groups groups dynamic-groups policies tag-namespaces registry functions dns observability budgets oke-images oke-clusters"
+            COMPREPLY=( $(compgen -W "${kinds}" -- ${cur}) )
+            return 0
+            ;;
+        --price-catalog)
+            # Complete with files
+            COMPREPLY=( $(compgen -f -- ${cur}) )
+            return 0
+            ;;
+        --progress)
+            # Complete with progress displays
+            COMPREPLY=( $(compgen -W "auto table text json" -- ${cur}) )
+            return 0
+            ;;
+        --backup-policy)
+            # Complete with Oracle-defined backup policies
+            COMPREPLY=( $(compgen -W "gold silver bronze" -- ${cur}) )
+            return 0
+            ;;
         *)
             ;;
     esac
@@ -46,6 +67,13 @@ _oci_tf_bootstrap() {
         COMPREPLY=( $(compgen -W "${opts}" -- ${cur}) )
         return 0
     fi
+
+    if [[ " ${COMP_WORDS[*]} " == *" diff "* ]]; then
+        # Complete diff snapshots with files
+        COMPREPLY=( $(compgen -f -- ${cur}) )
+        return 0
+    fi
+    COMPREPLY=( $(compgen -W "diff schema" -- ${cur}) )
 }
 
 complete -F _oci_tf_bootstrap oci-tf-bootstrap
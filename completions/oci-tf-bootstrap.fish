@@ -28,6 +28,35 @@ complete -c oci-tf-bootstrap -l config-file -d 'OCI config file path' -r -F
 complete -c oci-tf-bootstrap -l output -d 'Output directory for generated TF files' -r -a '(__fish_complete_directories)'
 complete -c oci-tf-bootstrap -l region -d 'Override region' -xa "$regions"
 complete -c oci-tf-bootstrap -l always-free -d 'Filter output to always-free tier eligible resources only'
+complete -c oci-tf-bootstrap -l oke-virtual-nodes -d 'Generate an enhanced OKE cluster with a virtual node pool'
+complete -c oci-tf-bootstrap -l observability -d 'Generate flow logs, an alert topic and instance alarms'
+complete -c oci-tf-bootstrap -l budget -d 'Monthly budget amount with alert rules and a shape quota' -x
+complete -c oci-tf-bootstrap -l price-catalog -d 'Price catalog JSON for the cost estimate' -r -F
+complete -c oci-tf-bootstrap -l instances -d 'Number of instances spread across availability and fault domains' -x
+complete -c oci-tf-bootstrap -l instance-pool -d 'Generate an instance pool spread across availability and fault domains'
+complete -c oci-tf-bootstrap -l max-retries -d 'Retries for throttled and failed API calls' -x
+complete -c oci-tf-bootstrap -l max-in-flight -d 'Maximum concurrent OCI API calls' -x
+complete -c oci-tf-bootstrap -l rps -d 'Maximum OCI API requests per second' -x
+complete -c oci-tf-bootstrap -l timeout -d 'Stop discovery after this long' -x
+complete -c oci-tf-bootstrap -l request-timeout -d 'Deadline for each OCI API request' -x
+complete -c oci-tf-bootstrap -l cache-ttl -d 'Reuse cached discovery output younger than this' -x
+complete -c oci-tf-bootstrap -l cache-dir -d 'Discovery cache directory' -r -a '(__fish_complete_directories)'
+complete -c oci-tf-bootstrap -l refresh -d 'Ignore and refresh the discovery cache'
+complete -c oci-tf-bootstrap -l progress -d 'Discovery progress display' -xa 'auto table text json'
+complete -c oci-tf-bootstrap -l backup-policy -d 'Volume backup policy for example volumes' -xa 'gold silver bronze'
+complete -c oci-tf-bootstrap -l policy -d 'Print the least-privilege IAM policy for discovery and exit'
+complete -c oci-tf-bootstrap -l policy-group -d 'Group name used in generated policy statements' -x
+complete -c oci-tf-bootstrap -l tags -d 'Defined tags for generated resources (Namespace.Key=Value,...)' -x
+complete -c oci-tf-bootstrap -l include -d 'Only discover these resource kinds' -x -a 'tenancy compartments availability-domains a1-capacity shapes images vcns limits block-volumes boot-volumes backup-policies volume-groups groups dynamic-groups policies tag-namespaces registry functions dns observability budgets oke-images oke-clusters'
+complete -c oci-tf-bootstrap -l exclude -d 'Do not discover these resource kinds' -x -a 'tenancy compartments availability-domains a1-capacity shapes images vcns limits block-volumes boot-volumes backup-policies volume-groups groups dynamic-groups policies tag-namespaces registry functions dns observability budgets oke-images oke-clusters'
+complete -c oci-tf-bootstrap -l filter-tag -d 'Only discover resources carrying these tags (Namespace.Key=Value or Key=Value)' -x
+complete -c oci-tf-bootstrap -l redact -d 'Replace identifiers in all output with stable pseudonyms'
+complete -c oci-tf-bootstrap -l redact-key -d 'Secret the --redact pseudonyms are derived from' -x
 complete -c oci-tf-bootstrap -l json -d 'Output raw discovery as JSON instead of TF'
 complete -c oci-tf-bootstrap -l version -d 'Print version information and exit'
 complete -c oci-tf-bootstrap -l help -d 'Show help'
+
+# Subcommands
+complete -c oci-tf-bootstrap -n '__fish_use_subcommand' -a diff -d 'Report drift between discovery snapshots'
+complete -c oci-tf-bootstrap -n '__fish_use_subcommand' -a schema -d 'Print the JSON Schema for --json output'
+complete -c oci-tf-bootstrap -n '__fish_seen_subcommand_from diff' -F
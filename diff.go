@@ -0,0 +1,125 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/larsenclose/oci-tf-bootstrap/internal/discovery"
+	"github.com/larsenclose/oci-tf-bootstrap/internal/drift"
+	"github.com/larsenclose/oci-tf-bootstrap/internal/progress"
+)
+
+// runDiff implements the diff subcommand: it compares a --json snapshot with
+// a second snapshot, or with live discovery using the usual discovery flags,
+// and writes the report to stdout. It reports whether anything drifted.
+func runDiff(args []string) (drifted bool, err error) {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "Write the report as JSON instead of text")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: oci-tf-bootstrap [discovery flags] diff [--json] OLD.json [NEW.json]")
+		fmt.Fprintln(fs.Output(), "\nCompares OLD.json with NEW.json, or with live discovery when NEW.json is omitted.")
+		fmt.Fprintln(fs.Output(), "Exits 0 without drift, 1 with drift and 2 on error.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return false, err
+	}
+	if fs.NArg() < 1 || fs.NArg() > 2 {
+		fs.Usage()
+		return false, fmt.Errorf("diff: expected OLD.json [NEW.json], got %d arguments", fs.NArg())
+	}
+
+	old, err := drift.LoadSnapshot(fs.Arg(0))
+	if err != nil {
+		return false, err
+	}
+	var current *discovery.Result
+	var notRun []string
+	if fs.NArg() == 2 {
+		current, err = drift.LoadSnapshot(fs.Arg(1))
+	} else {
+		current, notRun, err = discoverLive()
+	}
+	if err != nil {
+		return false, err
+	}
+
+	report, err := drift.Compare(old, current, notRun)
+	if err != nil {
+		return false, err
+	}
+	if *asJSON {
+		if err := report.WriteJSON(os.Stdout); err != nil {
+			return false, err
+		}
+	} else {
+		report.WriteText(os.Stdout)
+	}
+	return report.Drifted(), nil
+}
+
+// discoverLive runs discovery for diff, with progress on stderr so stdout
+// carries only the report. With --redact it compares against a snapshot taken
+// with --redact. It also returns the discoverers that did not run, left out
+// of --include or not enabled by the flags, whose resources are not compared.
+// A partial result would report everything not yet discovered as removed, so
+// it is an error, as is a discoverer that failed or warned.
+func discoverLive() (*discovery.Result, []string, error) {
+	ociConfigPath, _ := resolveConfigPath()
+	ociProfile := resolveProfile()
+
+	fmt.Fprintf(os.Stderr, "oci-tf-bootstrap diff\n")
+	fmt.Fprintf(os.Stderr, "  Profile:    %s\n", ociProfile)
+	fmt.Fprintf(os.Stderr, "  Config:     %s\n", ociConfigPath)
+
+	redactor, err := newRedactor()
+	if err != nil {
+		return nil, nil, err
+	}
+	dctx, err := newDiscoveryContext(ociConfigPath, ociProfile, redactor.Writer(os.Stderr))
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx, cancel := discoveryDeadline()
+	defer cancel()
+
+	stopProgress := startProgress(dctx, os.Stderr)
+	var incomplete []string
+	show := dctx.Progress
+	dctx.Progress = func(e progress.Event) {
+		if e.Discoverer != "" && !slices.Contains(incomplete, e.Discoverer) &&
+			(e.Kind == progress.Warning || e.Kind == progress.Finished && e.Err != nil) {
+			incomplete = append(incomplete, e.Discoverer)
+		}
+		show(e)
+	}
+	result, err := discovery.Run(ctx, dctx)
+	stopProgress()
+	if err != nil {
+		return nil, nil, fmt.Errorf("discovery failed: %w", err)
+	}
+	if len(incomplete) > 0 {
+		return nil, nil, fmt.Errorf("discovery incomplete: %s failed or warned, so their resources would show as removed", strings.Join(incomplete, ", "))
+	}
+	redactor.Result(result)
+	fmt.Fprintln(os.Stderr)
+	return result, notRun(dctx), nil
+}
+
+// notRun returns the registered discoverers that discovery with dctx skips.
+func notRun(dctx *discovery.Context) []string {
+	selected := make(map[string]bool)
+	for _, d := range dctx.Discoverers {
+		selected[d.Name()] = true
+	}
+	var names []string
+	for _, d := range discovery.Discoverers() {
+		if (dctx.Discoverers != nil && !selected[d.Name()]) || !d.Enabled(dctx) {
+			names = append(names, d.Name())
+		}
+	}
+	return names
+}
@@ -0,0 +1,36 @@
+package discovery
+
+// Budget is a spending budget and the alert rules attached to it. Budgets live
+// in the tenancy root and target compartments or cost-tracking tags.
+type Budget struct {
+	ID              string            `json:"id"`
+	Name            string            `json:"name"`
+	Amount          float64           `json:"amount"`
+	ResetPeriod     string            `json:"reset_period"` // MONTHLY or SINGLE_USE
+	TargetType      string            `json:"target_type"`  // COMPARTMENT or TAG
+	Targets         []string          `json:"targets"`      // Compartment OCIDs or cost-tracking tags
+	ActualSpend     float64           `json:"actual_spend"`
+	ForecastedSpend float64           `json:"forecasted_spend"`
+	AlertRules      []BudgetAlertRule `json:"alert_rules"`
+	Tags
+}
+
+// BudgetAlertRule notifies recipients when actual or forecast spend crosses a threshold.
+type BudgetAlertRule struct {
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	Type          string  `json:"type"` // ACTUAL or FORECAST
+	Threshold     float64 `json:"threshold"`
+	ThresholdType string  `json:"threshold_type"` // PERCENTAGE or ABSOLUTE
+	Recipients    string  `json:"recipients"`     // Comma-separated email addresses
+}
+
+// Quota is a compartment quota policy. Statements reference compartments by name.
+type Quota struct {
+	ID            string   `json:"id"`
+	Name          string   `json:"name"`
+	CompartmentID string   `json:"compartment_id"`
+	Description   string   `json:"description"`
+	Statements    []string `json:"statements"`
+	Tags
+}
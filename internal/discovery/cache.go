@@ -0,0 +1,196 @@
+package discovery
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/larsenclose/oci-tf-bootstrap/internal/progress"
+)
+
+// Cache configures the on-disk cache of discoverer output. Each discoverer's
+// output is stored separately, so a run that regenerates Terraform with
+// different flags reuses what earlier runs against the same scope fetched.
+type Cache struct {
+	Dir     string        // Root directory; each scope gets its own subdirectory
+	TTL     time.Duration // Age after which an entry is fetched again; 0 disables the cache
+	Refresh bool          // Ignore existing entries, but store the fresh output
+}
+
+// DefaultCacheDir returns the cache directory under the user's cache
+// directory (e.g. ~/.cache/oci-tf-bootstrap on Linux).
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "oci-tf-bootstrap"), nil
+}
+
+// cacheEntry is the on-disk form of one discoverer's output.
+type cacheEntry struct {
+	SavedAt time.Time       `json:"saved_at"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// scopeCache reads and writes the entries of one discovery scope. A nil
+// *scopeCache disables caching.
+type scopeCache struct {
+	dir     string
+	ttl     time.Duration
+	refresh bool
+	now     func() time.Time // Replaced in tests
+	events  *events          // Where to report entries that could not be stored
+
+	mu           sync.Mutex
+	hits, misses int
+}
+
+func newScopeCache(c *Cache, dctx *Context, ev *events) *scopeCache {
+	if c == nil || c.Dir == "" || c.TTL <= 0 {
+		return nil
+	}
+	return &scopeCache{
+		dir:     filepath.Join(c.Dir, cacheScope(dctx)),
+		ttl:     c.TTL,
+		refresh: c.Refresh,
+		now:     time.Now,
+		events:  ev,
+	}
+}
+
+// cacheScope names the directory holding dctx's entries: a hash of the
+// tenancy, region, compartment and profile, plus the tag filters since some
+// discoverers apply them while listing.
+func cacheScope(dctx *Context) string {
+	filters := make([]string, len(dctx.TagFilters))
+	for i, f := range dctx.TagFilters {
+		filters[i] = f.String()
+	}
+	slices.Sort(filters)
+
+	h := sha256.New()
+	for _, s := range append([]string{dctx.TenancyID, dctx.Region, dctx.CompartmentID, dctx.Profile}, filters...) {
+		fmt.Fprintf(h, "%s\x00", s)
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// cached returns the stored output of the discoverer name when it is younger
+// than the TTL, and otherwise calls fn and stores what it returns. Failed
+// calls, and calls made while ctx is canceled, are not stored.
+func cached[T any](ctx context.Context, c *scopeCache, name string, fn func() (T, error)) (T, error) {
+	return cachedWarn(ctx, c, name, nil, func(func(error)) (T, error) { return fn() })
+}
+
+// cachedWarn is cached for discoverers that keep going past partial failures
+// and pass them to warn, which must be safe for concurrent use. Output
+// gathered with a warning is incomplete and not stored, so the next run
+// fetches it again instead of serving it for the whole TTL.
+func cachedWarn[T any](ctx context.Context, c *scopeCache, name string, warn func(error), fn func(warn func(error)) (T, error)) (T, error) {
+	if c == nil {
+		return fn(warn)
+	}
+	if v, ok := load[T](c, name); ok {
+		c.count(true)
+		return v, nil
+	}
+	c.count(false)
+
+	var warned atomic.Bool
+	v, err := fn(func(err error) {
+		warned.Store(true)
+		warn(err)
+	})
+	if err != nil || ctx.Err() != nil || warned.Load() {
+		return v, err
+	}
+	if err := c.store(name, v); err != nil {
+		c.events.emitFrom(ctx, progress.Event{Kind: progress.Warning, Err: fmt.Errorf("cache: %w", err)})
+	}
+	return v, nil
+}
+
+// load reads the entry for name. Missing, expired and unreadable entries
+// are all misses.
+func load[T any](c *scopeCache, name string) (T, bool) {
+	var v T
+	if c.refresh {
+		return v, false
+	}
+	data, err := os.ReadFile(c.path(name)) // #nosec G304 -- path is built from the cache dir and a fixed entry name
+	if err != nil {
+		return v, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || c.now().Sub(entry.SavedAt) >= c.ttl {
+		return v, false
+	}
+	if err := json.Unmarshal(entry.Data, &v); err != nil {
+		return v, false
+	}
+	return v, true
+}
+
+// store writes the entry for name, replacing any older one atomically so
+// concurrent runs never read a partial file.
+func (c *scopeCache) store(name string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encoding %s: %w", name, err)
+	}
+	entry, err := json.Marshal(cacheEntry{SavedAt: c.now(), Data: data})
+	if err != nil {
+		return fmt.Errorf("encoding %s: %w", name, err)
+	}
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(c.dir, name+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(entry); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), c.path(name))
+}
+
+func (c *scopeCache) path(name string) string {
+	return filepath.Join(c.dir, name+".json")
+}
+
+func (c *scopeCache) count(hit bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if hit {
+		c.hits++
+	} else {
+		c.misses++
+	}
+}
+
+// use reports how many discoverer outputs came from the cache, or nil when
+// caching is disabled.
+func (c *scopeCache) use() *progress.CacheUse {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &progress.CacheUse{Hits: c.hits, Total: c.hits + c.misses, Dir: c.dir}
+}
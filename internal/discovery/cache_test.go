@@ -0,0 +1,100 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCached(t *testing.T) {
+	dctx := &Context{TenancyID: "tenancy-1", Region: "us-ashburn-1", CompartmentID: "tenancy-1", Profile: "DEFAULT"}
+	cache := &Cache{Dir: t.TempDir(), TTL: time.Hour}
+
+	var calls int
+	list := func() ([]Shape, error) {
+		calls++
+		return []Shape{{Name: "VM.Standard.E4.Flex"}}, nil
+	}
+
+	c := newScopeCache(cache, dctx, nil)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	if _, err := cached(context.Background(), c, "shapes", list); err != nil {
+		t.Fatalf("cached failed: %v", err)
+	}
+	shapes, err := cached(context.Background(), c, "shapes", list)
+	if err != nil || len(shapes) != 1 || shapes[0].Name != "VM.Standard.E4.Flex" {
+		t.Fatalf("expected the stored shapes, got %v, %v", shapes, err)
+	}
+	if calls != 1 {
+		t.Errorf("expected the second call to be served from the cache, fn called %d times", calls)
+	}
+	if c.hits != 1 || c.misses != 1 {
+		t.Errorf("expected 1 hit and 1 miss, got %d and %d", c.hits, c.misses)
+	}
+
+	t.Run("entries expire after the TTL", func(t *testing.T) {
+		later := newScopeCache(cache, dctx, nil)
+		later.now = func() time.Time { return now.Add(time.Hour) }
+		before := calls
+		_, _ = cached(context.Background(), later, "shapes", list)
+		if calls != before+1 {
+			t.Error("expected an expired entry to be fetched again")
+		}
+	})
+
+	t.Run("refresh ignores entries", func(t *testing.T) {
+		fresh := newScopeCache(&Cache{Dir: cache.Dir, TTL: time.Hour, Refresh: true}, dctx, nil)
+		before := calls
+		_, _ = cached(context.Background(), fresh, "shapes", list)
+		if calls != before+1 {
+			t.Error("expected --refresh to bypass the cache")
+		}
+	})
+
+	t.Run("scopes are separate", func(t *testing.T) {
+		other := *dctx
+		other.CompartmentID = "compartment-2"
+		before := calls
+		_, _ = cached(context.Background(), newScopeCache(cache, &other, nil), "shapes", list)
+		if calls != before+1 {
+			t.Error("expected another compartment not to share entries")
+		}
+	})
+
+	t.Run("failures are not stored", func(t *testing.T) {
+		c := newScopeCache(cache, dctx, nil)
+		errList := errors.New("list failed")
+		if _, err := cached(context.Background(), c, "vcns", func() ([]VCN, error) { return nil, errList }); err != errList {
+			t.Fatalf("expected the error to be returned, got %v", err)
+		}
+		if _, ok := load[[]VCN](c, "vcns"); ok {
+			t.Error("expected no entry after a failed call")
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, _ = cached(ctx, c, "vcns", func() ([]VCN, error) { return []VCN{{}}, nil })
+		if _, ok := load[[]VCN](c, "vcns"); ok {
+			t.Error("expected no entry for output gathered while canceled")
+		}
+
+		var warnings int
+		vcns, err := cachedWarn(context.Background(), c, "vcns", func(error) { warnings++ }, func(warn func(error)) ([]VCN, error) {
+			warn(errors.New("listing subnets failed"))
+			return []VCN{{}}, nil
+		})
+		if err != nil || len(vcns) != 1 || warnings != 1 {
+			t.Fatalf("expected the output and its warning passed on, got %v, %v and %d warnings", vcns, err, warnings)
+		}
+		if _, ok := load[[]VCN](c, "vcns"); ok {
+			t.Error("expected no entry for output gathered with a warning")
+		}
+	})
+
+	if newScopeCache(&Cache{Dir: cache.Dir}, dctx, nil) != nil {
+		t.Error("expected a zero TTL to disable the cache")
+	}
+}
@@ -0,0 +1,107 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+// A1 sizing checked by the capacity report: the always-free example instance
+// takes half of the 4 OCPU / 24 GB allowance.
+const (
+	AlwaysFreeA1OCPUs    float32 = 2
+	AlwaysFreeA1MemoryGB float32 = 12
+)
+
+// CapacityReport is host capacity for one shape and size in each availability
+// domain and fault domain, and the placement chosen from it.
+type CapacityReport struct {
+	Shape               string           `json:"shape"`
+	OCPUs               float32          `json:"ocpus"`
+	MemoryGB            float32          `json:"memory_gb"`
+	Domains             []DomainCapacity `json:"domains"`
+	SelectedAD          string           `json:"selected_availability_domain"`          // Empty when no AD reported capacity
+	SelectedFaultDomain string           `json:"selected_fault_domain"`                 // Empty when capacity was reported per AD
+	FailedADs           []string         `json:"failed_availability_domains,omitempty"` // ADs whose report could not be created
+}
+
+// DomainCapacity is the capacity report entry for one AD or fault domain.
+type DomainCapacity struct {
+	AvailabilityDomain string `json:"availability_domain"`
+	FaultDomain        string `json:"fault_domain"`    // Empty for an AD-wide entry
+	Status             string `json:"status"`          // AVAILABLE, OUT_OF_HOST_CAPACITY or HARDWARE_NOT_SUPPORTED
+	AvailableCount     int64  `json:"available_count"` // Instances of this size that fit
+}
+
+// discoverCapacity asks the compute capacity report API how many instances of
+// shape and size fit in each fault domain of each AD, and selects the AD and
+// fault domain with the most room. Capacity reports are created in the tenancy.
+// An AD whose report fails is recorded in FailedADs and passed to warn, and
+// the others are still checked; only when every AD fails is it an error.
+func discoverCapacity(ctx context.Context, client ComputeAPI, tenancyID string, ads []AvailabilityDomain, shape string, ocpus, memoryGB float32, warn func(error)) (*CapacityReport, error) {
+	report := &CapacityReport{Shape: shape, OCPUs: ocpus, MemoryGB: memoryGB}
+	config := &core.CapacityReportInstanceShapeConfig{Ocpus: common.Float32(ocpus), MemoryInGBs: common.Float32(memoryGB)}
+
+	var best int64
+	var failures []error
+	for _, ad := range ads {
+		var availabilities []core.CreateCapacityReportShapeAvailabilityDetails
+		for _, fd := range ad.FaultDomains {
+			availabilities = append(availabilities, core.CreateCapacityReportShapeAvailabilityDetails{
+				InstanceShape:       common.String(shape),
+				FaultDomain:         common.String(fd),
+				InstanceShapeConfig: config,
+			})
+		}
+		if len(availabilities) == 0 {
+			availabilities = []core.CreateCapacityReportShapeAvailabilityDetails{
+				{InstanceShape: common.String(shape), InstanceShapeConfig: config},
+			}
+		}
+
+		resp, err := client.CreateComputeCapacityReport(ctx, core.CreateComputeCapacityReportRequest{
+			CreateComputeCapacityReportDetails: core.CreateComputeCapacityReportDetails{
+				CompartmentId:       common.String(tenancyID),
+				AvailabilityDomain:  common.String(ad.Name),
+				ShapeAvailabilities: availabilities,
+			},
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			report.FailedADs = append(report.FailedADs, ad.Name)
+			failures = append(failures, fmt.Errorf("%s: %w", ad.Name, err))
+			continue
+		}
+
+		for _, a := range resp.ShapeAvailabilities {
+			d := DomainCapacity{
+				AvailabilityDomain: ad.Name,
+				FaultDomain:        safeString(a.FaultDomain),
+				Status:             string(a.AvailabilityStatus),
+			}
+			if a.AvailableCount != nil {
+				d.AvailableCount = *a.AvailableCount
+			}
+			report.Domains = append(report.Domains, d)
+
+			if a.AvailabilityStatus == core.CapacityReportShapeAvailabilityAvailabilityStatusAvailable && d.AvailableCount > best {
+				best = d.AvailableCount
+				report.SelectedAD = d.AvailabilityDomain
+				report.SelectedFaultDomain = d.FaultDomain
+			}
+		}
+	}
+
+	if len(failures) > 0 && len(failures) == len(ads) {
+		return nil, errors.Join(failures...)
+	}
+	for _, err := range failures {
+		warn(classifyOCIError(shape+" capacity report", err))
+	}
+	return report, nil
+}
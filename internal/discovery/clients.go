@@ -0,0 +1,341 @@
+package discovery
+
+import (
+	"context"
+
+	"github.com/oracle/oci-go-sdk/v65/artifacts"
+	"github.com/oracle/oci-go-sdk/v65/budget"
+	"github.com/oracle/oci-go-sdk/v65/containerengine"
+	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/oracle/oci-go-sdk/v65/dns"
+	"github.com/oracle/oci-go-sdk/v65/functions"
+	"github.com/oracle/oci-go-sdk/v65/identity"
+	lim "github.com/oracle/oci-go-sdk/v65/limits"
+	"github.com/oracle/oci-go-sdk/v65/logging"
+	"github.com/oracle/oci-go-sdk/v65/monitoring"
+	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+	"github.com/oracle/oci-go-sdk/v65/ons"
+)
+
+// wrap returns Clients whose every call goes through c. Each service has its
+// own circuit and statistics, named after the Clients field.
+func (clients *Clients) wrap(c *caller) *Clients {
+	return &Clients{
+		Identity:        calledIdentity{clients.Identity, c},
+		Compute:         calledCompute{clients.Compute, c},
+		VirtualNetwork:  calledVirtualNetwork{clients.VirtualNetwork, c},
+		Blockstorage:    calledBlockstorage{clients.Blockstorage, c},
+		Limits:          calledLimits{clients.Limits, c},
+		ContainerEngine: calledContainerEngine{clients.ContainerEngine, c},
+		Artifacts:       calledArtifacts{clients.Artifacts, c},
+		ObjectStorage:   calledObjectStorage{clients.ObjectStorage, c},
+		Functions:       calledFunctions{clients.Functions, c},
+		DNS:             calledDNS{clients.DNS, c},
+		Logging:         calledLogging{clients.Logging, c},
+		Notifications:   calledNotification{clients.Notifications, c},
+		Monitoring:      calledMonitoring{clients.Monitoring, c},
+		Budget:          calledBudget{clients.Budget, c},
+		Quotas:          calledQuotas{clients.Quotas, c},
+	}
+}
+
+type calledIdentity struct {
+	next IdentityAPI
+	c    *caller
+}
+
+func (x calledIdentity) ListCompartments(ctx context.Context, request identity.ListCompartmentsRequest) (identity.ListCompartmentsResponse, error) {
+	return invoke(ctx, x.c, "identity", func(ctx context.Context) (identity.ListCompartmentsResponse, error) {
+		return x.next.ListCompartments(ctx, request)
+	})
+}
+
+func (x calledIdentity) ListAvailabilityDomains(ctx context.Context, request identity.ListAvailabilityDomainsRequest) (identity.ListAvailabilityDomainsResponse, error) {
+	return invoke(ctx, x.c, "identity", func(ctx context.Context) (identity.ListAvailabilityDomainsResponse, error) {
+		return x.next.ListAvailabilityDomains(ctx, request)
+	})
+}
+
+func (x calledIdentity) ListFaultDomains(ctx context.Context, request identity.ListFaultDomainsRequest) (identity.ListFaultDomainsResponse, error) {
+	return invoke(ctx, x.c, "identity", func(ctx context.Context) (identity.ListFaultDomainsResponse, error) {
+		return x.next.ListFaultDomains(ctx, request)
+	})
+}
+
+func (x calledIdentity) GetTenancy(ctx context.Context, request identity.GetTenancyRequest) (identity.GetTenancyResponse, error) {
+	return invoke(ctx, x.c, "identity", func(ctx context.Context) (identity.GetTenancyResponse, error) { return x.next.GetTenancy(ctx, request) })
+}
+
+func (x calledIdentity) ListGroups(ctx context.Context, request identity.ListGroupsRequest) (identity.ListGroupsResponse, error) {
+	return invoke(ctx, x.c, "identity", func(ctx context.Context) (identity.ListGroupsResponse, error) { return x.next.ListGroups(ctx, request) })
+}
+
+func (x calledIdentity) ListDynamicGroups(ctx context.Context, request identity.ListDynamicGroupsRequest) (identity.ListDynamicGroupsResponse, error) {
+	return invoke(ctx, x.c, "identity", func(ctx context.Context) (identity.ListDynamicGroupsResponse, error) {
+		return x.next.ListDynamicGroups(ctx, request)
+	})
+}
+
+func (x calledIdentity) ListPolicies(ctx context.Context, request identity.ListPoliciesRequest) (identity.ListPoliciesResponse, error) {
+	return invoke(ctx, x.c, "identity", func(ctx context.Context) (identity.ListPoliciesResponse, error) {
+		return x.next.ListPolicies(ctx, request)
+	})
+}
+
+func (x calledIdentity) ListTagNamespaces(ctx context.Context, request identity.ListTagNamespacesRequest) (identity.ListTagNamespacesResponse, error) {
+	return invoke(ctx, x.c, "identity", func(ctx context.Context) (identity.ListTagNamespacesResponse, error) {
+		return x.next.ListTagNamespaces(ctx, request)
+	})
+}
+
+func (x calledIdentity) ListTags(ctx context.Context, request identity.ListTagsRequest) (identity.ListTagsResponse, error) {
+	return invoke(ctx, x.c, "identity", func(ctx context.Context) (identity.ListTagsResponse, error) { return x.next.ListTags(ctx, request) })
+}
+
+func (x calledIdentity) GetTag(ctx context.Context, request identity.GetTagRequest) (identity.GetTagResponse, error) {
+	return invoke(ctx, x.c, "identity", func(ctx context.Context) (identity.GetTagResponse, error) { return x.next.GetTag(ctx, request) })
+}
+
+func (x calledIdentity) ListTagDefaults(ctx context.Context, request identity.ListTagDefaultsRequest) (identity.ListTagDefaultsResponse, error) {
+	return invoke(ctx, x.c, "identity", func(ctx context.Context) (identity.ListTagDefaultsResponse, error) {
+		return x.next.ListTagDefaults(ctx, request)
+	})
+}
+
+func (x calledIdentity) ListRegions(ctx context.Context) (identity.ListRegionsResponse, error) {
+	return invoke(ctx, x.c, "identity", func(ctx context.Context) (identity.ListRegionsResponse, error) { return x.next.ListRegions(ctx) })
+}
+
+type calledCompute struct {
+	next ComputeAPI
+	c    *caller
+}
+
+func (x calledCompute) ListShapes(ctx context.Context, request core.ListShapesRequest) (core.ListShapesResponse, error) {
+	return invoke(ctx, x.c, "compute", func(ctx context.Context) (core.ListShapesResponse, error) { return x.next.ListShapes(ctx, request) })
+}
+
+func (x calledCompute) ListImages(ctx context.Context, request core.ListImagesRequest) (core.ListImagesResponse, error) {
+	return invoke(ctx, x.c, "compute", func(ctx context.Context) (core.ListImagesResponse, error) { return x.next.ListImages(ctx, request) })
+}
+
+func (x calledCompute) CreateComputeCapacityReport(ctx context.Context, request core.CreateComputeCapacityReportRequest) (core.CreateComputeCapacityReportResponse, error) {
+	return invoke(ctx, x.c, "compute", func(ctx context.Context) (core.CreateComputeCapacityReportResponse, error) {
+		return x.next.CreateComputeCapacityReport(ctx, request)
+	})
+}
+
+type calledVirtualNetwork struct {
+	next VirtualNetworkAPI
+	c    *caller
+}
+
+func (x calledVirtualNetwork) ListVcns(ctx context.Context, request core.ListVcnsRequest) (core.ListVcnsResponse, error) {
+	return invoke(ctx, x.c, "network", func(ctx context.Context) (core.ListVcnsResponse, error) { return x.next.ListVcns(ctx, request) })
+}
+
+func (x calledVirtualNetwork) ListSubnets(ctx context.Context, request core.ListSubnetsRequest) (core.ListSubnetsResponse, error) {
+	return invoke(ctx, x.c, "network", func(ctx context.Context) (core.ListSubnetsResponse, error) { return x.next.ListSubnets(ctx, request) })
+}
+
+func (x calledVirtualNetwork) ListSecurityLists(ctx context.Context, request core.ListSecurityListsRequest) (core.ListSecurityListsResponse, error) {
+	return invoke(ctx, x.c, "network", func(ctx context.Context) (core.ListSecurityListsResponse, error) {
+		return x.next.ListSecurityLists(ctx, request)
+	})
+}
+
+func (x calledVirtualNetwork) ListRouteTables(ctx context.Context, request core.ListRouteTablesRequest) (core.ListRouteTablesResponse, error) {
+	return invoke(ctx, x.c, "network", func(ctx context.Context) (core.ListRouteTablesResponse, error) {
+		return x.next.ListRouteTables(ctx, request)
+	})
+}
+
+func (x calledVirtualNetwork) ListInternetGateways(ctx context.Context, request core.ListInternetGatewaysRequest) (core.ListInternetGatewaysResponse, error) {
+	return invoke(ctx, x.c, "network", func(ctx context.Context) (core.ListInternetGatewaysResponse, error) {
+		return x.next.ListInternetGateways(ctx, request)
+	})
+}
+
+func (x calledVirtualNetwork) ListNatGateways(ctx context.Context, request core.ListNatGatewaysRequest) (core.ListNatGatewaysResponse, error) {
+	return invoke(ctx, x.c, "network", func(ctx context.Context) (core.ListNatGatewaysResponse, error) {
+		return x.next.ListNatGateways(ctx, request)
+	})
+}
+
+type calledBlockstorage struct {
+	next BlockstorageAPI
+	c    *caller
+}
+
+func (x calledBlockstorage) ListVolumes(ctx context.Context, request core.ListVolumesRequest) (core.ListVolumesResponse, error) {
+	return invoke(ctx, x.c, "blockstorage", func(ctx context.Context) (core.ListVolumesResponse, error) { return x.next.ListVolumes(ctx, request) })
+}
+
+func (x calledBlockstorage) ListBootVolumes(ctx context.Context, request core.ListBootVolumesRequest) (core.ListBootVolumesResponse, error) {
+	return invoke(ctx, x.c, "blockstorage", func(ctx context.Context) (core.ListBootVolumesResponse, error) {
+		return x.next.ListBootVolumes(ctx, request)
+	})
+}
+
+func (x calledBlockstorage) ListVolumeBackupPolicies(ctx context.Context, request core.ListVolumeBackupPoliciesRequest) (core.ListVolumeBackupPoliciesResponse, error) {
+	return invoke(ctx, x.c, "blockstorage", func(ctx context.Context) (core.ListVolumeBackupPoliciesResponse, error) {
+		return x.next.ListVolumeBackupPolicies(ctx, request)
+	})
+}
+
+func (x calledBlockstorage) ListVolumeGroups(ctx context.Context, request core.ListVolumeGroupsRequest) (core.ListVolumeGroupsResponse, error) {
+	return invoke(ctx, x.c, "blockstorage", func(ctx context.Context) (core.ListVolumeGroupsResponse, error) {
+		return x.next.ListVolumeGroups(ctx, request)
+	})
+}
+
+type calledLimits struct {
+	next LimitsAPI
+	c    *caller
+}
+
+func (x calledLimits) ListLimitValues(ctx context.Context, request lim.ListLimitValuesRequest) (lim.ListLimitValuesResponse, error) {
+	return invoke(ctx, x.c, "limits", func(ctx context.Context) (lim.ListLimitValuesResponse, error) {
+		return x.next.ListLimitValues(ctx, request)
+	})
+}
+
+type calledContainerEngine struct {
+	next ContainerEngineAPI
+	c    *caller
+}
+
+func (x calledContainerEngine) GetNodePoolOptions(ctx context.Context, request containerengine.GetNodePoolOptionsRequest) (containerengine.GetNodePoolOptionsResponse, error) {
+	return invoke(ctx, x.c, "containerengine", func(ctx context.Context) (containerengine.GetNodePoolOptionsResponse, error) {
+		return x.next.GetNodePoolOptions(ctx, request)
+	})
+}
+
+func (x calledContainerEngine) ListClusters(ctx context.Context, request containerengine.ListClustersRequest) (containerengine.ListClustersResponse, error) {
+	return invoke(ctx, x.c, "containerengine", func(ctx context.Context) (containerengine.ListClustersResponse, error) {
+		return x.next.ListClusters(ctx, request)
+	})
+}
+
+func (x calledContainerEngine) ListNodePools(ctx context.Context, request containerengine.ListNodePoolsRequest) (containerengine.ListNodePoolsResponse, error) {
+	return invoke(ctx, x.c, "containerengine", func(ctx context.Context) (containerengine.ListNodePoolsResponse, error) {
+		return x.next.ListNodePools(ctx, request)
+	})
+}
+
+type calledArtifacts struct {
+	next ArtifactsAPI
+	c    *caller
+}
+
+func (x calledArtifacts) ListContainerRepositories(ctx context.Context, request artifacts.ListContainerRepositoriesRequest) (artifacts.ListContainerRepositoriesResponse, error) {
+	return invoke(ctx, x.c, "artifacts", func(ctx context.Context) (artifacts.ListContainerRepositoriesResponse, error) {
+		return x.next.ListContainerRepositories(ctx, request)
+	})
+}
+
+type calledObjectStorage struct {
+	next ObjectStorageAPI
+	c    *caller
+}
+
+func (x calledObjectStorage) GetNamespace(ctx context.Context, request objectstorage.GetNamespaceRequest) (objectstorage.GetNamespaceResponse, error) {
+	return invoke(ctx, x.c, "objectstorage", func(ctx context.Context) (objectstorage.GetNamespaceResponse, error) {
+		return x.next.GetNamespace(ctx, request)
+	})
+}
+
+type calledFunctions struct {
+	next FunctionsAPI
+	c    *caller
+}
+
+func (x calledFunctions) ListApplications(ctx context.Context, request functions.ListApplicationsRequest) (functions.ListApplicationsResponse, error) {
+	return invoke(ctx, x.c, "functions", func(ctx context.Context) (functions.ListApplicationsResponse, error) {
+		return x.next.ListApplications(ctx, request)
+	})
+}
+
+type calledDNS struct {
+	next DNSAPI
+	c    *caller
+}
+
+func (x calledDNS) ListZones(ctx context.Context, request dns.ListZonesRequest) (dns.ListZonesResponse, error) {
+	return invoke(ctx, x.c, "dns", func(ctx context.Context) (dns.ListZonesResponse, error) { return x.next.ListZones(ctx, request) })
+}
+
+func (x calledDNS) ListViews(ctx context.Context, request dns.ListViewsRequest) (dns.ListViewsResponse, error) {
+	return invoke(ctx, x.c, "dns", func(ctx context.Context) (dns.ListViewsResponse, error) { return x.next.ListViews(ctx, request) })
+}
+
+func (x calledDNS) ListResolvers(ctx context.Context, request dns.ListResolversRequest) (dns.ListResolversResponse, error) {
+	return invoke(ctx, x.c, "dns", func(ctx context.Context) (dns.ListResolversResponse, error) {
+		return x.next.ListResolvers(ctx, request)
+	})
+}
+
+func (x calledDNS) ListResolverEndpoints(ctx context.Context, request dns.ListResolverEndpointsRequest) (dns.ListResolverEndpointsResponse, error) {
+	return invoke(ctx, x.c, "dns", func(ctx context.Context) (dns.ListResolverEndpointsResponse, error) {
+		return x.next.ListResolverEndpoints(ctx, request)
+	})
+}
+
+type calledLogging struct {
+	next LoggingAPI
+	c    *caller
+}
+
+func (x calledLogging) ListLogGroups(ctx context.Context, request logging.ListLogGroupsRequest) (logging.ListLogGroupsResponse, error) {
+	return invoke(ctx, x.c, "logging", func(ctx context.Context) (logging.ListLogGroupsResponse, error) {
+		return x.next.ListLogGroups(ctx, request)
+	})
+}
+
+type calledNotification struct {
+	next NotificationAPI
+	c    *caller
+}
+
+func (x calledNotification) ListTopics(ctx context.Context, request ons.ListTopicsRequest) (ons.ListTopicsResponse, error) {
+	return invoke(ctx, x.c, "notifications", func(ctx context.Context) (ons.ListTopicsResponse, error) { return x.next.ListTopics(ctx, request) })
+}
+
+type calledMonitoring struct {
+	next MonitoringAPI
+	c    *caller
+}
+
+func (x calledMonitoring) ListAlarms(ctx context.Context, request monitoring.ListAlarmsRequest) (monitoring.ListAlarmsResponse, error) {
+	return invoke(ctx, x.c, "monitoring", func(ctx context.Context) (monitoring.ListAlarmsResponse, error) {
+		return x.next.ListAlarms(ctx, request)
+	})
+}
+
+type calledBudget struct {
+	next BudgetAPI
+	c    *caller
+}
+
+func (x calledBudget) ListBudgets(ctx context.Context, request budget.ListBudgetsRequest) (budget.ListBudgetsResponse, error) {
+	return invoke(ctx, x.c, "budget", func(ctx context.Context) (budget.ListBudgetsResponse, error) { return x.next.ListBudgets(ctx, request) })
+}
+
+func (x calledBudget) ListAlertRules(ctx context.Context, request budget.ListAlertRulesRequest) (budget.ListAlertRulesResponse, error) {
+	return invoke(ctx, x.c, "budget", func(ctx context.Context) (budget.ListAlertRulesResponse, error) {
+		return x.next.ListAlertRules(ctx, request)
+	})
+}
+
+type calledQuotas struct {
+	next QuotasAPI
+	c    *caller
+}
+
+func (x calledQuotas) ListQuotas(ctx context.Context, request lim.ListQuotasRequest) (lim.ListQuotasResponse, error) {
+	return invoke(ctx, x.c, "quotas", func(ctx context.Context) (lim.ListQuotasResponse, error) { return x.next.ListQuotas(ctx, request) })
+}
+
+func (x calledQuotas) GetQuota(ctx context.Context, request lim.GetQuotaRequest) (lim.GetQuotaResponse, error) {
+	return invoke(ctx, x.c, "quotas", func(ctx context.Context) (lim.GetQuotaResponse, error) { return x.next.GetQuota(ctx, request) })
+}
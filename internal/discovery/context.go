@@ -49,5 +49,7 @@ func NewContext(profile, configPath, regionOverride, compartmentID string, alway
 		AlwaysFree:    alwaysFree,
 		OKE:           oke,
 		CompartmentID: compID,
+		Retry:         DefaultRetryPolicy(),
+		Schedule:      DefaultSchedulePolicy(),
 	}, nil
 }
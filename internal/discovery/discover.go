@@ -4,13 +4,24 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 
+	"github.com/oracle/oci-go-sdk/v65/artifacts"
+	"github.com/oracle/oci-go-sdk/v65/budget"
 	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/oracle/oci-go-sdk/v65/containerengine"
 	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/oracle/oci-go-sdk/v65/dns"
+	"github.com/oracle/oci-go-sdk/v65/functions"
 	"github.com/oracle/oci-go-sdk/v65/identity"
 	lim "github.com/oracle/oci-go-sdk/v65/limits"
+	"github.com/oracle/oci-go-sdk/v65/logging"
+	"github.com/oracle/oci-go-sdk/v65/monitoring"
+	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+	"github.com/oracle/oci-go-sdk/v65/ons"
 )
 
 func safeString(s *string) string {
@@ -20,6 +31,19 @@ func safeString(s *string) string {
 	return *s
 }
 
+func safeBool(b *bool) bool {
+	return b != nil && *b
+}
+
+// safeFloat widens an SDK float32 without float32 rounding noise (0.1 stays 0.1).
+func safeFloat(f *float32) float64 {
+	if f == nil {
+		return 0
+	}
+	v, _ := strconv.ParseFloat(strconv.FormatFloat(float64(*f), 'g', -1, 32), 64)
+	return v
+}
+
 func discoverCompartments(ctx context.Context, client IdentityAPI, tenancyID string) ([]Compartment, error) {
 	req := identity.ListCompartmentsRequest{
 		CompartmentId:          &tenancyID,
@@ -40,6 +64,7 @@ func discoverCompartments(ctx context.Context, client IdentityAPI, tenancyID str
 				Name:        *c.Name,
 				Description: safeString(c.Description),
 				ParentID:    safeString(c.CompartmentId),
+				Tags:        newTags(c.FreeformTags, c.DefinedTags),
 			})
 		}
 
@@ -51,6 +76,253 @@ func discoverCompartments(ctx context.Context, client IdentityAPI, tenancyID str
 	return compartments, nil
 }
 
+func discoverGroups(ctx context.Context, client IdentityAPI, tenancyID string) ([]Group, error) {
+	req := identity.ListGroupsRequest{
+		CompartmentId: &tenancyID,
+	}
+
+	var groups []Group
+	for {
+		resp, err := client.ListGroups(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, g := range resp.Items {
+			groups = append(groups, Group{
+				ID:          *g.Id,
+				Name:        safeString(g.Name),
+				Description: safeString(g.Description),
+				Tags:        newTags(g.FreeformTags, g.DefinedTags),
+			})
+		}
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		req.Page = resp.OpcNextPage
+	}
+	return groups, nil
+}
+
+func discoverDynamicGroups(ctx context.Context, client IdentityAPI, tenancyID string) ([]DynamicGroup, error) {
+	req := identity.ListDynamicGroupsRequest{
+		CompartmentId: &tenancyID,
+	}
+
+	var groups []DynamicGroup
+	for {
+		resp, err := client.ListDynamicGroups(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, g := range resp.Items {
+			groups = append(groups, DynamicGroup{
+				ID:           *g.Id,
+				Name:         safeString(g.Name),
+				Description:  safeString(g.Description),
+				MatchingRule: safeString(g.MatchingRule),
+				Tags:         newTags(g.FreeformTags, g.DefinedTags),
+			})
+		}
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		req.Page = resp.OpcNextPage
+	}
+	return groups, nil
+}
+
+// discoverPolicies lists policies attached to the tenancy root and to every
+// compartment in the tree. Policies can only be listed per compartment, so a
+// failure in one compartment is skipped rather than failing the whole tree.
+func discoverPolicies(ctx context.Context, client IdentityAPI, tenancyID string, compartments []Compartment) ([]Policy, error) {
+	type target struct{ id, name string }
+	targets := []target{{tenancyID, "root"}}
+	for _, c := range compartments {
+		targets = append(targets, target{c.ID, c.Name})
+	}
+
+	var policies []Policy
+	for i, t := range targets {
+		req := identity.ListPoliciesRequest{
+			CompartmentId:  common.String(t.id),
+			LifecycleState: identity.PolicyLifecycleStateActive,
+		}
+
+		for {
+			resp, err := client.ListPolicies(ctx, req)
+			if err != nil {
+				// The tenancy root is always readable by a user who can read policies,
+				// so only an error there means policies are inaccessible altogether.
+				if i == 0 {
+					return nil, err
+				}
+				break
+			}
+
+			for _, p := range resp.Items {
+				policies = append(policies, Policy{
+					ID:              *p.Id,
+					Name:            safeString(p.Name),
+					Description:     safeString(p.Description),
+					CompartmentID:   t.id,
+					CompartmentName: t.name,
+					Statements:      p.Statements,
+					Tags:            newTags(p.FreeformTags, p.DefinedTags),
+				})
+			}
+
+			if resp.OpcNextPage == nil {
+				break
+			}
+			req.Page = resp.OpcNextPage
+		}
+	}
+	return policies, nil
+}
+
+// discoverTagNamespaces lists tag namespaces across the tenancy with their tag
+// definitions. Tags are fetched individually because only the full tag carries
+// its validator, which holds the allowed values of enum tags. A tag whose
+// validator cannot be read is passed to warn and kept without allowed values.
+func discoverTagNamespaces(ctx context.Context, client IdentityAPI, tenancyID string, warn func(error)) ([]TagNamespace, error) {
+	req := identity.ListTagNamespacesRequest{
+		CompartmentId:          &tenancyID,
+		IncludeSubcompartments: common.Bool(true),
+	}
+
+	var namespaces []TagNamespace
+	for {
+		resp, err := client.ListTagNamespaces(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, n := range resp.Items {
+			ns := TagNamespace{
+				ID:            *n.Id,
+				Name:          safeString(n.Name),
+				Description:   safeString(n.Description),
+				CompartmentID: safeString(n.CompartmentId),
+				Tags:          newTags(n.FreeformTags, n.DefinedTags),
+			}
+			if n.IsRetired != nil {
+				ns.IsRetired = *n.IsRetired
+			}
+
+			defs, err := discoverTagDefinitions(ctx, client, ns, warn)
+			if err != nil {
+				return nil, err
+			}
+			ns.Definitions = defs
+			namespaces = append(namespaces, ns)
+		}
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		req.Page = resp.OpcNextPage
+	}
+	return namespaces, nil
+}
+
+func discoverTagDefinitions(ctx context.Context, client IdentityAPI, ns TagNamespace, warn func(error)) ([]TagDefinition, error) {
+	req := identity.ListTagsRequest{
+		TagNamespaceId: &ns.ID,
+	}
+
+	var defs []TagDefinition
+	for {
+		resp, err := client.ListTags(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, t := range resp.Items {
+			def := TagDefinition{
+				ID:          *t.Id,
+				Name:        safeString(t.Name),
+				Description: safeString(t.Description),
+			}
+			if t.IsCostTracking != nil {
+				def.IsCostTracking = *t.IsCostTracking
+			}
+			if t.IsRetired != nil {
+				def.IsRetired = *t.IsRetired
+			}
+
+			tag, err := client.GetTag(ctx, identity.GetTagRequest{
+				TagNamespaceId: &ns.ID,
+				TagName:        t.Name,
+			})
+			if err != nil {
+				warn(classifyOCIError(fmt.Sprintf("allowed values of tag %s.%s", ns.Name, def.Name), err))
+			} else if enum, ok := tag.Validator.(identity.EnumTagDefinitionValidator); ok {
+				def.AllowedValues = enum.Values
+			}
+			defs = append(defs, def)
+		}
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		req.Page = resp.OpcNextPage
+	}
+	return defs, nil
+}
+
+// discoverTagDefaults lists tag defaults set on the tenancy root and, when
+// different, the target compartment, resolving tag names through namespaces.
+func discoverTagDefaults(ctx context.Context, client IdentityAPI, tenancyID, compartmentID string, namespaces []TagNamespace) ([]TagDefault, error) {
+	nsNames := make(map[string]string, len(namespaces))
+	for _, ns := range namespaces {
+		nsNames[ns.ID] = ns.Name
+	}
+
+	targets := []string{tenancyID}
+	if compartmentID != "" && compartmentID != tenancyID {
+		targets = append(targets, compartmentID)
+	}
+
+	var defaults []TagDefault
+	for _, compID := range targets {
+		req := identity.ListTagDefaultsRequest{
+			CompartmentId:  common.String(compID),
+			LifecycleState: identity.TagDefaultSummaryLifecycleStateActive,
+		}
+
+		for {
+			resp, err := client.ListTagDefaults(ctx, req)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, d := range resp.Items {
+				td := TagDefault{
+					ID:            *d.Id,
+					CompartmentID: safeString(d.CompartmentId),
+					Namespace:     nsNames[safeString(d.TagNamespaceId)],
+					Key:           safeString(d.TagDefinitionName),
+					Value:         safeString(d.Value),
+				}
+				if d.IsRequired != nil {
+					td.IsRequired = *d.IsRequired
+				}
+				defaults = append(defaults, td)
+			}
+
+			if resp.OpcNextPage == nil {
+				break
+			}
+			req.Page = resp.OpcNextPage
+		}
+	}
+	return defaults, nil
+}
+
 func discoverADs(ctx context.Context, client IdentityAPI, tenancyID string) ([]AvailabilityDomain, error) {
 	req := identity.ListAvailabilityDomainsRequest{
 		CompartmentId: &tenancyID,
@@ -141,7 +413,9 @@ func discoverShapes(ctx context.Context, client ComputeAPI, compartmentID string
 	return shapes, nil
 }
 
-func discoverImages(ctx context.Context, client ComputeAPI, compartmentID string) ([]Image, error) {
+// discoverImages lists the newest image per OS version. Tag filters apply only
+// to custom images; platform images belong to no compartment and carry no tags.
+func discoverImages(ctx context.Context, client ComputeAPI, compartmentID string, filters []TagFilter) ([]Image, error) {
 	osList := []string{"Oracle Linux", "Canonical Ubuntu", "CentOS", "Windows"}
 	var images []Image
 
@@ -162,6 +436,9 @@ func discoverImages(ctx context.Context, client ComputeAPI, compartmentID string
 			}
 
 			for _, img := range resp.Items {
+				if img.CompartmentId != nil && !newTags(img.FreeformTags, img.DefinedTags).MatchesTagFilters(filters) {
+					continue
+				}
 				version := safeString(img.OperatingSystemVersion)
 				key := osName + "-" + version
 				if seenVersions[key] {
@@ -174,6 +451,7 @@ func discoverImages(ctx context.Context, client ComputeAPI, compartmentID string
 					DisplayName: safeString(img.DisplayName),
 					OS:          safeString(img.OperatingSystem),
 					OSVersion:   version,
+					Tags:        newTags(img.FreeformTags, img.DefinedTags),
 				}
 				if img.TimeCreated != nil {
 					image.TimeCreated = img.TimeCreated.String()
@@ -193,7 +471,13 @@ func discoverImages(ctx context.Context, client ComputeAPI, compartmentID string
 	return images, nil
 }
 
-func discoverVCNs(ctx context.Context, client VirtualNetworkAPI, compartmentID string) ([]VCN, error) {
+// discoverVCNs lists VCNs and their networking resources. VCNs not matching the
+// tag filters are skipped before their child resources are listed. Child
+// resources of every VCN are fetched concurrently; the client's scheduler
+// bounds how many calls actually run at once. A child listing that fails is
+// passed to warn, which must be safe for concurrent use, and the VCN is kept
+// without those resources.
+func discoverVCNs(ctx context.Context, client VirtualNetworkAPI, compartmentID string, filters []TagFilter, warn func(error)) ([]VCN, error) {
 	req := core.ListVcnsRequest{
 		CompartmentId: &compartmentID,
 	}
@@ -212,56 +496,71 @@ func discoverVCNs(ctx context.Context, client VirtualNetworkAPI, compartmentID s
 				CIDRBlock:     safeString(v.CidrBlock),
 				CompartmentID: *v.CompartmentId,
 				DNSLabel:      safeString(v.DnsLabel),
+				Tags:          newTags(v.FreeformTags, v.DefinedTags),
+			}
+			if vcn.MatchesTagFilters(filters) {
+				vcns = append(vcns, vcn)
 			}
+		}
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		req.Page = resp.OpcNextPage
+	}
+
+	// Each goroutine writes a different field of its VCN, so no locking is needed.
+	var wg sync.WaitGroup
+	for i := range vcns {
+		vcn := &vcns[i]
 
-			// Discover subnets
-			subnets, err := discoverSubnets(ctx, client, *v.CompartmentId, *v.Id)
+		wg.Go(func() {
+			subnets, err := discoverSubnets(ctx, client, vcn.CompartmentID, vcn.ID)
 			if err != nil {
-				fmt.Printf("    ⚠ Could not list subnets for VCN %s: %v\n", safeString(v.DisplayName), err)
-			} else {
-				vcn.Subnets = subnets
+				warn(classifyOCIError(fmt.Sprintf("subnet discovery for VCN %s", vcn.DisplayName), err))
+				return
 			}
+			vcn.Subnets = filterByTags(subnets, filters)
+		})
 
-			// Discover security lists
-			secLists, err := discoverSecurityLists(ctx, client, *v.CompartmentId, *v.Id)
+		wg.Go(func() {
+			secLists, err := discoverSecurityLists(ctx, client, vcn.CompartmentID, vcn.ID)
 			if err != nil {
-				fmt.Printf("    ⚠ Could not list security lists for VCN %s: %v\n", safeString(v.DisplayName), err)
-			} else {
-				vcn.SecurityLists = secLists
+				warn(classifyOCIError(fmt.Sprintf("security list discovery for VCN %s", vcn.DisplayName), err))
+				return
 			}
+			vcn.SecurityLists = filterByTags(secLists, filters)
+		})
 
-			// Discover route tables
-			routeTables, err := discoverRouteTables(ctx, client, *v.CompartmentId, *v.Id)
+		wg.Go(func() {
+			routeTables, err := discoverRouteTables(ctx, client, vcn.CompartmentID, vcn.ID)
 			if err != nil {
-				fmt.Printf("    ⚠ Could not list route tables for VCN %s: %v\n", safeString(v.DisplayName), err)
-			} else {
-				vcn.RouteTables = routeTables
+				warn(classifyOCIError(fmt.Sprintf("route table discovery for VCN %s", vcn.DisplayName), err))
+				return
 			}
+			vcn.RouteTables = filterByTags(routeTables, filters)
+		})
 
-			// Discover internet gateway
-			igw, err := discoverInternetGateway(ctx, client, *v.CompartmentId, *v.Id)
+		wg.Go(func() {
+			igw, err := discoverInternetGateway(ctx, client, vcn.CompartmentID, vcn.ID)
 			if err != nil {
-				fmt.Printf("    ⚠ Could not list internet gateway for VCN %s: %v\n", safeString(v.DisplayName), err)
-			} else {
+				warn(classifyOCIError(fmt.Sprintf("internet gateway discovery for VCN %s", vcn.DisplayName), err))
+			} else if igw != nil && igw.MatchesTagFilters(filters) {
 				vcn.InternetGateway = igw
 			}
+		})
 
-			// Discover NAT gateway
-			nat, err := discoverNATGateway(ctx, client, *v.CompartmentId, *v.Id)
+		wg.Go(func() {
+			nat, err := discoverNATGateway(ctx, client, vcn.CompartmentID, vcn.ID)
 			if err != nil {
-				fmt.Printf("    ⚠ Could not list NAT gateway for VCN %s: %v\n", safeString(v.DisplayName), err)
-			} else {
+				warn(classifyOCIError(fmt.Sprintf("NAT gateway discovery for VCN %s", vcn.DisplayName), err))
+			} else if nat != nil && nat.MatchesTagFilters(filters) {
 				vcn.NATGateway = nat
 			}
-
-			vcns = append(vcns, vcn)
-		}
-
-		if resp.OpcNextPage == nil {
-			break
-		}
-		req.Page = resp.OpcNextPage
+		})
 	}
+	wg.Wait()
+
 	return vcns, nil
 }
 
@@ -285,6 +584,7 @@ func discoverSubnets(ctx context.Context, client VirtualNetworkAPI, compartmentI
 			AvailabilityDomain: safeString(s.AvailabilityDomain),
 			IsPublic:           !*s.ProhibitPublicIpOnVnic,
 			DNSLabel:           safeString(s.DnsLabel),
+			Tags:               newTags(s.FreeformTags, s.DefinedTags),
 		})
 	}
 	return subnets, nil
@@ -307,6 +607,7 @@ func discoverSecurityLists(ctx context.Context, client VirtualNetworkAPI, compar
 			secList := SecurityList{
 				ID:          *sl.Id,
 				DisplayName: safeString(sl.DisplayName),
+				Tags:        newTags(sl.FreeformTags, sl.DefinedTags),
 			}
 
 			for _, rule := range sl.IngressSecurityRules {
@@ -371,6 +672,7 @@ func discoverRouteTables(ctx context.Context, client VirtualNetworkAPI, compartm
 			routeTable := RouteTable{
 				ID:          *rt.Id,
 				DisplayName: safeString(rt.DisplayName),
+				Tags:        newTags(rt.FreeformTags, rt.DefinedTags),
 			}
 
 			for _, rule := range rt.RouteRules {
@@ -414,6 +716,7 @@ func discoverInternetGateway(ctx context.Context, client VirtualNetworkAPI, comp
 		ID:          *igw.Id,
 		DisplayName: safeString(igw.DisplayName),
 		IsEnabled:   *igw.IsEnabled,
+		Tags:        newTags(igw.FreeformTags, igw.DefinedTags),
 	}, nil
 }
 
@@ -438,6 +741,7 @@ func discoverNATGateway(ctx context.Context, client VirtualNetworkAPI, compartme
 		DisplayName:  safeString(nat.DisplayName),
 		PublicIP:     safeString(nat.NatIp),
 		BlockTraffic: *nat.BlockTraffic,
+		Tags:         newTags(nat.FreeformTags, nat.DefinedTags),
 	}, nil
 }
 
@@ -458,6 +762,7 @@ func discoverBlockVolumes(ctx context.Context, client BlockstorageAPI, compartme
 				ID:                 *v.Id,
 				DisplayName:        safeString(v.DisplayName),
 				AvailabilityDomain: safeString(v.AvailabilityDomain),
+				Tags:               newTags(v.FreeformTags, v.DefinedTags),
 			}
 			if v.SizeInGBs != nil {
 				vol.SizeGB = *v.SizeInGBs
@@ -479,6 +784,134 @@ func discoverBlockVolumes(ctx context.Context, client BlockstorageAPI, compartme
 	return volumes, nil
 }
 
+// discoverBootVolumes lists boot volumes in each availability domain. Boot
+// volumes count toward the always-free block storage limit alongside block volumes.
+func discoverBootVolumes(ctx context.Context, client BlockstorageAPI, compartmentID string, adNames []string) ([]BootVolume, error) {
+	var volumes []BootVolume
+	for _, adName := range adNames {
+		req := core.ListBootVolumesRequest{
+			AvailabilityDomain: common.String(adName),
+			CompartmentId:      &compartmentID,
+		}
+
+		for {
+			resp, err := client.ListBootVolumes(ctx, req)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, v := range resp.Items {
+				vol := BootVolume{
+					ID:                 *v.Id,
+					DisplayName:        safeString(v.DisplayName),
+					AvailabilityDomain: safeString(v.AvailabilityDomain),
+					ImageID:            safeString(v.ImageId),
+					VolumeGroupID:      safeString(v.VolumeGroupId),
+					Tags:               newTags(v.FreeformTags, v.DefinedTags),
+				}
+				if v.SizeInGBs != nil {
+					vol.SizeGB = *v.SizeInGBs
+				}
+				if v.VpusPerGB != nil {
+					vol.VPUsPerGB = *v.VpusPerGB
+				}
+				if v.IsHydrated != nil {
+					vol.IsHydrated = *v.IsHydrated
+				}
+				volumes = append(volumes, vol)
+			}
+
+			if resp.OpcNextPage == nil {
+				break
+			}
+			req.Page = resp.OpcNextPage
+		}
+	}
+	return volumes, nil
+}
+
+// discoverBackupPolicies lists the Oracle-defined volume backup policies
+// (gold, silver, bronze) followed by custom policies in the compartment.
+func discoverBackupPolicies(ctx context.Context, client BlockstorageAPI, compartmentID string) ([]BackupPolicy, error) {
+	var policies []BackupPolicy
+
+	// Omitting the compartment lists the Oracle-defined policies.
+	for _, compID := range []*string{nil, &compartmentID} {
+		req := core.ListVolumeBackupPoliciesRequest{
+			CompartmentId: compID,
+		}
+
+		for {
+			resp, err := client.ListVolumeBackupPolicies(ctx, req)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, p := range resp.Items {
+				policy := BackupPolicy{
+					ID:                *p.Id,
+					DisplayName:       safeString(p.DisplayName),
+					CompartmentID:     safeString(p.CompartmentId),
+					IsOracleDefined:   p.CompartmentId == nil,
+					DestinationRegion: safeString(p.DestinationRegion),
+					Tags:              newTags(p.FreeformTags, p.DefinedTags),
+				}
+				for _, sched := range p.Schedules {
+					schedule := BackupSchedule{
+						BackupType: string(sched.BackupType),
+						Period:     string(sched.Period),
+					}
+					if sched.RetentionSeconds != nil {
+						schedule.RetentionSeconds = *sched.RetentionSeconds
+					}
+					policy.Schedules = append(policy.Schedules, schedule)
+				}
+				policies = append(policies, policy)
+			}
+
+			if resp.OpcNextPage == nil {
+				break
+			}
+			req.Page = resp.OpcNextPage
+		}
+	}
+	return policies, nil
+}
+
+func discoverVolumeGroups(ctx context.Context, client BlockstorageAPI, compartmentID string) ([]VolumeGroup, error) {
+	req := core.ListVolumeGroupsRequest{
+		CompartmentId: &compartmentID,
+	}
+
+	var groups []VolumeGroup
+	for {
+		resp, err := client.ListVolumeGroups(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, vg := range resp.Items {
+			group := VolumeGroup{
+				ID:                 *vg.Id,
+				DisplayName:        safeString(vg.DisplayName),
+				AvailabilityDomain: safeString(vg.AvailabilityDomain),
+				VolumeIDs:          vg.VolumeIds,
+				Tags:               newTags(vg.FreeformTags, vg.DefinedTags),
+			}
+			if vg.SizeInGBs != nil {
+				group.SizeGB = *vg.SizeInGBs
+			}
+			groups = append(groups, group)
+		}
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		req.Page = resp.OpcNextPage
+	}
+	return groups, nil
+}
+
 func discoverLimits(ctx context.Context, client LimitsAPI, tenancyID string) ([]ServiceLimit, error) {
 	computeServices := []string{"compute", "compute-core"}
 	var limits []ServiceLimit
@@ -517,7 +950,10 @@ func discoverLimits(ctx context.Context, client LimitsAPI, tenancyID string) ([]
 // "Oracle-Linux-8.10-aarch64-2025.11.20-0-OKE-1.31.10-1345"
 var okeVersionRe = regexp.MustCompile(`OKE-(\d+\.\d+\.\d+)`)
 
-func discoverOKEImages(ctx context.Context, client ContainerEngineAPI, compartmentID string) ([]OKEImage, error) {
+// discoverOKEImages returns the OKE node images together with the Kubernetes
+// versions the node pool options report as supported. Images are mapped to a
+// supported version by the version embedded in their source name.
+func discoverOKEImages(ctx context.Context, client ContainerEngineAPI, compartmentID string) ([]OKEImage, []string, error) {
 	optionID := "all"
 	req := containerengine.GetNodePoolOptionsRequest{
 		NodePoolOptionId: &optionID,
@@ -526,8 +962,9 @@ func discoverOKEImages(ctx context.Context, client ContainerEngineAPI, compartme
 
 	resp, err := client.GetNodePoolOptions(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	supported := resp.KubernetesVersions
 
 	var images []OKEImage
 	for _, src := range resp.Sources {
@@ -547,6 +984,9 @@ func discoverOKEImages(ctx context.Context, client ContainerEngineAPI, compartme
 		if matches := okeVersionRe.FindStringSubmatch(sourceName); len(matches) > 1 {
 			k8sVersion = matches[1]
 		}
+		isSupported := slices.ContainsFunc(supported, func(v string) bool {
+			return k8sVersion != "" && CompareKubernetesVersions(v, k8sVersion) == 0
+		})
 
 		// Determine architecture from source name
 		arch := "x86_64"
@@ -559,10 +999,591 @@ func discoverOKEImages(ctx context.Context, client ContainerEngineAPI, compartme
 			SourceName:        sourceName,
 			KubernetesVersion: k8sVersion,
 			Architecture:      arch,
+			IsSupported:       isSupported,
 		})
 	}
 
-	return images, nil
+	return images, supported, nil
+}
+
+// discoverOKEClusters lists active clusters with their node pools. Clusters not
+// matching the tag filters are skipped before their node pools are listed.
+func discoverOKEClusters(ctx context.Context, client ContainerEngineAPI, compartmentID string, filters []TagFilter) ([]OKECluster, error) {
+	req := containerengine.ListClustersRequest{
+		CompartmentId:  &compartmentID,
+		LifecycleState: []containerengine.ClusterLifecycleStateEnum{containerengine.ClusterLifecycleStateActive},
+	}
+
+	var clusters []OKECluster
+	for {
+		resp, err := client.ListClusters(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range resp.Items {
+			cluster := OKECluster{
+				ID:                *c.Id,
+				Name:              safeString(c.Name),
+				CompartmentID:     safeString(c.CompartmentId),
+				VCNID:             safeString(c.VcnId),
+				KubernetesVersion: safeString(c.KubernetesVersion),
+				Type:              string(c.Type),
+				AvailableUpgrades: c.AvailableKubernetesUpgrades,
+				Tags:              newTags(c.FreeformTags, c.DefinedTags),
+			}
+			if !cluster.MatchesTagFilters(filters) {
+				continue
+			}
+			for _, opt := range c.ClusterPodNetworkOptions {
+				switch opt.(type) {
+				case containerengine.OciVcnIpNativeClusterPodNetworkOptionDetails:
+					cluster.CNIType = "OCI_VCN_IP_NATIVE"
+				case containerengine.FlannelOverlayClusterPodNetworkOptionDetails:
+					cluster.CNIType = "FLANNEL_OVERLAY"
+				}
+			}
+			if c.EndpointConfig != nil {
+				cluster.EndpointSubnetID = safeString(c.EndpointConfig.SubnetId)
+				if c.EndpointConfig.IsPublicIpEnabled != nil {
+					cluster.IsPublicEndpoint = *c.EndpointConfig.IsPublicIpEnabled
+				}
+			}
+			if c.Endpoints != nil {
+				cluster.PublicEndpoint = safeString(c.Endpoints.PublicEndpoint)
+				cluster.PrivateEndpoint = safeString(c.Endpoints.PrivateEndpoint)
+			}
+			if c.Options != nil {
+				cluster.ServiceLBSubnetIDs = c.Options.ServiceLbSubnetIds
+			}
+
+			pools, err := discoverOKENodePools(ctx, client, cluster.CompartmentID, cluster.ID)
+			if err != nil {
+				return nil, err
+			}
+			cluster.NodePools = filterByTags(pools, filters)
+			clusters = append(clusters, cluster)
+		}
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		req.Page = resp.OpcNextPage
+	}
+	return clusters, nil
+}
+
+func discoverOKENodePools(ctx context.Context, client ContainerEngineAPI, compartmentID, clusterID string) ([]OKENodePool, error) {
+	req := containerengine.ListNodePoolsRequest{
+		CompartmentId: &compartmentID,
+		ClusterId:     &clusterID,
+		LifecycleState: []containerengine.NodePoolLifecycleStateEnum{
+			containerengine.NodePoolLifecycleStateActive,
+			containerengine.NodePoolLifecycleStateUpdating,
+			containerengine.NodePoolLifecycleStateNeedsAttention,
+		},
+	}
+
+	var pools []OKENodePool
+	for {
+		resp, err := client.ListNodePools(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, np := range resp.Items {
+			pool := OKENodePool{
+				ID:                *np.Id,
+				Name:              safeString(np.Name),
+				KubernetesVersion: safeString(np.KubernetesVersion),
+				NodeShape:         safeString(np.NodeShape),
+				ImageID:           safeString(np.NodeImageId),
+				SubnetIDs:         np.SubnetIds,
+				Tags:              newTags(np.FreeformTags, np.DefinedTags),
+			}
+			if np.NodeShapeConfig != nil {
+				if np.NodeShapeConfig.Ocpus != nil {
+					pool.OCPUs = *np.NodeShapeConfig.Ocpus
+				}
+				if np.NodeShapeConfig.MemoryInGBs != nil {
+					pool.MemoryGB = *np.NodeShapeConfig.MemoryInGBs
+				}
+			}
+			if src, ok := np.NodeSourceDetails.(containerengine.NodeSourceViaImageDetails); ok && src.ImageId != nil {
+				pool.ImageID = *src.ImageId
+			}
+			// Pools created with placement configs report subnets there instead
+			if np.NodeConfigDetails != nil {
+				if np.NodeConfigDetails.Size != nil {
+					pool.Size = *np.NodeConfigDetails.Size
+				}
+				for _, pc := range np.NodeConfigDetails.PlacementConfigs {
+					if id := safeString(pc.SubnetId); id != "" && !slices.Contains(pool.SubnetIDs, id) {
+						pool.SubnetIDs = append(pool.SubnetIDs, id)
+					}
+				}
+			}
+			pools = append(pools, pool)
+		}
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		req.Page = resp.OpcNextPage
+	}
+	return pools, nil
+}
+
+// discoverRegistry resolves the tenancy namespace and the OCIR endpoint for the
+// region's short key, then lists the container repositories in the compartment.
+func discoverRegistry(ctx context.Context, identityClient IdentityAPI, osClient ObjectStorageAPI, artifactsClient ArtifactsAPI, compartmentID, region string, filters []TagFilter) (*Registry, error) {
+	nsResp, err := osClient.GetNamespace(ctx, objectstorage.GetNamespaceRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	regionsResp, err := identityClient.ListRegions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var regionKey string
+	for _, r := range regionsResp.Items {
+		if safeString(r.Name) == region {
+			regionKey = safeString(r.Key)
+			break
+		}
+	}
+	endpoint, err := ocirEndpoint(regionKey, region)
+	if err != nil {
+		return nil, err
+	}
+
+	registry := &Registry{
+		Namespace: safeString(nsResp.Value),
+		RegionKey: regionKey,
+		Endpoint:  endpoint,
+	}
+
+	req := artifacts.ListContainerRepositoriesRequest{
+		CompartmentId: &compartmentID,
+	}
+	for {
+		resp, err := artifactsClient.ListContainerRepositories(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range resp.Items {
+			repo := ContainerRepository{
+				ID:            *r.Id,
+				Name:          safeString(r.DisplayName),
+				CompartmentID: safeString(r.CompartmentId),
+				Tags:          newTags(r.FreeformTags, r.DefinedTags),
+			}
+			if r.IsPublic != nil {
+				repo.IsPublic = *r.IsPublic
+			}
+			if r.ImageCount != nil {
+				repo.ImageCount = *r.ImageCount
+			}
+			registry.Repositories = append(registry.Repositories, repo)
+		}
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		req.Page = resp.OpcNextPage
+	}
+	registry.Repositories = filterByTags(registry.Repositories, filters)
+	return registry, nil
+}
+
+// discoverFunctionsApplications lists active Functions applications.
+func discoverFunctionsApplications(ctx context.Context, client FunctionsAPI, compartmentID string) ([]FunctionsApplication, error) {
+	req := functions.ListApplicationsRequest{
+		CompartmentId:  &compartmentID,
+		LifecycleState: functions.ApplicationLifecycleStateActive,
+	}
+
+	var apps []FunctionsApplication
+	for {
+		resp, err := client.ListApplications(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, a := range resp.Items {
+			apps = append(apps, FunctionsApplication{
+				ID:            *a.Id,
+				Name:          safeString(a.DisplayName),
+				CompartmentID: safeString(a.CompartmentId),
+				SubnetIDs:     a.SubnetIds,
+				Shape:         string(a.Shape),
+				Tags:          newTags(a.FreeformTags, a.DefinedTags),
+			})
+		}
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		req.Page = resp.OpcNextPage
+	}
+	return apps, nil
+}
+
+// discoverDNSZones lists active public (GLOBAL) and private zones in the compartment.
+func discoverDNSZones(ctx context.Context, client DNSAPI, compartmentID string) ([]DNSZone, error) {
+	var zones []DNSZone
+	for _, scope := range []dns.ListZonesScopeEnum{dns.ListZonesScopeGlobal, dns.ListZonesScopePrivate} {
+		req := dns.ListZonesRequest{
+			CompartmentId:  &compartmentID,
+			Scope:          scope,
+			LifecycleState: dns.ListZonesLifecycleStateActive,
+		}
+		for {
+			resp, err := client.ListZones(ctx, req)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, z := range resp.Items {
+				zones = append(zones, DNSZone{
+					ID:            *z.Id,
+					Name:          safeString(z.Name),
+					CompartmentID: safeString(z.CompartmentId),
+					ZoneType:      string(z.ZoneType),
+					Scope:         string(z.Scope),
+					ViewID:        safeString(z.ViewId),
+					IsProtected:   safeBool(z.IsProtected),
+					Tags:          newTags(z.FreeformTags, z.DefinedTags),
+				})
+			}
+
+			if resp.OpcNextPage == nil {
+				break
+			}
+			req.Page = resp.OpcNextPage
+		}
+	}
+	return zones, nil
+}
+
+// discoverDNSViews lists active private views in the compartment, including
+// the protected default view of each VCN.
+func discoverDNSViews(ctx context.Context, client DNSAPI, compartmentID string) ([]DNSView, error) {
+	req := dns.ListViewsRequest{
+		CompartmentId:  &compartmentID,
+		Scope:          dns.ListViewsScopePrivate,
+		LifecycleState: dns.ViewSummaryLifecycleStateActive,
+	}
+
+	var views []DNSView
+	for {
+		resp, err := client.ListViews(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, v := range resp.Items {
+			views = append(views, DNSView{
+				ID:            *v.Id,
+				Name:          safeString(v.DisplayName),
+				CompartmentID: safeString(v.CompartmentId),
+				IsProtected:   safeBool(v.IsProtected),
+				Tags:          newTags(v.FreeformTags, v.DefinedTags),
+			})
+		}
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		req.Page = resp.OpcNextPage
+	}
+	return views, nil
+}
+
+// discoverDNSResolvers lists the VCN resolvers in the compartment with their
+// listening and forwarding endpoints.
+func discoverDNSResolvers(ctx context.Context, client DNSAPI, compartmentID string) ([]DNSResolver, error) {
+	req := dns.ListResolversRequest{
+		CompartmentId:  &compartmentID,
+		Scope:          dns.ListResolversScopePrivate,
+		LifecycleState: dns.ResolverSummaryLifecycleStateActive,
+	}
+
+	var resolvers []DNSResolver
+	for {
+		resp, err := client.ListResolvers(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range resp.Items {
+			endpoints, err := discoverResolverEndpoints(ctx, client, *r.Id)
+			if err != nil {
+				return nil, err
+			}
+			resolvers = append(resolvers, DNSResolver{
+				ID:            *r.Id,
+				Name:          safeString(r.DisplayName),
+				CompartmentID: safeString(r.CompartmentId),
+				VCNID:         safeString(r.AttachedVcnId),
+				DefaultViewID: safeString(r.DefaultViewId),
+				Endpoints:     endpoints,
+				Tags:          newTags(r.FreeformTags, r.DefinedTags),
+			})
+		}
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		req.Page = resp.OpcNextPage
+	}
+	return resolvers, nil
+}
+
+func discoverResolverEndpoints(ctx context.Context, client DNSAPI, resolverID string) ([]ResolverEndpoint, error) {
+	req := dns.ListResolverEndpointsRequest{
+		ResolverId: &resolverID,
+		Scope:      dns.ListResolverEndpointsScopePrivate,
+	}
+
+	var endpoints []ResolverEndpoint
+	for {
+		resp, err := client.ListResolverEndpoints(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range resp.Items {
+			ep := ResolverEndpoint{
+				Name:              safeString(e.GetName()),
+				IsListening:       safeBool(e.GetIsListening()),
+				IsForwarding:      safeBool(e.GetIsForwarding()),
+				ListeningAddress:  safeString(e.GetListeningAddress()),
+				ForwardingAddress: safeString(e.GetForwardingAddress()),
+			}
+			if vnic, ok := e.(dns.ResolverVnicEndpointSummary); ok {
+				ep.SubnetID = safeString(vnic.SubnetId)
+			}
+			endpoints = append(endpoints, ep)
+		}
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		req.Page = resp.OpcNextPage
+	}
+	return endpoints, nil
+}
+
+func discoverLogGroups(ctx context.Context, client LoggingAPI, compartmentID string) ([]LogGroup, error) {
+	req := logging.ListLogGroupsRequest{
+		CompartmentId: &compartmentID,
+	}
+
+	var groups []LogGroup
+	for {
+		resp, err := client.ListLogGroups(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, lg := range resp.Items {
+			groups = append(groups, LogGroup{
+				ID:            *lg.Id,
+				Name:          safeString(lg.DisplayName),
+				CompartmentID: safeString(lg.CompartmentId),
+				Description:   safeString(lg.Description),
+				Tags:          newTags(lg.FreeformTags, lg.DefinedTags),
+			})
+		}
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		req.Page = resp.OpcNextPage
+	}
+	return groups, nil
+}
+
+func discoverNotificationTopics(ctx context.Context, client NotificationAPI, compartmentID string) ([]NotificationTopic, error) {
+	req := ons.ListTopicsRequest{
+		CompartmentId:  &compartmentID,
+		LifecycleState: ons.NotificationTopicSummaryLifecycleStateActive,
+	}
+
+	var topics []NotificationTopic
+	for {
+		resp, err := client.ListTopics(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, t := range resp.Items {
+			topics = append(topics, NotificationTopic{
+				ID:            *t.TopicId,
+				Name:          safeString(t.Name),
+				CompartmentID: safeString(t.CompartmentId),
+				Description:   safeString(t.Description),
+				APIEndpoint:   safeString(t.ApiEndpoint),
+				Tags:          newTags(t.FreeformTags, t.DefinedTags),
+			})
+		}
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		req.Page = resp.OpcNextPage
+	}
+	return topics, nil
+}
+
+func discoverAlarms(ctx context.Context, client MonitoringAPI, compartmentID string) ([]Alarm, error) {
+	req := monitoring.ListAlarmsRequest{
+		CompartmentId:  &compartmentID,
+		LifecycleState: monitoring.AlarmLifecycleStateActive,
+	}
+
+	var alarms []Alarm
+	for {
+		resp, err := client.ListAlarms(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, a := range resp.Items {
+			alarms = append(alarms, Alarm{
+				ID:            *a.Id,
+				Name:          safeString(a.DisplayName),
+				CompartmentID: safeString(a.CompartmentId),
+				Namespace:     safeString(a.Namespace),
+				Query:         safeString(a.Query),
+				Severity:      string(a.Severity),
+				Destinations:  a.Destinations,
+				IsEnabled:     safeBool(a.IsEnabled),
+				Tags:          newTags(a.FreeformTags, a.DefinedTags),
+			})
+		}
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		req.Page = resp.OpcNextPage
+	}
+	return alarms, nil
+}
+
+// discoverBudgets lists the active budgets in the tenancy with their alert rules.
+func discoverBudgets(ctx context.Context, client BudgetAPI, tenancyID string) ([]Budget, error) {
+	req := budget.ListBudgetsRequest{
+		CompartmentId:  &tenancyID,
+		LifecycleState: budget.ListBudgetsLifecycleStateActive,
+		TargetType:     budget.ListBudgetsTargetTypeAll,
+	}
+
+	var budgets []Budget
+	for {
+		resp, err := client.ListBudgets(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, b := range resp.Items {
+			rules, err := discoverBudgetAlertRules(ctx, client, *b.Id)
+			if err != nil {
+				return nil, err
+			}
+			budgets = append(budgets, Budget{
+				ID:              *b.Id,
+				Name:            safeString(b.DisplayName),
+				Amount:          safeFloat(b.Amount),
+				ResetPeriod:     string(b.ResetPeriod),
+				TargetType:      string(b.TargetType),
+				Targets:         b.Targets,
+				ActualSpend:     safeFloat(b.ActualSpend),
+				ForecastedSpend: safeFloat(b.ForecastedSpend),
+				AlertRules:      rules,
+				Tags:            newTags(b.FreeformTags, b.DefinedTags),
+			})
+		}
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		req.Page = resp.OpcNextPage
+	}
+	return budgets, nil
+}
+
+func discoverBudgetAlertRules(ctx context.Context, client BudgetAPI, budgetID string) ([]BudgetAlertRule, error) {
+	req := budget.ListAlertRulesRequest{
+		BudgetId:       &budgetID,
+		LifecycleState: budget.ListAlertRulesLifecycleStateActive,
+	}
+
+	var rules []BudgetAlertRule
+	for {
+		resp, err := client.ListAlertRules(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range resp.Items {
+			rules = append(rules, BudgetAlertRule{
+				ID:            *r.Id,
+				Name:          safeString(r.DisplayName),
+				Type:          string(r.Type),
+				Threshold:     safeFloat(r.Threshold),
+				ThresholdType: string(r.ThresholdType),
+				Recipients:    safeString(r.Recipients),
+			})
+		}
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		req.Page = resp.OpcNextPage
+	}
+	return rules, nil
+}
+
+// discoverQuotas lists the quota policies in the tenancy root, where all quotas
+// are created, and fetches each policy's statements.
+func discoverQuotas(ctx context.Context, client QuotasAPI, tenancyID string) ([]Quota, error) {
+	req := lim.ListQuotasRequest{
+		CompartmentId:  &tenancyID,
+		LifecycleState: lim.ListQuotasLifecycleStateActive,
+	}
+
+	var quotas []Quota
+	for {
+		resp, err := client.ListQuotas(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, q := range resp.Items {
+			full, err := client.GetQuota(ctx, lim.GetQuotaRequest{QuotaId: q.Id})
+			if err != nil {
+				return nil, err
+			}
+			quotas = append(quotas, Quota{
+				ID:            *q.Id,
+				Name:          safeString(q.Name),
+				CompartmentID: safeString(q.CompartmentId),
+				Description:   safeString(q.Description),
+				Statements:    full.Statements,
+				Tags:          newTags(q.FreeformTags, q.DefinedTags),
+			})
+		}
+
+		if resp.OpcNextPage == nil {
+			break
+		}
+		req.Page = resp.OpcNextPage
+	}
+	return quotas, nil
 }
 
 func discoverTenancy(ctx context.Context, client IdentityAPI, tenancyID string) (TenancyInfo, error) {
@@ -3,13 +3,25 @@ package discovery
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/oracle/oci-go-sdk/v65/artifacts"
+	"github.com/oracle/oci-go-sdk/v65/budget"
 	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/oracle/oci-go-sdk/v65/containerengine"
 	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/oracle/oci-go-sdk/v65/dns"
+	"github.com/oracle/oci-go-sdk/v65/functions"
 	"github.com/oracle/oci-go-sdk/v65/identity"
 	lim "github.com/oracle/oci-go-sdk/v65/limits"
+	"github.com/oracle/oci-go-sdk/v65/logging"
+	"github.com/oracle/oci-go-sdk/v65/monitoring"
+	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+	"github.com/oracle/oci-go-sdk/v65/ons"
 )
 
 // --- Mock helpers ---
@@ -30,6 +42,21 @@ type mockIdentityClient struct {
 	faultDomainErr error
 	tenancy        identity.Tenancy
 	tenancyErr     error
+	groups         []identity.Group
+	groupErr       error
+	dynamicGroups  []identity.DynamicGroup
+	dynamicGrpErr  error
+	policies       []identity.Policy
+	policyErrs     map[string]error // keyed by compartment OCID
+	tagNamespaces  []identity.TagNamespaceSummary
+	tagNsErr       error
+	tags           map[string][]identity.TagSummary               // keyed by namespace OCID
+	tagValidators  map[string]identity.BaseTagDefinitionValidator // keyed by tag name
+	getTagErrs     map[string]error                               // keyed by tag name
+	tagDefaults    []identity.TagDefaultSummary
+	tagDefaultErr  error
+	regions        []identity.Region
+	regionErr      error
 }
 
 func (m *mockIdentityClient) ListCompartments(_ context.Context, _ identity.ListCompartmentsRequest) (identity.ListCompartmentsResponse, error) {
@@ -68,13 +95,98 @@ func (m *mockIdentityClient) GetTenancy(_ context.Context, _ identity.GetTenancy
 	}, nil
 }
 
+func (m *mockIdentityClient) ListGroups(_ context.Context, _ identity.ListGroupsRequest) (identity.ListGroupsResponse, error) {
+	if m.groupErr != nil {
+		return identity.ListGroupsResponse{}, m.groupErr
+	}
+	return identity.ListGroupsResponse{
+		Items: m.groups,
+	}, nil
+}
+
+func (m *mockIdentityClient) ListDynamicGroups(_ context.Context, _ identity.ListDynamicGroupsRequest) (identity.ListDynamicGroupsResponse, error) {
+	if m.dynamicGrpErr != nil {
+		return identity.ListDynamicGroupsResponse{}, m.dynamicGrpErr
+	}
+	return identity.ListDynamicGroupsResponse{
+		Items: m.dynamicGroups,
+	}, nil
+}
+
+// ListPolicies returns the policies attached to the requested compartment.
+func (m *mockIdentityClient) ListPolicies(_ context.Context, req identity.ListPoliciesRequest) (identity.ListPoliciesResponse, error) {
+	if err := m.policyErrs[*req.CompartmentId]; err != nil {
+		return identity.ListPoliciesResponse{}, err
+	}
+	var items []identity.Policy
+	for _, p := range m.policies {
+		if *p.CompartmentId == *req.CompartmentId {
+			items = append(items, p)
+		}
+	}
+	return identity.ListPoliciesResponse{
+		Items: items,
+	}, nil
+}
+
+func (m *mockIdentityClient) ListTagNamespaces(_ context.Context, _ identity.ListTagNamespacesRequest) (identity.ListTagNamespacesResponse, error) {
+	if m.tagNsErr != nil {
+		return identity.ListTagNamespacesResponse{}, m.tagNsErr
+	}
+	return identity.ListTagNamespacesResponse{
+		Items: m.tagNamespaces,
+	}, nil
+}
+
+func (m *mockIdentityClient) ListTags(_ context.Context, req identity.ListTagsRequest) (identity.ListTagsResponse, error) {
+	return identity.ListTagsResponse{
+		Items: m.tags[*req.TagNamespaceId],
+	}, nil
+}
+
+func (m *mockIdentityClient) GetTag(_ context.Context, req identity.GetTagRequest) (identity.GetTagResponse, error) {
+	if err := m.getTagErrs[*req.TagName]; err != nil {
+		return identity.GetTagResponse{}, err
+	}
+	return identity.GetTagResponse{
+		Tag: identity.Tag{Name: req.TagName, Validator: m.tagValidators[*req.TagName]},
+	}, nil
+}
+
+func (m *mockIdentityClient) ListTagDefaults(_ context.Context, req identity.ListTagDefaultsRequest) (identity.ListTagDefaultsResponse, error) {
+	if m.tagDefaultErr != nil {
+		return identity.ListTagDefaultsResponse{}, m.tagDefaultErr
+	}
+	var items []identity.TagDefaultSummary
+	for _, d := range m.tagDefaults {
+		if *d.CompartmentId == *req.CompartmentId {
+			items = append(items, d)
+		}
+	}
+	return identity.ListTagDefaultsResponse{
+		Items: items,
+	}, nil
+}
+
+func (m *mockIdentityClient) ListRegions(_ context.Context) (identity.ListRegionsResponse, error) {
+	if m.regionErr != nil {
+		return identity.ListRegionsResponse{}, m.regionErr
+	}
+	return identity.ListRegionsResponse{
+		Items: m.regions,
+	}, nil
+}
+
 // --- Mock Compute Client ---
 
 type mockComputeClient struct {
-	shapes   []core.Shape
-	shapeErr error
-	images   []core.Image
-	imageErr error
+	shapes      []core.Shape
+	shapeErr    error
+	images      []core.Image
+	imageErr    error
+	capacity    map[string]core.CapacityReportShapeAvailability // keyed by "AD/FD"
+	capacityErr error
+	failAD      string // AD whose capacity report fails with capacityErr; empty for every AD
 }
 
 func (m *mockComputeClient) ListShapes(_ context.Context, _ core.ListShapesRequest) (core.ListShapesResponse, error) {
@@ -95,6 +207,27 @@ func (m *mockComputeClient) ListImages(_ context.Context, _ core.ListImagesReque
 	}, nil
 }
 
+func (m *mockComputeClient) CreateComputeCapacityReport(_ context.Context, req core.CreateComputeCapacityReportRequest) (core.CreateComputeCapacityReportResponse, error) {
+	details := req.CreateComputeCapacityReportDetails
+	if m.capacityErr != nil && (m.failAD == "" || m.failAD == *details.AvailabilityDomain) {
+		return core.CreateComputeCapacityReportResponse{}, m.capacityErr
+	}
+	var items []core.CapacityReportShapeAvailability
+	for _, a := range details.ShapeAvailabilities {
+		fd := ""
+		if a.FaultDomain != nil {
+			fd = *a.FaultDomain
+		}
+		item := m.capacity[*details.AvailabilityDomain+"/"+fd]
+		item.FaultDomain = a.FaultDomain
+		item.InstanceShape = a.InstanceShape
+		items = append(items, item)
+	}
+	return core.CreateComputeCapacityReportResponse{
+		ComputeCapacityReport: core.ComputeCapacityReport{ShapeAvailabilities: items},
+	}, nil
+}
+
 // --- Mock VirtualNetwork Client ---
 
 type mockVirtualNetworkClient struct {
@@ -169,8 +302,14 @@ func (m *mockVirtualNetworkClient) ListNatGateways(_ context.Context, _ core.Lis
 // --- Mock Blockstorage Client ---
 
 type mockBlockstorageClient struct {
-	volumes   []core.Volume
-	volumeErr error
+	volumes         []core.Volume
+	volumeErr       error
+	bootVolumes     []core.BootVolume
+	bootVolumeErr   error
+	backupPolicies  []core.VolumeBackupPolicy
+	backupPolicyErr error
+	volumeGroups    []core.VolumeGroup
+	volumeGroupErr  error
 }
 
 func (m *mockBlockstorageClient) ListVolumes(_ context.Context, _ core.ListVolumesRequest) (core.ListVolumesResponse, error) {
@@ -182,6 +321,41 @@ func (m *mockBlockstorageClient) ListVolumes(_ context.Context, _ core.ListVolum
 	}, nil
 }
 
+func (m *mockBlockstorageClient) ListBootVolumes(_ context.Context, _ core.ListBootVolumesRequest) (core.ListBootVolumesResponse, error) {
+	if m.bootVolumeErr != nil {
+		return core.ListBootVolumesResponse{}, m.bootVolumeErr
+	}
+	return core.ListBootVolumesResponse{
+		Items: m.bootVolumes,
+	}, nil
+}
+
+// ListVolumeBackupPolicies returns Oracle-defined policies (no compartment) when the
+// request omits a compartment, and custom policies otherwise.
+func (m *mockBlockstorageClient) ListVolumeBackupPolicies(_ context.Context, req core.ListVolumeBackupPoliciesRequest) (core.ListVolumeBackupPoliciesResponse, error) {
+	if m.backupPolicyErr != nil {
+		return core.ListVolumeBackupPoliciesResponse{}, m.backupPolicyErr
+	}
+	var items []core.VolumeBackupPolicy
+	for _, p := range m.backupPolicies {
+		if (req.CompartmentId == nil) == (p.CompartmentId == nil) {
+			items = append(items, p)
+		}
+	}
+	return core.ListVolumeBackupPoliciesResponse{
+		Items: items,
+	}, nil
+}
+
+func (m *mockBlockstorageClient) ListVolumeGroups(_ context.Context, _ core.ListVolumeGroupsRequest) (core.ListVolumeGroupsResponse, error) {
+	if m.volumeGroupErr != nil {
+		return core.ListVolumeGroupsResponse{}, m.volumeGroupErr
+	}
+	return core.ListVolumeGroupsResponse{
+		Items: m.volumeGroups,
+	}, nil
+}
+
 // --- Mock Limits Client ---
 
 type mockLimitsClient struct {
@@ -201,8 +375,12 @@ func (m *mockLimitsClient) ListLimitValues(_ context.Context, _ lim.ListLimitVal
 // --- Mock ContainerEngine Client ---
 
 type mockContainerEngineClient struct {
-	sources []containerengine.NodeSourceOption
-	ceErr   error
+	sources    []containerengine.NodeSourceOption
+	versions   []string
+	ceErr      error
+	clusters   []containerengine.ClusterSummary
+	clusterErr error
+	nodePools  []containerengine.NodePoolSummary
 }
 
 func (m *mockContainerEngineClient) GetNodePoolOptions(_ context.Context, _ containerengine.GetNodePoolOptionsRequest) (containerengine.GetNodePoolOptionsResponse, error) {
@@ -211,11 +389,204 @@ func (m *mockContainerEngineClient) GetNodePoolOptions(_ context.Context, _ cont
 	}
 	return containerengine.GetNodePoolOptionsResponse{
 		NodePoolOptions: containerengine.NodePoolOptions{
-			Sources: m.sources,
+			KubernetesVersions: m.versions,
+			Sources:            m.sources,
 		},
 	}, nil
 }
 
+func (m *mockContainerEngineClient) ListClusters(_ context.Context, _ containerengine.ListClustersRequest) (containerengine.ListClustersResponse, error) {
+	if m.clusterErr != nil {
+		return containerengine.ListClustersResponse{}, m.clusterErr
+	}
+	return containerengine.ListClustersResponse{
+		Items: m.clusters,
+	}, nil
+}
+
+func (m *mockContainerEngineClient) ListNodePools(_ context.Context, req containerengine.ListNodePoolsRequest) (containerengine.ListNodePoolsResponse, error) {
+	var items []containerengine.NodePoolSummary
+	for _, np := range m.nodePools {
+		if *np.ClusterId == *req.ClusterId {
+			items = append(items, np)
+		}
+	}
+	return containerengine.ListNodePoolsResponse{
+		Items: items,
+	}, nil
+}
+
+// --- Mock Artifacts Client ---
+
+type mockArtifactsClient struct {
+	repos   []artifacts.ContainerRepositorySummary
+	repoErr error
+}
+
+func (m *mockArtifactsClient) ListContainerRepositories(_ context.Context, _ artifacts.ListContainerRepositoriesRequest) (artifacts.ListContainerRepositoriesResponse, error) {
+	if m.repoErr != nil {
+		return artifacts.ListContainerRepositoriesResponse{}, m.repoErr
+	}
+	return artifacts.ListContainerRepositoriesResponse{
+		ContainerRepositoryCollection: artifacts.ContainerRepositoryCollection{Items: m.repos},
+	}, nil
+}
+
+// --- Mock ObjectStorage Client ---
+
+type mockObjectStorageClient struct {
+	namespace string
+	nsErr     error
+}
+
+func (m *mockObjectStorageClient) GetNamespace(_ context.Context, _ objectstorage.GetNamespaceRequest) (objectstorage.GetNamespaceResponse, error) {
+	if m.nsErr != nil {
+		return objectstorage.GetNamespaceResponse{}, m.nsErr
+	}
+	return objectstorage.GetNamespaceResponse{
+		Value: strPtr(m.namespace),
+	}, nil
+}
+
+// --- Mock Functions Client ---
+
+type mockFunctionsClient struct {
+	apps   []functions.ApplicationSummary
+	appErr error
+}
+
+func (m *mockFunctionsClient) ListApplications(_ context.Context, _ functions.ListApplicationsRequest) (functions.ListApplicationsResponse, error) {
+	if m.appErr != nil {
+		return functions.ListApplicationsResponse{}, m.appErr
+	}
+	return functions.ListApplicationsResponse{
+		Items: m.apps,
+	}, nil
+}
+
+// --- Mock DNS Client ---
+
+type mockDNSClient struct {
+	zones     []dns.ZoneSummary
+	zoneErr   error
+	views     []dns.ViewSummary
+	resolvers []dns.ResolverSummary
+	endpoints map[string][]dns.ResolverEndpointSummary // keyed by resolver OCID
+}
+
+func (m *mockDNSClient) ListZones(_ context.Context, req dns.ListZonesRequest) (dns.ListZonesResponse, error) {
+	if m.zoneErr != nil {
+		return dns.ListZonesResponse{}, m.zoneErr
+	}
+	var items []dns.ZoneSummary
+	for _, z := range m.zones {
+		if string(z.Scope) == string(req.Scope) {
+			items = append(items, z)
+		}
+	}
+	return dns.ListZonesResponse{
+		Items: items,
+	}, nil
+}
+
+func (m *mockDNSClient) ListViews(_ context.Context, _ dns.ListViewsRequest) (dns.ListViewsResponse, error) {
+	return dns.ListViewsResponse{
+		Items: m.views,
+	}, nil
+}
+
+func (m *mockDNSClient) ListResolvers(_ context.Context, _ dns.ListResolversRequest) (dns.ListResolversResponse, error) {
+	return dns.ListResolversResponse{
+		Items: m.resolvers,
+	}, nil
+}
+
+func (m *mockDNSClient) ListResolverEndpoints(_ context.Context, req dns.ListResolverEndpointsRequest) (dns.ListResolverEndpointsResponse, error) {
+	return dns.ListResolverEndpointsResponse{
+		Items: m.endpoints[*req.ResolverId],
+	}, nil
+}
+
+// --- Mock Observability Clients ---
+
+type mockLoggingClient struct {
+	groups []logging.LogGroupSummary
+	err    error
+}
+
+func (m *mockLoggingClient) ListLogGroups(_ context.Context, _ logging.ListLogGroupsRequest) (logging.ListLogGroupsResponse, error) {
+	if m.err != nil {
+		return logging.ListLogGroupsResponse{}, m.err
+	}
+	return logging.ListLogGroupsResponse{
+		Items: m.groups,
+	}, nil
+}
+
+type mockNotificationClient struct {
+	topics []ons.NotificationTopicSummary
+}
+
+func (m *mockNotificationClient) ListTopics(_ context.Context, _ ons.ListTopicsRequest) (ons.ListTopicsResponse, error) {
+	return ons.ListTopicsResponse{
+		Items: m.topics,
+	}, nil
+}
+
+type mockMonitoringClient struct {
+	alarms []monitoring.AlarmSummary
+}
+
+func (m *mockMonitoringClient) ListAlarms(_ context.Context, _ monitoring.ListAlarmsRequest) (monitoring.ListAlarmsResponse, error) {
+	return monitoring.ListAlarmsResponse{
+		Items: m.alarms,
+	}, nil
+}
+
+// --- Mock Budget and Quotas Clients ---
+
+type mockBudgetClient struct {
+	budgets   []budget.BudgetSummary
+	budgetErr error
+	rules     map[string][]budget.AlertRuleSummary // keyed by budget OCID
+}
+
+func (m *mockBudgetClient) ListBudgets(_ context.Context, _ budget.ListBudgetsRequest) (budget.ListBudgetsResponse, error) {
+	if m.budgetErr != nil {
+		return budget.ListBudgetsResponse{}, m.budgetErr
+	}
+	return budget.ListBudgetsResponse{
+		Items: m.budgets,
+	}, nil
+}
+
+func (m *mockBudgetClient) ListAlertRules(_ context.Context, req budget.ListAlertRulesRequest) (budget.ListAlertRulesResponse, error) {
+	return budget.ListAlertRulesResponse{
+		Items: m.rules[*req.BudgetId],
+	}, nil
+}
+
+type mockQuotasClient struct {
+	quotas     []lim.QuotaSummary
+	statements map[string][]string // keyed by quota OCID
+	getErr     error
+}
+
+func (m *mockQuotasClient) ListQuotas(_ context.Context, _ lim.ListQuotasRequest) (lim.ListQuotasResponse, error) {
+	return lim.ListQuotasResponse{
+		Items: m.quotas,
+	}, nil
+}
+
+func (m *mockQuotasClient) GetQuota(_ context.Context, req lim.GetQuotaRequest) (lim.GetQuotaResponse, error) {
+	if m.getErr != nil {
+		return lim.GetQuotaResponse{}, m.getErr
+	}
+	return lim.GetQuotaResponse{
+		Quota: lim.Quota{Id: req.QuotaId, Statements: m.statements[*req.QuotaId]},
+	}, nil
+}
+
 // --- Tests ---
 
 func TestDiscoverCompartments(t *testing.T) {
@@ -309,6 +680,196 @@ func TestDiscoverADs(t *testing.T) {
 	})
 }
 
+func TestDiscoverGroups(t *testing.T) {
+	t.Run("returns groups and dynamic groups", func(t *testing.T) {
+		mock := &mockIdentityClient{
+			groups: []identity.Group{
+				{Id: strPtr("group-1"), Name: strPtr("Administrators"), Description: strPtr("Admins")},
+			},
+			dynamicGroups: []identity.DynamicGroup{
+				{Id: strPtr("dg-1"), Name: strPtr("instances"), MatchingRule: strPtr("ALL {instance.compartment.id = 'comp-1'}")},
+			},
+		}
+
+		groups, err := discoverGroups(context.Background(), mock, "tenancy-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(groups) != 1 || groups[0].Name != "Administrators" {
+			t.Fatalf("unexpected groups: %+v", groups)
+		}
+
+		dynGroups, err := discoverDynamicGroups(context.Background(), mock, "tenancy-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(dynGroups) != 1 || dynGroups[0].MatchingRule == "" {
+			t.Fatalf("unexpected dynamic groups: %+v", dynGroups)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock := &mockIdentityClient{groupErr: fmt.Errorf("api error"), dynamicGrpErr: fmt.Errorf("api error")}
+		if _, err := discoverGroups(context.Background(), mock, "tenancy-1"); err == nil {
+			t.Error("expected group error, got nil")
+		}
+		if _, err := discoverDynamicGroups(context.Background(), mock, "tenancy-1"); err == nil {
+			t.Error("expected dynamic group error, got nil")
+		}
+	})
+}
+
+func TestDiscoverPolicies(t *testing.T) {
+	compartments := []Compartment{
+		{ID: "comp-net", Name: "network", ParentID: "tenancy-1"},
+		{ID: "comp-app", Name: "apps", ParentID: "tenancy-1"},
+	}
+
+	t.Run("attributes policies to compartments", func(t *testing.T) {
+		mock := &mockIdentityClient{
+			policies: []identity.Policy{
+				{Id: strPtr("pol-root"), Name: strPtr("Tenant Admin Policy"), CompartmentId: strPtr("tenancy-1"), Statements: []string{"ALLOW GROUP Administrators to manage all-resources IN TENANCY"}},
+				{Id: strPtr("pol-net"), Name: strPtr("netops"), CompartmentId: strPtr("comp-net"), Statements: []string{"Allow group NetOps to manage virtual-network-family in compartment network"}},
+			},
+		}
+
+		policies, err := discoverPolicies(context.Background(), mock, "tenancy-1", compartments)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(policies) != 2 {
+			t.Fatalf("expected 2 policies, got %d", len(policies))
+		}
+		if policies[0].CompartmentName != "root" {
+			t.Errorf("expected root policy first, got %q", policies[0].CompartmentName)
+		}
+		if policies[1].CompartmentID != "comp-net" || policies[1].CompartmentName != "network" {
+			t.Errorf("unexpected attribution: %+v", policies[1])
+		}
+		if len(policies[1].Statements) != 1 {
+			t.Errorf("expected 1 statement, got %d", len(policies[1].Statements))
+		}
+	})
+
+	t.Run("skips inaccessible compartments", func(t *testing.T) {
+		mock := &mockIdentityClient{
+			policies: []identity.Policy{
+				{Id: strPtr("pol-app"), Name: strPtr("apps"), CompartmentId: strPtr("comp-app")},
+			},
+			policyErrs: map[string]error{"comp-net": fmt.Errorf("not authorized")},
+		}
+
+		policies, err := discoverPolicies(context.Background(), mock, "tenancy-1", compartments)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(policies) != 1 || policies[0].ID != "pol-app" {
+			t.Errorf("expected only the apps policy, got %+v", policies)
+		}
+	})
+
+	t.Run("root error", func(t *testing.T) {
+		mock := &mockIdentityClient{
+			policyErrs: map[string]error{"tenancy-1": fmt.Errorf("not authorized")},
+		}
+		if _, err := discoverPolicies(context.Background(), mock, "tenancy-1", compartments); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+func TestDiscoverTagNamespaces(t *testing.T) {
+	t.Run("returns namespaces with definitions and enum values", func(t *testing.T) {
+		mock := &mockIdentityClient{
+			tagNamespaces: []identity.TagNamespaceSummary{
+				{Id: strPtr("ns-1"), Name: strPtr("Operations"), CompartmentId: strPtr("tenancy-1"), IsRetired: boolPtr(false)},
+			},
+			tags: map[string][]identity.TagSummary{
+				"ns-1": {
+					{Id: strPtr("tag-1"), Name: strPtr("CostCenter"), IsCostTracking: boolPtr(true)},
+					{Id: strPtr("tag-2"), Name: strPtr("Owner")},
+				},
+			},
+			tagValidators: map[string]identity.BaseTagDefinitionValidator{
+				"CostCenter": identity.EnumTagDefinitionValidator{Values: []string{"eng", "ops"}},
+			},
+		}
+
+		namespaces, err := discoverTagNamespaces(context.Background(), mock, "tenancy-1", noWarn(t))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(namespaces) != 1 || len(namespaces[0].Definitions) != 2 {
+			t.Fatalf("unexpected namespaces: %+v", namespaces)
+		}
+		cc := namespaces[0].Definitions[0]
+		if !cc.IsCostTracking || len(cc.AllowedValues) != 2 {
+			t.Errorf("expected cost-tracking enum tag, got %+v", cc)
+		}
+		if owner := namespaces[0].Definitions[1]; len(owner.AllowedValues) != 0 {
+			t.Errorf("free-text tag should have no allowed values, got %v", owner.AllowedValues)
+		}
+	})
+
+	t.Run("warns about tags whose allowed values cannot be read", func(t *testing.T) {
+		mock := &mockIdentityClient{
+			tagNamespaces: []identity.TagNamespaceSummary{
+				{Id: strPtr("ns-1"), Name: strPtr("Operations"), CompartmentId: strPtr("tenancy-1")},
+			},
+			tags: map[string][]identity.TagSummary{
+				"ns-1": {{Id: strPtr("tag-1"), Name: strPtr("CostCenter")}},
+			},
+			tagValidators: map[string]identity.BaseTagDefinitionValidator{
+				"CostCenter": identity.EnumTagDefinitionValidator{Values: []string{"eng", "ops"}},
+			},
+			getTagErrs: map[string]error{"CostCenter": fmt.Errorf("api error")},
+		}
+
+		var warnings []error
+		namespaces, err := discoverTagNamespaces(context.Background(), mock, "tenancy-1", func(err error) {
+			warnings = append(warnings, err)
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(namespaces) != 1 || len(namespaces[0].Definitions) != 1 || namespaces[0].Definitions[0].AllowedValues != nil {
+			t.Errorf("expected the tag kept without allowed values, got %+v", namespaces)
+		}
+		if len(warnings) != 1 || !strings.Contains(warnings[0].Error(), "Operations.CostCenter") {
+			t.Errorf("expected a warning naming the tag, got %v", warnings)
+		}
+	})
+
+	t.Run("resolves tag defaults for tenancy and compartment", func(t *testing.T) {
+		mock := &mockIdentityClient{
+			tagDefaults: []identity.TagDefaultSummary{
+				{Id: strPtr("td-1"), CompartmentId: strPtr("tenancy-1"), TagNamespaceId: strPtr("ns-1"), TagDefinitionName: strPtr("CostCenter"), Value: strPtr(""), IsRequired: boolPtr(true)},
+				{Id: strPtr("td-2"), CompartmentId: strPtr("comp-1"), TagNamespaceId: strPtr("ns-1"), TagDefinitionName: strPtr("Owner"), Value: strPtr("${iam.principal.name}"), IsRequired: boolPtr(false)},
+				{Id: strPtr("td-3"), CompartmentId: strPtr("comp-2"), TagNamespaceId: strPtr("ns-1"), TagDefinitionName: strPtr("Owner"), Value: strPtr("other")},
+			},
+		}
+		namespaces := []TagNamespace{{ID: "ns-1", Name: "Operations"}}
+
+		defaults, err := discoverTagDefaults(context.Background(), mock, "tenancy-1", "comp-1", namespaces)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(defaults) != 2 {
+			t.Fatalf("expected 2 defaults, got %+v", defaults)
+		}
+		if defaults[0].Namespace != "Operations" || defaults[0].Key != "CostCenter" || !defaults[0].IsRequired {
+			t.Errorf("unexpected required default: %+v", defaults[0])
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock := &mockIdentityClient{tagNsErr: fmt.Errorf("api error")}
+		if _, err := discoverTagNamespaces(context.Background(), mock, "tenancy-1", noWarn(t)); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
+
 func TestDiscoverShapes(t *testing.T) {
 	t.Run("returns shapes with deduplication", func(t *testing.T) {
 		mock := &mockComputeClient{
@@ -384,7 +945,7 @@ func TestDiscoverImages(t *testing.T) {
 			},
 		}
 
-		images, err := discoverImages(context.Background(), mock, "comp-1")
+		images, err := discoverImages(context.Background(), mock, "comp-1", nil)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -400,10 +961,33 @@ func TestDiscoverImages(t *testing.T) {
 		}
 	})
 
+	t.Run("tag filters apply only to custom images", func(t *testing.T) {
+		mock := &mockComputeClient{
+			images: []core.Image{
+				{Id: strPtr("custom-untagged"), OperatingSystem: strPtr("Oracle Linux"), OperatingSystemVersion: strPtr("9"), CompartmentId: strPtr("comp-1")},
+				{Id: strPtr("platform"), OperatingSystem: strPtr("Oracle Linux"), OperatingSystemVersion: strPtr("9")},
+			},
+		}
+		filters := []TagFilter{{Key: "team", Value: "platform"}}
+
+		images, err := discoverImages(context.Background(), mock, "comp-1", filters)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, img := range images {
+			if img.ID != "platform" {
+				t.Errorf("untagged custom image should be filtered out, got %s", img.ID)
+			}
+		}
+		if len(images) == 0 {
+			t.Error("platform images should not be filtered")
+		}
+	})
+
 	t.Run("handles image list error gracefully", func(t *testing.T) {
 		mock := &mockComputeClient{imageErr: fmt.Errorf("api error")}
 		// discoverImages breaks on error per-OS, doesn't propagate
-		images, err := discoverImages(context.Background(), mock, "comp-1")
+		images, err := discoverImages(context.Background(), mock, "comp-1", nil)
 		if err != nil {
 			t.Fatalf("expected nil error (errors are swallowed per-OS), got: %v", err)
 		}
@@ -413,6 +997,13 @@ func TestDiscoverImages(t *testing.T) {
 	})
 }
 
+// noWarn fails the test when discovery warns.
+func noWarn(t *testing.T) func(error) {
+	return func(err error) {
+		t.Errorf("unexpected warning: %v", err)
+	}
+}
+
 func TestDiscoverVCNs(t *testing.T) {
 	t.Run("returns VCNs with subnets", func(t *testing.T) {
 		mock := &mockVirtualNetworkClient{
@@ -423,6 +1014,8 @@ func TestDiscoverVCNs(t *testing.T) {
 					CidrBlock:     strPtr("10.0.0.0/16"),
 					CompartmentId: strPtr("comp-1"),
 					DnsLabel:      strPtr("main"),
+					FreeformTags:  map[string]string{"env": "prod"},
+					DefinedTags:   map[string]map[string]interface{}{"Operations": {"CostCenter": 42}},
 				},
 			},
 			subnets: []core.Subnet{
@@ -448,7 +1041,7 @@ func TestDiscoverVCNs(t *testing.T) {
 			},
 		}
 
-		vcns, err := discoverVCNs(context.Background(), mock, "comp-1")
+		vcns, err := discoverVCNs(context.Background(), mock, "comp-1", nil, noWarn(t))
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -458,6 +1051,12 @@ func TestDiscoverVCNs(t *testing.T) {
 		if vcns[0].DisplayName != "main-vcn" {
 			t.Errorf("unexpected VCN name: %s", vcns[0].DisplayName)
 		}
+		if vcns[0].FreeformTags["env"] != "prod" {
+			t.Errorf("expected freeform tag env=prod, got %v", vcns[0].FreeformTags)
+		}
+		if vcns[0].DefinedTags["Operations"]["CostCenter"] != "42" {
+			t.Errorf("expected defined tag Operations.CostCenter=42, got %v", vcns[0].DefinedTags)
+		}
 		if len(vcns[0].Subnets) != 1 {
 			t.Errorf("expected 1 subnet, got %d", len(vcns[0].Subnets))
 		}
@@ -475,10 +1074,42 @@ func TestDiscoverVCNs(t *testing.T) {
 		}
 	})
 
-	t.Run("error", func(t *testing.T) {
-		mock := &mockVirtualNetworkClient{vcnErr: fmt.Errorf("api error")}
-		_, err := discoverVCNs(context.Background(), mock, "comp-1")
-		if err == nil {
+	t.Run("skips VCNs and children not matching tag filters", func(t *testing.T) {
+		team := map[string]map[string]interface{}{"Operations": {"Team": "platform"}}
+		mock := &mockVirtualNetworkClient{
+			vcns: []core.Vcn{
+				{Id: strPtr("vcn-1"), DisplayName: strPtr("ours"), CompartmentId: strPtr("comp-1"), DefinedTags: team},
+				{Id: strPtr("vcn-2"), DisplayName: strPtr("theirs"), CompartmentId: strPtr("comp-1")},
+			},
+			subnets: []core.Subnet{
+				{Id: strPtr("sub-1"), DisplayName: strPtr("tagged"), ProhibitPublicIpOnVnic: boolPtr(true), DefinedTags: team},
+				{Id: strPtr("sub-2"), DisplayName: strPtr("untagged"), ProhibitPublicIpOnVnic: boolPtr(true)},
+			},
+			internetGateways: []core.InternetGateway{
+				{Id: strPtr("igw-1"), DisplayName: strPtr("igw"), IsEnabled: boolPtr(true)},
+			},
+		}
+		filters := []TagFilter{{Namespace: "operations", Key: "team", Value: "platform"}}
+
+		vcns, err := discoverVCNs(context.Background(), mock, "comp-1", filters, noWarn(t))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(vcns) != 1 || vcns[0].ID != "vcn-1" {
+			t.Fatalf("expected only the tagged VCN, got %+v", vcns)
+		}
+		if len(vcns[0].Subnets) != 1 || vcns[0].Subnets[0].ID != "sub-1" {
+			t.Errorf("expected only the tagged subnet, got %+v", vcns[0].Subnets)
+		}
+		if vcns[0].InternetGateway != nil {
+			t.Error("untagged internet gateway should be filtered out")
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock := &mockVirtualNetworkClient{vcnErr: fmt.Errorf("api error")}
+		_, err := discoverVCNs(context.Background(), mock, "comp-1", nil, noWarn(t))
+		if err == nil {
 			t.Fatal("expected error, got nil")
 		}
 	})
@@ -489,7 +1120,7 @@ func TestDiscoverVCNs(t *testing.T) {
 				{Id: strPtr("vcn-1"), DisplayName: strPtr("vcn"), CidrBlock: strPtr("10.0.0.0/16"), CompartmentId: strPtr("comp-1")},
 			},
 		}
-		vcns, err := discoverVCNs(context.Background(), mock, "comp-1")
+		vcns, err := discoverVCNs(context.Background(), mock, "comp-1", nil, noWarn(t))
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -500,6 +1131,81 @@ func TestDiscoverVCNs(t *testing.T) {
 			t.Error("expected nil NAT gateway when none exist")
 		}
 	})
+
+	t.Run("warns about child resources that cannot be listed", func(t *testing.T) {
+		mock := &mockVirtualNetworkClient{
+			vcns: []core.Vcn{
+				{Id: strPtr("vcn-1"), DisplayName: strPtr("main-vcn"), CompartmentId: strPtr("comp-1")},
+			},
+			subnetErr: fmt.Errorf("subnet api error"),
+			natErr:    fmt.Errorf("nat api error"),
+		}
+		var mu sync.Mutex
+		var warnings []string
+		vcns, err := discoverVCNs(context.Background(), mock, "comp-1", nil, func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			warnings = append(warnings, err.Error())
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(vcns) != 1 || vcns[0].Subnets != nil {
+			t.Fatalf("expected the VCN without subnets, got %+v", vcns)
+		}
+		slices.Sort(warnings)
+		if len(warnings) != 2 || !strings.HasPrefix(warnings[0], "NAT gateway discovery for VCN main-vcn: nat api error") ||
+			!strings.HasPrefix(warnings[1], "subnet discovery for VCN main-vcn: subnet api error") {
+			t.Errorf("expected NAT gateway and subnet warnings, got %q", warnings)
+		}
+	})
+
+	t.Run("lists subnets of every VCN concurrently", func(t *testing.T) {
+		mock := &barrierSubnets{
+			mockVirtualNetworkClient: &mockVirtualNetworkClient{
+				vcns: []core.Vcn{
+					{Id: strPtr("vcn-1"), DisplayName: strPtr("a"), CompartmentId: strPtr("comp-1")},
+					{Id: strPtr("vcn-2"), DisplayName: strPtr("b"), CompartmentId: strPtr("comp-1")},
+					{Id: strPtr("vcn-3"), DisplayName: strPtr("c"), CompartmentId: strPtr("comp-1")},
+				},
+				subnets: []core.Subnet{{Id: strPtr("sub-1"), ProhibitPublicIpOnVnic: boolPtr(true)}},
+			},
+			release: make(chan struct{}),
+		}
+		mock.arrived.Add(3)
+		go func() {
+			mock.arrived.Wait()
+			close(mock.release)
+		}()
+
+		vcns, err := discoverVCNs(context.Background(), mock, "comp-1", nil, noWarn(t))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for i, id := range []string{"vcn-1", "vcn-2", "vcn-3"} {
+			if vcns[i].ID != id || len(vcns[i].Subnets) != 1 {
+				t.Errorf("VCN %d: expected %s with its subnet, got %+v", i, id, vcns[i])
+			}
+		}
+	})
+}
+
+// barrierSubnets holds every ListSubnets call until one has arrived for each
+// VCN, failing calls that wait too long because they were made sequentially.
+type barrierSubnets struct {
+	*mockVirtualNetworkClient
+	arrived sync.WaitGroup
+	release chan struct{}
+}
+
+func (m *barrierSubnets) ListSubnets(ctx context.Context, req core.ListSubnetsRequest) (core.ListSubnetsResponse, error) {
+	m.arrived.Done()
+	select {
+	case <-m.release:
+		return m.mockVirtualNetworkClient.ListSubnets(ctx, req)
+	case <-time.After(5 * time.Second):
+		return core.ListSubnetsResponse{}, fmt.Errorf("subnets for %s listed sequentially", *req.VcnId)
+	}
 }
 
 func TestDiscoverBlockVolumes(t *testing.T) {
@@ -555,6 +1261,137 @@ func TestDiscoverBlockVolumes(t *testing.T) {
 	})
 }
 
+func TestDiscoverBootVolumes(t *testing.T) {
+	t.Run("returns boot volumes for each AD", func(t *testing.T) {
+		mock := &mockBlockstorageClient{
+			bootVolumes: []core.BootVolume{
+				{
+					Id:                 strPtr("bv-1"),
+					DisplayName:        strPtr("web (Boot Volume)"),
+					AvailabilityDomain: strPtr("AD-1"),
+					SizeInGBs:          intPtr(50),
+					VpusPerGB:          intPtr(10),
+					ImageId:            strPtr("img-1"),
+				},
+			},
+		}
+
+		volumes, err := discoverBootVolumes(context.Background(), mock, "comp-1", []string{"AD-1", "AD-2"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		// The mock returns the same volume for every AD queried
+		if len(volumes) != 2 {
+			t.Fatalf("expected 2 boot volumes (one per AD), got %d", len(volumes))
+		}
+		if volumes[0].SizeGB != 50 || volumes[0].VPUsPerGB != 10 {
+			t.Errorf("unexpected size/VPUs: %d/%d", volumes[0].SizeGB, volumes[0].VPUsPerGB)
+		}
+		if volumes[0].ImageID != "img-1" {
+			t.Errorf("expected image ID img-1, got %q", volumes[0].ImageID)
+		}
+	})
+
+	t.Run("no ADs", func(t *testing.T) {
+		volumes, err := discoverBootVolumes(context.Background(), &mockBlockstorageClient{}, "comp-1", nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(volumes) != 0 {
+			t.Errorf("expected 0 boot volumes, got %d", len(volumes))
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock := &mockBlockstorageClient{bootVolumeErr: fmt.Errorf("api error")}
+		_, err := discoverBootVolumes(context.Background(), mock, "comp-1", []string{"AD-1"})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+func TestDiscoverBackupPolicies(t *testing.T) {
+	t.Run("returns Oracle-defined and custom policies", func(t *testing.T) {
+		mock := &mockBlockstorageClient{
+			backupPolicies: []core.VolumeBackupPolicy{
+				{
+					Id:          strPtr("policy-gold"),
+					DisplayName: strPtr("gold"),
+					Schedules: []core.VolumeBackupSchedule{
+						{BackupType: core.VolumeBackupScheduleBackupTypeIncremental, Period: core.VolumeBackupSchedulePeriodDay, RetentionSeconds: common.Int(604800)},
+					},
+				},
+				{
+					Id:            strPtr("policy-custom"),
+					DisplayName:   strPtr("nightly"),
+					CompartmentId: strPtr("comp-1"),
+				},
+			},
+		}
+
+		policies, err := discoverBackupPolicies(context.Background(), mock, "comp-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(policies) != 2 {
+			t.Fatalf("expected 2 policies, got %d", len(policies))
+		}
+		if !policies[0].IsOracleDefined || policies[0].DisplayName != "gold" {
+			t.Errorf("expected Oracle-defined gold policy first, got %+v", policies[0])
+		}
+		if len(policies[0].Schedules) != 1 || policies[0].Schedules[0].RetentionSeconds != 604800 {
+			t.Errorf("unexpected schedules: %+v", policies[0].Schedules)
+		}
+		if policies[1].IsOracleDefined {
+			t.Error("custom policy should not be marked Oracle-defined")
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock := &mockBlockstorageClient{backupPolicyErr: fmt.Errorf("api error")}
+		_, err := discoverBackupPolicies(context.Background(), mock, "comp-1")
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+func TestDiscoverVolumeGroups(t *testing.T) {
+	t.Run("returns volume groups", func(t *testing.T) {
+		mock := &mockBlockstorageClient{
+			volumeGroups: []core.VolumeGroup{
+				{
+					Id:                 strPtr("vg-1"),
+					DisplayName:        strPtr("app"),
+					AvailabilityDomain: strPtr("AD-1"),
+					SizeInGBs:          intPtr(100),
+					VolumeIds:          []string{"vol-1", "vol-2"},
+				},
+			},
+		}
+
+		groups, err := discoverVolumeGroups(context.Background(), mock, "comp-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(groups) != 1 {
+			t.Fatalf("expected 1 volume group, got %d", len(groups))
+		}
+		if groups[0].SizeGB != 100 || len(groups[0].VolumeIDs) != 2 {
+			t.Errorf("unexpected volume group: %+v", groups[0])
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock := &mockBlockstorageClient{volumeGroupErr: fmt.Errorf("api error")}
+		_, err := discoverVolumeGroups(context.Background(), mock, "comp-1")
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
 func TestDiscoverLimits(t *testing.T) {
 	t.Run("returns limits filtering zero values", func(t *testing.T) {
 		mock := &mockLimitsClient{
@@ -604,7 +1441,7 @@ func TestDiscoverOKEImages(t *testing.T) {
 			},
 		}
 
-		images, err := discoverOKEImages(context.Background(), mock, "comp-1")
+		images, _, err := discoverOKEImages(context.Background(), mock, "comp-1")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -622,6 +1459,36 @@ func TestDiscoverOKEImages(t *testing.T) {
 		}
 	})
 
+	t.Run("maps images to supported versions", func(t *testing.T) {
+		mock := &mockContainerEngineClient{
+			versions: []string{"v1.31.10", "v1.32.1"},
+			sources: []containerengine.NodeSourceOption{
+				containerengine.NodeSourceViaImageOption{
+					SourceName: strPtr("Oracle-Linux-8.10-2025.11.20-0-OKE-1.31.10-1345"),
+					ImageId:    strPtr("ocid1.image.oc1..supported"),
+				},
+				containerengine.NodeSourceViaImageOption{
+					SourceName: strPtr("Oracle-Linux-8.10-2025.01.20-0-OKE-1.29.1-900"),
+					ImageId:    strPtr("ocid1.image.oc1..retired"),
+				},
+			},
+		}
+
+		images, versions, err := discoverOKEImages(context.Background(), mock, "comp-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(versions) != 2 || versions[1] != "v1.32.1" {
+			t.Errorf("expected supported versions from node pool options, got %v", versions)
+		}
+		if !images[0].IsSupported {
+			t.Error("1.31.10 image should map to supported version v1.31.10")
+		}
+		if images[1].IsSupported {
+			t.Error("1.29.1 image should not be supported")
+		}
+	})
+
 	t.Run("skips entries with empty fields", func(t *testing.T) {
 		mock := &mockContainerEngineClient{
 			sources: []containerengine.NodeSourceOption{
@@ -635,7 +1502,7 @@ func TestDiscoverOKEImages(t *testing.T) {
 				},
 			},
 		}
-		images, err := discoverOKEImages(context.Background(), mock, "comp-1")
+		images, _, err := discoverOKEImages(context.Background(), mock, "comp-1")
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -646,13 +1513,524 @@ func TestDiscoverOKEImages(t *testing.T) {
 
 	t.Run("error", func(t *testing.T) {
 		mock := &mockContainerEngineClient{ceErr: fmt.Errorf("api error")}
-		_, err := discoverOKEImages(context.Background(), mock, "comp-1")
+		_, _, err := discoverOKEImages(context.Background(), mock, "comp-1")
 		if err == nil {
 			t.Fatal("expected error, got nil")
 		}
 	})
 }
 
+func TestDiscoverOKEClusters(t *testing.T) {
+	t.Run("returns clusters with endpoints and node pools", func(t *testing.T) {
+		mock := &mockContainerEngineClient{
+			clusters: []containerengine.ClusterSummary{
+				{
+					Id:                strPtr("cluster-1"),
+					Name:              strPtr("prod"),
+					CompartmentId:     strPtr("comp-1"),
+					VcnId:             strPtr("vcn-1"),
+					KubernetesVersion: strPtr("v1.31.1"),
+					Type:              containerengine.ClusterTypeBasicCluster,
+					EndpointConfig:    &containerengine.ClusterEndpointConfig{SubnetId: strPtr("sub-api"), IsPublicIpEnabled: boolPtr(true)},
+					Endpoints:         &containerengine.ClusterEndpoints{PublicEndpoint: strPtr("1.2.3.4:6443"), PrivateEndpoint: strPtr("10.0.0.2:6443")},
+					Options:           &containerengine.ClusterCreateOptions{ServiceLbSubnetIds: []string{"sub-lb"}},
+					ClusterPodNetworkOptions: []containerengine.ClusterPodNetworkOptionDetails{
+						containerengine.OciVcnIpNativeClusterPodNetworkOptionDetails{},
+					},
+				},
+			},
+			nodePools: []containerengine.NodePoolSummary{
+				{
+					Id:                strPtr("np-1"),
+					ClusterId:         strPtr("cluster-1"),
+					Name:              strPtr("pool1"),
+					KubernetesVersion: strPtr("v1.31.1"),
+					NodeShape:         strPtr("VM.Standard.A1.Flex"),
+					NodeShapeConfig:   &containerengine.NodeShapeConfig{Ocpus: f32Ptr(2), MemoryInGBs: f32Ptr(12)},
+					NodeSourceDetails: containerengine.NodeSourceViaImageDetails{ImageId: strPtr("img-1")},
+					NodeConfigDetails: &containerengine.NodePoolNodeConfigDetails{
+						Size: common.Int(3),
+						PlacementConfigs: []containerengine.NodePoolPlacementConfigDetails{
+							{AvailabilityDomain: strPtr("AD-1"), SubnetId: strPtr("sub-workers")},
+							{AvailabilityDomain: strPtr("AD-2"), SubnetId: strPtr("sub-workers")},
+						},
+					},
+				},
+				{Id: strPtr("np-other"), ClusterId: strPtr("cluster-2"), Name: strPtr("other")},
+			},
+		}
+
+		clusters, err := discoverOKEClusters(context.Background(), mock, "comp-1", nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(clusters) != 1 {
+			t.Fatalf("expected 1 cluster, got %d", len(clusters))
+		}
+		c := clusters[0]
+		if c.KubernetesVersion != "v1.31.1" || !c.IsPublicEndpoint || c.EndpointSubnetID != "sub-api" {
+			t.Errorf("unexpected cluster: %+v", c)
+		}
+		if c.CNIType != "OCI_VCN_IP_NATIVE" {
+			t.Errorf("expected OCI_VCN_IP_NATIVE CNI, got %q", c.CNIType)
+		}
+		if c.PublicEndpoint != "1.2.3.4:6443" || len(c.ServiceLBSubnetIDs) != 1 {
+			t.Errorf("unexpected endpoints: %+v", c)
+		}
+		if len(c.NodePools) != 1 {
+			t.Fatalf("expected 1 node pool for cluster-1, got %d", len(c.NodePools))
+		}
+		np := c.NodePools[0]
+		if np.OCPUs != 2 || np.Size != 3 || np.ImageID != "img-1" {
+			t.Errorf("unexpected node pool: %+v", np)
+		}
+		if len(np.SubnetIDs) != 1 || np.SubnetIDs[0] != "sub-workers" {
+			t.Errorf("expected deduplicated placement subnets, got %v", np.SubnetIDs)
+		}
+	})
+
+	t.Run("tag filters skip clusters", func(t *testing.T) {
+		mock := &mockContainerEngineClient{
+			clusters: []containerengine.ClusterSummary{{Id: strPtr("cluster-1"), Name: strPtr("theirs")}},
+		}
+		clusters, err := discoverOKEClusters(context.Background(), mock, "comp-1", []TagFilter{{Key: "team", Value: "ours"}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(clusters) != 0 {
+			t.Errorf("expected untagged cluster to be skipped, got %+v", clusters)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock := &mockContainerEngineClient{clusterErr: fmt.Errorf("api error")}
+		if _, err := discoverOKEClusters(context.Background(), mock, "comp-1", nil); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
+
+func TestDiscoverRegistry(t *testing.T) {
+	identityMock := &mockIdentityClient{
+		regions: []identity.Region{
+			{Key: strPtr("IAD"), Name: strPtr("us-ashburn-1")},
+			{Key: strPtr("FRA"), Name: strPtr("eu-frankfurt-1")},
+		},
+	}
+	osMock := &mockObjectStorageClient{namespace: "axabc123"}
+
+	t.Run("resolves namespace, endpoint and repositories", func(t *testing.T) {
+		artifactsMock := &mockArtifactsClient{
+			repos: []artifacts.ContainerRepositorySummary{
+				{Id: strPtr("repo-1"), DisplayName: strPtr("team/api"), CompartmentId: strPtr("comp-1"), IsPublic: boolPtr(false), ImageCount: common.Int(4)},
+				{Id: strPtr("repo-2"), DisplayName: strPtr("web"), CompartmentId: strPtr("comp-1"), IsPublic: boolPtr(true), ImageCount: common.Int(1)},
+			},
+		}
+
+		registry, err := discoverRegistry(context.Background(), identityMock, osMock, artifactsMock, "comp-1", "eu-frankfurt-1", nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if registry.Endpoint != "fra.ocir.io" {
+			t.Errorf("expected endpoint 'fra.ocir.io', got %q", registry.Endpoint)
+		}
+		if registry.URL() != "fra.ocir.io/axabc123" {
+			t.Errorf("expected registry URL 'fra.ocir.io/axabc123', got %q", registry.URL())
+		}
+		if len(registry.Repositories) != 2 {
+			t.Fatalf("expected 2 repositories, got %d", len(registry.Repositories))
+		}
+		if r := registry.Repositories[0]; r.Name != "team/api" || r.IsPublic || r.ImageCount != 4 {
+			t.Errorf("unexpected repository: %+v", r)
+		}
+	})
+
+	t.Run("tag filters skip repositories", func(t *testing.T) {
+		artifactsMock := &mockArtifactsClient{
+			repos: []artifacts.ContainerRepositorySummary{{Id: strPtr("repo-1"), DisplayName: strPtr("theirs")}},
+		}
+		registry, err := discoverRegistry(context.Background(), identityMock, osMock, artifactsMock, "comp-1", "us-ashburn-1", []TagFilter{{Key: "team", Value: "ours"}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(registry.Repositories) != 0 {
+			t.Errorf("expected untagged repository to be skipped, got %+v", registry.Repositories)
+		}
+		if registry.Namespace != "axabc123" {
+			t.Errorf("expected namespace to be kept, got %q", registry.Namespace)
+		}
+	})
+
+	t.Run("unknown region", func(t *testing.T) {
+		_, err := discoverRegistry(context.Background(), identityMock, osMock, &mockArtifactsClient{}, "comp-1", "ap-nowhere-1", nil)
+		if err == nil {
+			t.Error("expected error for region without a key, got nil")
+		}
+	})
+
+	t.Run("namespace error", func(t *testing.T) {
+		_, err := discoverRegistry(context.Background(), identityMock, &mockObjectStorageClient{nsErr: fmt.Errorf("api error")}, &mockArtifactsClient{}, "comp-1", "us-ashburn-1", nil)
+		if err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
+
+func TestOCIREndpoint(t *testing.T) {
+	tests := []struct {
+		key, region, want string
+	}{
+		{"IAD", "us-ashburn-1", "iad.ocir.io"},
+		{"PHX", "us-phoenix-1", "phx.ocir.io"},
+		{"LTN", "uk-gov-london-1", "ltn.ocir.oraclegovcloud.uk"},
+	}
+	for _, tt := range tests {
+		got, err := ocirEndpoint(tt.key, tt.region)
+		if err != nil {
+			t.Errorf("ocirEndpoint(%q, %q): unexpected error: %v", tt.key, tt.region, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ocirEndpoint(%q, %q) = %q, want %q", tt.key, tt.region, got, tt.want)
+		}
+	}
+}
+
+func TestDiscoverFunctionsApplications(t *testing.T) {
+	t.Run("returns applications", func(t *testing.T) {
+		mock := &mockFunctionsClient{
+			apps: []functions.ApplicationSummary{
+				{
+					Id:            strPtr("app-1"),
+					DisplayName:   strPtr("billing"),
+					CompartmentId: strPtr("comp-1"),
+					SubnetIds:     []string{"sub-priv"},
+					Shape:         functions.ApplicationSummaryShapeArm,
+				},
+			},
+		}
+
+		apps, err := discoverFunctionsApplications(context.Background(), mock, "comp-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(apps) != 1 {
+			t.Fatalf("expected 1 application, got %d", len(apps))
+		}
+		if apps[0].Name != "billing" || apps[0].Shape != "GENERIC_ARM" || len(apps[0].SubnetIDs) != 1 {
+			t.Errorf("unexpected application: %+v", apps[0])
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock := &mockFunctionsClient{appErr: fmt.Errorf("api error")}
+		if _, err := discoverFunctionsApplications(context.Background(), mock, "comp-1"); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
+
+func TestDiscoverDNS(t *testing.T) {
+	mock := &mockDNSClient{
+		zones: []dns.ZoneSummary{
+			{Id: strPtr("zone-pub"), Name: strPtr("example.com"), CompartmentId: strPtr("comp-1"), ZoneType: dns.ZoneSummaryZoneTypePrimary, Scope: dns.ScopeGlobal},
+			{Id: strPtr("zone-priv"), Name: strPtr("internal.example"), CompartmentId: strPtr("comp-1"), ZoneType: dns.ZoneSummaryZoneTypePrimary, Scope: dns.ScopePrivate, ViewId: strPtr("view-1")},
+		},
+		views: []dns.ViewSummary{
+			{Id: strPtr("view-1"), DisplayName: strPtr("corp"), CompartmentId: strPtr("comp-1"), IsProtected: boolPtr(false)},
+			{Id: strPtr("view-vcn"), DisplayName: strPtr("main-vcn"), CompartmentId: strPtr("comp-1"), IsProtected: boolPtr(true)},
+		},
+		resolvers: []dns.ResolverSummary{
+			{Id: strPtr("resolver-1"), DisplayName: strPtr("main-vcn"), CompartmentId: strPtr("comp-1"), AttachedVcnId: strPtr("vcn-1"), DefaultViewId: strPtr("view-vcn")},
+		},
+		endpoints: map[string][]dns.ResolverEndpointSummary{
+			"resolver-1": {
+				dns.ResolverVnicEndpointSummary{Name: strPtr("inbound"), SubnetId: strPtr("sub-priv"), IsListening: boolPtr(true), IsForwarding: boolPtr(false), ListeningAddress: strPtr("10.0.1.53")},
+			},
+		},
+	}
+
+	t.Run("zones from both scopes", func(t *testing.T) {
+		zones, err := discoverDNSZones(context.Background(), mock, "comp-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(zones) != 2 {
+			t.Fatalf("expected 2 zones, got %d", len(zones))
+		}
+		if zones[0].Scope != "GLOBAL" || zones[1].Scope != "PRIVATE" || zones[1].ViewID != "view-1" {
+			t.Errorf("unexpected zones: %+v", zones)
+		}
+	})
+
+	t.Run("views", func(t *testing.T) {
+		views, err := discoverDNSViews(context.Background(), mock, "comp-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(views) != 2 || views[0].IsProtected || !views[1].IsProtected {
+			t.Errorf("unexpected views: %+v", views)
+		}
+	})
+
+	t.Run("resolvers with endpoints", func(t *testing.T) {
+		resolvers, err := discoverDNSResolvers(context.Background(), mock, "comp-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(resolvers) != 1 {
+			t.Fatalf("expected 1 resolver, got %d", len(resolvers))
+		}
+		r := resolvers[0]
+		if r.VCNID != "vcn-1" || r.DefaultViewID != "view-vcn" {
+			t.Errorf("unexpected resolver: %+v", r)
+		}
+		if len(r.Endpoints) != 1 {
+			t.Fatalf("expected 1 endpoint, got %d", len(r.Endpoints))
+		}
+		if ep := r.Endpoints[0]; !ep.IsListening || ep.SubnetID != "sub-priv" || ep.ListeningAddress != "10.0.1.53" {
+			t.Errorf("unexpected endpoint: %+v", ep)
+		}
+	})
+
+	t.Run("zone error", func(t *testing.T) {
+		if _, err := discoverDNSZones(context.Background(), &mockDNSClient{zoneErr: fmt.Errorf("api error")}, "comp-1"); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
+
+func TestDiscoverObservability(t *testing.T) {
+	t.Run("log groups", func(t *testing.T) {
+		mock := &mockLoggingClient{
+			groups: []logging.LogGroupSummary{
+				{Id: strPtr("lg-1"), DisplayName: strPtr("app-logs"), CompartmentId: strPtr("comp-1"), Description: strPtr("Application logs")},
+			},
+		}
+		groups, err := discoverLogGroups(context.Background(), mock, "comp-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(groups) != 1 || groups[0].Name != "app-logs" || groups[0].Description != "Application logs" {
+			t.Errorf("unexpected log groups: %+v", groups)
+		}
+	})
+
+	t.Run("log group error", func(t *testing.T) {
+		if _, err := discoverLogGroups(context.Background(), &mockLoggingClient{err: fmt.Errorf("api error")}, "comp-1"); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+
+	t.Run("topics", func(t *testing.T) {
+		mock := &mockNotificationClient{
+			topics: []ons.NotificationTopicSummary{
+				{TopicId: strPtr("topic-1"), Name: strPtr("ops-alerts"), CompartmentId: strPtr("comp-1"), ApiEndpoint: strPtr("https://notification.us-ashburn-1.oci.oraclecloud.com")},
+			},
+		}
+		topics, err := discoverNotificationTopics(context.Background(), mock, "comp-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(topics) != 1 || topics[0].ID != "topic-1" || topics[0].Name != "ops-alerts" {
+			t.Errorf("unexpected topics: %+v", topics)
+		}
+	})
+
+	t.Run("alarms", func(t *testing.T) {
+		mock := &mockMonitoringClient{
+			alarms: []monitoring.AlarmSummary{
+				{
+					Id:           strPtr("alarm-1"),
+					DisplayName:  strPtr("cpu-high"),
+					Namespace:    strPtr("oci_computeagent"),
+					Query:        strPtr("CpuUtilization[1m].mean() > 80"),
+					Severity:     monitoring.AlarmSummarySeverityCritical,
+					Destinations: []string{"topic-1"},
+					IsEnabled:    boolPtr(true),
+				},
+			},
+		}
+		alarms, err := discoverAlarms(context.Background(), mock, "comp-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(alarms) != 1 {
+			t.Fatalf("expected 1 alarm, got %d", len(alarms))
+		}
+		a := alarms[0]
+		if a.Severity != "CRITICAL" || !a.IsEnabled || a.Namespace != "oci_computeagent" || len(a.Destinations) != 1 {
+			t.Errorf("unexpected alarm: %+v", a)
+		}
+	})
+}
+
+func TestDiscoverBudgets(t *testing.T) {
+	t.Run("returns budgets with alert rules", func(t *testing.T) {
+		mock := &mockBudgetClient{
+			budgets: []budget.BudgetSummary{
+				{
+					Id:          strPtr("budget-1"),
+					DisplayName: strPtr("dev"),
+					Amount:      f32Ptr(250.5),
+					ResetPeriod: budget.ResetPeriodMonthly,
+					TargetType:  budget.TargetTypeCompartment,
+					Targets:     []string{"comp-1"},
+					ActualSpend: f32Ptr(12.1),
+				},
+			},
+			rules: map[string][]budget.AlertRuleSummary{
+				"budget-1": {
+					{Id: strPtr("rule-1"), DisplayName: strPtr("forecast-100"), Type: budget.AlertTypeForecast, Threshold: f32Ptr(100), ThresholdType: budget.ThresholdTypePercentage, Recipients: strPtr("ops@example.com")},
+				},
+			},
+		}
+
+		budgets, err := discoverBudgets(context.Background(), mock, "tenancy-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(budgets) != 1 {
+			t.Fatalf("expected 1 budget, got %d", len(budgets))
+		}
+		b := budgets[0]
+		if b.Amount != 250.5 || b.ActualSpend != 12.1 || b.ResetPeriod != "MONTHLY" || b.TargetType != "COMPARTMENT" {
+			t.Errorf("unexpected budget: %+v", b)
+		}
+		if len(b.AlertRules) != 1 || b.AlertRules[0].Type != "FORECAST" || b.AlertRules[0].Threshold != 100 {
+			t.Errorf("unexpected alert rules: %+v", b.AlertRules)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		mock := &mockBudgetClient{budgetErr: fmt.Errorf("api error")}
+		if _, err := discoverBudgets(context.Background(), mock, "tenancy-1"); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
+
+func TestDiscoverQuotas(t *testing.T) {
+	t.Run("returns quotas with statements", func(t *testing.T) {
+		mock := &mockQuotasClient{
+			quotas: []lim.QuotaSummary{
+				{Id: strPtr("quota-1"), Name: strPtr("dev-shapes"), CompartmentId: strPtr("tenancy-1"), Description: strPtr("Dev limits")},
+			},
+			statements: map[string][]string{
+				"quota-1": {"zero compute-core quotas in compartment dev"},
+			},
+		}
+
+		quotas, err := discoverQuotas(context.Background(), mock, "tenancy-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(quotas) != 1 || quotas[0].Name != "dev-shapes" || len(quotas[0].Statements) != 1 {
+			t.Errorf("unexpected quotas: %+v", quotas)
+		}
+	})
+
+	t.Run("get error", func(t *testing.T) {
+		mock := &mockQuotasClient{
+			quotas: []lim.QuotaSummary{{Id: strPtr("quota-1"), Name: strPtr("dev-shapes")}},
+			getErr: fmt.Errorf("api error"),
+		}
+		if _, err := discoverQuotas(context.Background(), mock, "tenancy-1"); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
+
+func TestDiscoverCapacity(t *testing.T) {
+	ads := []AvailabilityDomain{
+		{Name: "AD-1", FaultDomains: []string{"FAULT-DOMAIN-1", "FAULT-DOMAIN-2"}},
+		{Name: "AD-2", FaultDomains: []string{"FAULT-DOMAIN-1", "FAULT-DOMAIN-2"}},
+	}
+	available := func(n int64) core.CapacityReportShapeAvailability {
+		return core.CapacityReportShapeAvailability{AvailabilityStatus: core.CapacityReportShapeAvailabilityAvailabilityStatusAvailable, AvailableCount: &n}
+	}
+	full := core.CapacityReportShapeAvailability{AvailabilityStatus: core.CapacityReportShapeAvailabilityAvailabilityStatusOutOfHostCapacity}
+
+	t.Run("selects the fault domain with the most capacity", func(t *testing.T) {
+		mock := &mockComputeClient{capacity: map[string]core.CapacityReportShapeAvailability{
+			"AD-1/FAULT-DOMAIN-1": full,
+			"AD-1/FAULT-DOMAIN-2": full,
+			"AD-2/FAULT-DOMAIN-1": available(1),
+			"AD-2/FAULT-DOMAIN-2": available(3),
+		}}
+
+		report, err := discoverCapacity(context.Background(), mock, "tenancy-1", ads, "VM.Standard.A1.Flex", 2, 12, noWarn(t))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(report.Domains) != 4 {
+			t.Fatalf("expected 4 domain entries, got %d", len(report.Domains))
+		}
+		if report.Domains[0].Status != "OUT_OF_HOST_CAPACITY" || report.Domains[3].AvailableCount != 3 {
+			t.Errorf("unexpected domains: %+v", report.Domains)
+		}
+		if report.SelectedAD != "AD-2" || report.SelectedFaultDomain != "FAULT-DOMAIN-2" {
+			t.Errorf("expected AD-2/FAULT-DOMAIN-2, got %s/%s", report.SelectedAD, report.SelectedFaultDomain)
+		}
+		if report.OCPUs != 2 || report.MemoryGB != 12 {
+			t.Errorf("expected 2 OCPU / 12 GB, got %v / %v", report.OCPUs, report.MemoryGB)
+		}
+	})
+
+	t.Run("no capacity anywhere", func(t *testing.T) {
+		mock := &mockComputeClient{capacity: map[string]core.CapacityReportShapeAvailability{
+			"AD-1/FAULT-DOMAIN-1": full, "AD-1/FAULT-DOMAIN-2": full,
+			"AD-2/FAULT-DOMAIN-1": full, "AD-2/FAULT-DOMAIN-2": full,
+		}}
+		report, err := discoverCapacity(context.Background(), mock, "tenancy-1", ads, "VM.Standard.A1.Flex", 2, 12, noWarn(t))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if report.SelectedAD != "" {
+			t.Errorf("expected no selection, got %s", report.SelectedAD)
+		}
+	})
+
+	t.Run("AD without fault domains", func(t *testing.T) {
+		mock := &mockComputeClient{capacity: map[string]core.CapacityReportShapeAvailability{"AD-1/": available(2)}}
+		report, err := discoverCapacity(context.Background(), mock, "tenancy-1", []AvailabilityDomain{{Name: "AD-1"}}, "VM.Standard.A1.Flex", 2, 12, noWarn(t))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if report.SelectedAD != "AD-1" || report.SelectedFaultDomain != "" {
+			t.Errorf("expected AD-1 with no fault domain, got %s/%s", report.SelectedAD, report.SelectedFaultDomain)
+		}
+	})
+
+	t.Run("AD whose report fails", func(t *testing.T) {
+		mock := &mockComputeClient{
+			capacity:    map[string]core.CapacityReportShapeAvailability{"AD-2/FAULT-DOMAIN-1": available(2)},
+			capacityErr: fmt.Errorf("api error"),
+			failAD:      "AD-1",
+		}
+		var warnings []error
+		report, err := discoverCapacity(context.Background(), mock, "tenancy-1", ads, "VM.Standard.A1.Flex", 2, 12, func(err error) {
+			warnings = append(warnings, err)
+		})
+		if err != nil {
+			t.Fatalf("expected the other AD's report, got %v", err)
+		}
+		if report.SelectedAD != "AD-2" || !slices.Equal(report.FailedADs, []string{"AD-1"}) {
+			t.Errorf("expected AD-2 selected and AD-1 failed, got %s and %v", report.SelectedAD, report.FailedADs)
+		}
+		if len(warnings) != 1 || !strings.Contains(warnings[0].Error(), "AD-1: api error") {
+			t.Errorf("expected a warning naming AD-1, got %v", warnings)
+		}
+	})
+
+	t.Run("error in every AD", func(t *testing.T) {
+		mock := &mockComputeClient{capacityErr: fmt.Errorf("api error")}
+		if _, err := discoverCapacity(context.Background(), mock, "tenancy-1", ads, "VM.Standard.A1.Flex", 2, 12, noWarn(t)); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
+
 func TestDiscoverTenancy(t *testing.T) {
 	t.Run("returns tenancy info", func(t *testing.T) {
 		mock := &mockIdentityClient{
@@ -739,7 +2117,7 @@ func TestOKEVersionPrefixAmbiguity(t *testing.T) {
 		},
 	}
 
-	images, err := discoverOKEImages(context.Background(), mock, "comp-1")
+	images, _, err := discoverOKEImages(context.Background(), mock, "comp-1")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -765,6 +2143,15 @@ var (
 	_ BlockstorageAPI    = (*mockBlockstorageClient)(nil)
 	_ LimitsAPI          = (*mockLimitsClient)(nil)
 	_ ContainerEngineAPI = (*mockContainerEngineClient)(nil)
+	_ ArtifactsAPI       = (*mockArtifactsClient)(nil)
+	_ ObjectStorageAPI   = (*mockObjectStorageClient)(nil)
+	_ FunctionsAPI       = (*mockFunctionsClient)(nil)
+	_ DNSAPI             = (*mockDNSClient)(nil)
+	_ LoggingAPI         = (*mockLoggingClient)(nil)
+	_ NotificationAPI    = (*mockNotificationClient)(nil)
+	_ MonitoringAPI      = (*mockMonitoringClient)(nil)
+	_ BudgetAPI          = (*mockBudgetClient)(nil)
+	_ QuotasAPI          = (*mockQuotasClient)(nil)
 )
 
 // Suppress unused import warning for common package (used in interface satisfaction checks).
@@ -0,0 +1,403 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+)
+
+// discoverer implements Discoverer for the built-in resource kinds.
+type discoverer struct {
+	name     string
+	label    string
+	services []string
+	fatal    bool
+	enabled  func(dctx *Context) bool // nil: always enabled
+	discover func(ctx context.Context, env *Env) (func(*Result), error)
+}
+
+func (d *discoverer) Name() string       { return d.name }
+func (d *discoverer) Label() string      { return d.label }
+func (d *discoverer) Services() []string { return d.services }
+func (d *discoverer) Fatal() bool        { return d.fatal }
+
+func (d *discoverer) Enabled(dctx *Context) bool {
+	return d.enabled == nil || d.enabled(dctx)
+}
+
+func (d *discoverer) Discover(ctx context.Context, env *Env) (func(*Result), error) {
+	return d.discover(ctx, env)
+}
+
+func init() {
+	for _, d := range builtinDiscoverers {
+		Register(d)
+	}
+}
+
+func alwaysFree(dctx *Context) bool { return dctx.AlwaysFree }
+
+// OKE images and clusters are discovered when requested and in always-free mode
+func okeEnabled(dctx *Context) bool { return dctx.AlwaysFree || dctx.OKE }
+
+var builtinDiscoverers = []*discoverer{
+	{
+		name: "tenancy", label: "Tenancy Details", services: []string{"identity"}, fatal: true,
+		discover: func(ctx context.Context, env *Env) (func(*Result), error) {
+			tenancy, err := cached(ctx, env.cache, "tenancy", func() (TenancyInfo, error) {
+				return discoverTenancy(ctx, env.Clients.Identity, env.Context.TenancyID)
+			})
+			if err != nil {
+				return nil, classifyOCIError("tenancy details", err)
+			}
+			return func(r *Result) {
+				r.Tenancy = tenancy
+				r.Tenancy.HomeRegion = env.Context.Region
+			}, nil
+		},
+	},
+	{
+		name: "compartments", label: "Compartments", services: []string{"identity"}, fatal: true,
+		discover: func(ctx context.Context, env *Env) (func(*Result), error) {
+			comps, err := env.Compartments()
+			if err != nil {
+				return nil, classifyOCIError("compartments", err)
+			}
+			return func(r *Result) { r.Compartments = filterByTags(comps, env.Context.TagFilters) }, nil
+		},
+	},
+	{
+		name: "availability-domains", label: "Availability Domains", services: []string{"identity"}, fatal: true,
+		discover: func(ctx context.Context, env *Env) (func(*Result), error) {
+			ads, err := env.AvailabilityDomains()
+			if err != nil {
+				return nil, classifyOCIError("availability domains", err)
+			}
+			return func(r *Result) { r.AvailabilityDomains = ads }, nil
+		},
+	},
+	{
+		// A1.Flex capacity varies by AD and minute to minute, so the report is
+		// never cached; it picks an AD with room for the free instance.
+		name: "a1-capacity", label: "A1.Flex Capacity", services: []string{"identity", "compute"}, enabled: alwaysFree,
+		discover: func(ctx context.Context, env *Env) (func(*Result), error) {
+			ads, err := env.AvailabilityDomains()
+			if err != nil {
+				return nil, classifyOCIError("A1.Flex capacity report", err)
+			}
+			report, err := discoverCapacity(ctx, env.Clients.Compute, env.Context.TenancyID, ads, "VM.Standard.A1.Flex", AlwaysFreeA1OCPUs, AlwaysFreeA1MemoryGB, env.Warn)
+			if err != nil {
+				return nil, classifyOCIError("A1.Flex capacity report", err)
+			}
+			if report.SelectedAD == "" {
+				env.Warn(fmt.Errorf("no availability domain reports A1.Flex capacity for %g OCPU / %g GB", report.OCPUs, report.MemoryGB))
+			}
+			return func(r *Result) { r.A1Capacity = report }, nil
+		},
+	},
+	{
+		name: "shapes", label: "Shapes", services: []string{"compute"}, fatal: true,
+		discover: func(ctx context.Context, env *Env) (func(*Result), error) {
+			shapes, err := cached(ctx, env.cache, "shapes", func() ([]Shape, error) {
+				return discoverShapes(ctx, env.Clients.Compute, env.Context.CompartmentID)
+			})
+			if err != nil {
+				return nil, classifyOCIError("shapes", err)
+			}
+			return func(r *Result) { r.Shapes = shapes }, nil
+		},
+	},
+	{
+		name: "images", label: "Images", services: []string{"compute"}, fatal: true,
+		discover: func(ctx context.Context, env *Env) (func(*Result), error) {
+			images, err := cached(ctx, env.cache, "images", func() ([]Image, error) {
+				return discoverImages(ctx, env.Clients.Compute, env.Context.CompartmentID, env.Context.TagFilters)
+			})
+			if err != nil {
+				return nil, classifyOCIError("images", err)
+			}
+			return func(r *Result) { r.Images = images }, nil
+		},
+	},
+	{
+		name: "vcns", label: "VCNs", services: []string{"network"},
+		discover: func(ctx context.Context, env *Env) (func(*Result), error) {
+			vcns, err := cachedWarn(ctx, env.cache, "vcns", env.Warn, func(warn func(error)) ([]VCN, error) {
+				return discoverVCNs(ctx, env.Clients.VirtualNetwork, env.Context.CompartmentID, env.Context.TagFilters, warn)
+			})
+			if err != nil {
+				return nil, classifyOCIError("VCN discovery", err)
+			}
+			return func(r *Result) { r.VCNs = vcns }, nil
+		},
+	},
+	{
+		name: "limits", label: "Service Limits", services: []string{"limits"},
+		discover: func(ctx context.Context, env *Env) (func(*Result), error) {
+			limits, err := cached(ctx, env.cache, "limits", func() ([]ServiceLimit, error) {
+				return discoverLimits(ctx, env.Clients.Limits, env.Context.TenancyID)
+			})
+			if err != nil {
+				return nil, classifyOCIError("service limits", err)
+			}
+			return func(r *Result) { r.Limits = limits }, nil
+		},
+	},
+	{
+		name: "block-volumes", label: "Block Volumes", services: []string{"blockstorage"},
+		discover: func(ctx context.Context, env *Env) (func(*Result), error) {
+			volumes, err := cached(ctx, env.cache, "block_volumes", func() ([]BlockVolume, error) {
+				return discoverBlockVolumes(ctx, env.Clients.Blockstorage, env.Context.CompartmentID)
+			})
+			if err != nil {
+				return nil, classifyOCIError("block volume discovery", err)
+			}
+			return func(r *Result) { r.BlockVolumes = filterByTags(volumes, env.Context.TagFilters) }, nil
+		},
+	},
+	{
+		name: "boot-volumes", label: "Boot Volumes", services: []string{"identity", "blockstorage"},
+		discover: func(ctx context.Context, env *Env) (func(*Result), error) {
+			ads, err := env.AvailabilityDomains()
+			if err != nil {
+				return nil, classifyOCIError("boot volume discovery", err)
+			}
+			adNames := make([]string, len(ads))
+			for i, ad := range ads {
+				adNames[i] = ad.Name
+			}
+			bootVolumes, err := cached(ctx, env.cache, "boot_volumes", func() ([]BootVolume, error) {
+				return discoverBootVolumes(ctx, env.Clients.Blockstorage, env.Context.CompartmentID, adNames)
+			})
+			if err != nil {
+				return nil, classifyOCIError("boot volume discovery", err)
+			}
+			return func(r *Result) { r.BootVolumes = filterByTags(bootVolumes, env.Context.TagFilters) }, nil
+		},
+	},
+	{
+		name: "backup-policies", label: "Backup Policies", services: []string{"blockstorage"},
+		discover: func(ctx context.Context, env *Env) (func(*Result), error) {
+			policies, err := cached(ctx, env.cache, "backup_policies", func() ([]BackupPolicy, error) {
+				return discoverBackupPolicies(ctx, env.Clients.Blockstorage, env.Context.CompartmentID)
+			})
+			if err != nil {
+				return nil, classifyOCIError("backup policy discovery", err)
+			}
+			// Oracle-defined policies carry no tenancy tags and are always kept.
+			var matched []BackupPolicy
+			for _, p := range policies {
+				if p.IsOracleDefined || p.MatchesTagFilters(env.Context.TagFilters) {
+					matched = append(matched, p)
+				}
+			}
+			return func(r *Result) { r.BackupPolicies = matched }, nil
+		},
+	},
+	{
+		name: "volume-groups", label: "Volume Groups", services: []string{"blockstorage"},
+		discover: func(ctx context.Context, env *Env) (func(*Result), error) {
+			groups, err := cached(ctx, env.cache, "volume_groups", func() ([]VolumeGroup, error) {
+				return discoverVolumeGroups(ctx, env.Clients.Blockstorage, env.Context.CompartmentID)
+			})
+			if err != nil {
+				return nil, classifyOCIError("volume group discovery", err)
+			}
+			return func(r *Result) { r.VolumeGroups = filterByTags(groups, env.Context.TagFilters) }, nil
+		},
+	},
+	{
+		name: "groups", label: "IAM Groups", services: []string{"identity"},
+		discover: func(ctx context.Context, env *Env) (func(*Result), error) {
+			groups, err := cached(ctx, env.cache, "groups", func() ([]Group, error) {
+				return discoverGroups(ctx, env.Clients.Identity, env.Context.TenancyID)
+			})
+			if err != nil {
+				return nil, classifyOCIError("IAM group discovery", err)
+			}
+			return func(r *Result) { r.Groups = filterByTags(groups, env.Context.TagFilters) }, nil
+		},
+	},
+	{
+		name: "dynamic-groups", label: "Dynamic Groups", services: []string{"identity"},
+		discover: func(ctx context.Context, env *Env) (func(*Result), error) {
+			groups, err := cached(ctx, env.cache, "dynamic_groups", func() ([]DynamicGroup, error) {
+				return discoverDynamicGroups(ctx, env.Clients.Identity, env.Context.TenancyID)
+			})
+			if err != nil {
+				return nil, classifyOCIError("dynamic group discovery", err)
+			}
+			return func(r *Result) { r.DynamicGroups = filterByTags(groups, env.Context.TagFilters) }, nil
+		},
+	},
+	{
+		name: "policies", label: "IAM Policies", services: []string{"identity"},
+		discover: func(ctx context.Context, env *Env) (func(*Result), error) {
+			// The compartments discoverer reports its own (fatal) error; on
+			// failure only root-level policies are listed.
+			comps, _ := env.Compartments()
+			policies, err := cached(ctx, env.cache, "policies", func() ([]Policy, error) {
+				return discoverPolicies(ctx, env.Clients.Identity, env.Context.TenancyID, comps)
+			})
+			if err != nil {
+				return nil, classifyOCIError("IAM policy discovery", err)
+			}
+			return func(r *Result) { r.Policies = filterByTags(policies, env.Context.TagFilters) }, nil
+		},
+	},
+	{
+		name: "tag-namespaces", label: "Tag Namespaces", services: []string{"identity"},
+		discover: func(ctx context.Context, env *Env) (func(*Result), error) {
+			namespaces, err := cachedWarn(ctx, env.cache, "tag_namespaces", env.Warn, func(warn func(error)) ([]TagNamespace, error) {
+				return discoverTagNamespaces(ctx, env.Clients.Identity, env.Context.TenancyID, warn)
+			})
+			if err != nil {
+				return nil, classifyOCIError("tag namespace discovery", err)
+			}
+			defaults, err := cached(ctx, env.cache, "tag_defaults", func() ([]TagDefault, error) {
+				return discoverTagDefaults(ctx, env.Clients.Identity, env.Context.TenancyID, env.Context.CompartmentID, namespaces)
+			})
+			if err != nil {
+				env.Warn(classifyOCIError("tag default discovery", err))
+			}
+			return func(r *Result) {
+				r.TagNamespaces = namespaces
+				r.TagDefaults = defaults
+			}, nil
+		},
+	},
+	{
+		name: "registry", label: "Container Registry", services: []string{"identity", "objectstorage", "artifacts"},
+		discover: func(ctx context.Context, env *Env) (func(*Result), error) {
+			registry, err := cached(ctx, env.cache, "registry", func() (*Registry, error) {
+				return discoverRegistry(ctx, env.Clients.Identity, env.Clients.ObjectStorage, env.Clients.Artifacts, env.Context.CompartmentID, env.Context.Region, env.Context.TagFilters)
+			})
+			if err != nil {
+				return nil, classifyOCIError("container registry discovery", err)
+			}
+			return func(r *Result) { r.Registry = registry }, nil
+		},
+	},
+	{
+		name: "functions", label: "Functions Applications", services: []string{"functions"},
+		discover: func(ctx context.Context, env *Env) (func(*Result), error) {
+			apps, err := cached(ctx, env.cache, "functions_applications", func() ([]FunctionsApplication, error) {
+				return discoverFunctionsApplications(ctx, env.Clients.Functions, env.Context.CompartmentID)
+			})
+			if err != nil {
+				return nil, classifyOCIError("functions application discovery", err)
+			}
+			return func(r *Result) { r.FunctionsApplications = filterByTags(apps, env.Context.TagFilters) }, nil
+		},
+	},
+	{
+		name: "dns", label: "DNS Zones and Resolvers", services: []string{"dns"},
+		discover: func(ctx context.Context, env *Env) (func(*Result), error) {
+			zones, err := cached(ctx, env.cache, "dns_zones", func() ([]DNSZone, error) {
+				return discoverDNSZones(ctx, env.Clients.DNS, env.Context.CompartmentID)
+			})
+			if err != nil {
+				env.Warn(classifyOCIError("DNS zone discovery", err))
+			}
+			views, err := cached(ctx, env.cache, "dns_views", func() ([]DNSView, error) {
+				return discoverDNSViews(ctx, env.Clients.DNS, env.Context.CompartmentID)
+			})
+			if err != nil {
+				env.Warn(classifyOCIError("DNS view discovery", err))
+			}
+			resolvers, err := cached(ctx, env.cache, "dns_resolvers", func() ([]DNSResolver, error) {
+				return discoverDNSResolvers(ctx, env.Clients.DNS, env.Context.CompartmentID)
+			})
+			if err != nil {
+				env.Warn(classifyOCIError("DNS resolver discovery", err))
+			}
+			return func(r *Result) {
+				r.DNSZones = filterByTags(zones, env.Context.TagFilters)
+				r.DNSViews = filterByTags(views, env.Context.TagFilters)
+				r.DNSResolvers = resolvers
+			}, nil
+		},
+	},
+	{
+		name: "observability", label: "Logging and Monitoring", services: []string{"logging", "notifications", "monitoring"},
+		discover: func(ctx context.Context, env *Env) (func(*Result), error) {
+			logGroups, err := cached(ctx, env.cache, "log_groups", func() ([]LogGroup, error) {
+				return discoverLogGroups(ctx, env.Clients.Logging, env.Context.CompartmentID)
+			})
+			if err != nil {
+				env.Warn(classifyOCIError("log group discovery", err))
+			}
+			topics, err := cached(ctx, env.cache, "notification_topics", func() ([]NotificationTopic, error) {
+				return discoverNotificationTopics(ctx, env.Clients.Notifications, env.Context.CompartmentID)
+			})
+			if err != nil {
+				env.Warn(classifyOCIError("notification topic discovery", err))
+			}
+			alarms, err := cached(ctx, env.cache, "alarms", func() ([]Alarm, error) {
+				return discoverAlarms(ctx, env.Clients.Monitoring, env.Context.CompartmentID)
+			})
+			if err != nil {
+				env.Warn(classifyOCIError("alarm discovery", err))
+			}
+			return func(r *Result) {
+				r.LogGroups = filterByTags(logGroups, env.Context.TagFilters)
+				r.NotificationTopics = filterByTags(topics, env.Context.TagFilters)
+				r.Alarms = filterByTags(alarms, env.Context.TagFilters)
+			}, nil
+		},
+	},
+	{
+		name: "budgets", label: "Budgets and Quotas", services: []string{"budget", "quotas"},
+		discover: func(ctx context.Context, env *Env) (func(*Result), error) {
+			budgets, err := cached(ctx, env.cache, "budgets", func() ([]Budget, error) {
+				return discoverBudgets(ctx, env.Clients.Budget, env.Context.TenancyID)
+			})
+			if err != nil {
+				env.Warn(classifyOCIError("budget discovery", err))
+			}
+			quotas, err := cached(ctx, env.cache, "quotas", func() ([]Quota, error) {
+				return discoverQuotas(ctx, env.Clients.Quotas, env.Context.TenancyID)
+			})
+			if err != nil {
+				env.Warn(classifyOCIError("quota discovery", err))
+			}
+			return func(r *Result) {
+				r.Budgets = filterByTags(budgets, env.Context.TagFilters)
+				r.Quotas = filterByTags(quotas, env.Context.TagFilters)
+			}, nil
+		},
+	},
+	{
+		name: "oke-images", label: "OKE Node Images", services: []string{"containerengine"}, enabled: okeEnabled,
+		discover: func(ctx context.Context, env *Env) (func(*Result), error) {
+			oke, err := cached(ctx, env.cache, "oke_images", func() (okeImageOutput, error) {
+				images, versions, err := discoverOKEImages(ctx, env.Clients.ContainerEngine, env.Context.CompartmentID)
+				return okeImageOutput{images, versions}, err
+			})
+			if err != nil {
+				return nil, classifyOCIError("OKE image discovery", err)
+			}
+			return func(r *Result) {
+				r.OKEImages = oke.Images
+				r.OKESupportedVersions = oke.Versions
+			}, nil
+		},
+	},
+	{
+		name: "oke-clusters", label: "OKE Clusters", services: []string{"containerengine"}, enabled: okeEnabled,
+		discover: func(ctx context.Context, env *Env) (func(*Result), error) {
+			clusters, err := cached(ctx, env.cache, "oke_clusters", func() ([]OKECluster, error) {
+				return discoverOKEClusters(ctx, env.Clients.ContainerEngine, env.Context.CompartmentID, env.Context.TagFilters)
+			})
+			if err != nil {
+				return nil, classifyOCIError("OKE cluster discovery", err)
+			}
+			return func(r *Result) { r.OKEClusters = clusters }, nil
+		},
+	},
+}
+
+// okeImageOutput is the cached form of discoverOKEImages' two results.
+type okeImageOutput struct {
+	Images   []OKEImage `json:"images"`
+	Versions []string   `json:"versions"`
+}
@@ -0,0 +1,44 @@
+package discovery
+
+// DNSZone is a public (GLOBAL) or private DNS zone. Private zones belong to a view.
+type DNSZone struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	CompartmentID string `json:"compartment_id"`
+	ZoneType      string `json:"zone_type"` // PRIMARY or SECONDARY
+	Scope         string `json:"scope"`     // GLOBAL or PRIVATE
+	ViewID        string `json:"view_id,omitempty"`
+	IsProtected   bool   `json:"is_protected"` // Oracle-managed (e.g. a VCN's default zones)
+	Tags
+}
+
+// DNSView groups private zones. A resolver answers from the views attached to it.
+type DNSView struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	CompartmentID string `json:"compartment_id"`
+	IsProtected   bool   `json:"is_protected"` // Oracle-managed default view of a VCN
+	Tags
+}
+
+// DNSResolver is the private resolver attached to a VCN.
+type DNSResolver struct {
+	ID            string             `json:"id"`
+	Name          string             `json:"name"`
+	CompartmentID string             `json:"compartment_id"`
+	VCNID         string             `json:"vcn_id"`
+	DefaultViewID string             `json:"default_view_id"`
+	Endpoints     []ResolverEndpoint `json:"endpoints"`
+	Tags
+}
+
+// ResolverEndpoint is a listening (inbound) or forwarding (outbound) address
+// of a resolver in one of the VCN's subnets.
+type ResolverEndpoint struct {
+	Name              string `json:"name"`
+	SubnetID          string `json:"subnet_id,omitempty"`
+	IsListening       bool   `json:"is_listening"`
+	IsForwarding      bool   `json:"is_forwarding"`
+	ListeningAddress  string `json:"listening_address,omitempty"`
+	ForwardingAddress string `json:"forwarding_address,omitempty"`
+}
@@ -58,7 +58,7 @@ func classifyServiceError(resource string, svcErr common.ServiceError, original
 	case code == 404:
 		guidance = fmt.Sprintf("The %s was not found. Verify your compartment OCID and region are correct. Current region can be checked with `oci iam region-subscription list`.", resource)
 	case code == 429:
-		guidance = fmt.Sprintf("OCI API rate limit exceeded while discovering %s. Wait a few minutes and try again, or use --compartment to reduce the scope of discovery.", resource)
+		guidance = fmt.Sprintf("OCI API rate limit exceeded while discovering %s, even after retrying. Wait a few minutes and try again, raise --max-retries, or use --compartment to reduce the scope of discovery.", resource)
 	case code >= 500:
 		guidance = fmt.Sprintf("OCI service error while discovering %s. This is an OCI-side issue. Check https://ocistatus.oraclecloud.com/ and retry later.", resource)
 	default:
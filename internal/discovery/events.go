@@ -0,0 +1,90 @@
+package discovery
+
+import (
+	"context"
+	"maps"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/larsenclose/oci-tf-bootstrap/internal/progress"
+)
+
+// events delivers progress events to a handler one at a time, so handlers
+// need no locking of their own and output from concurrent discoverers never
+// interleaves. A nil *events drops them.
+type events struct {
+	mu      sync.Mutex
+	handler progress.Handler
+}
+
+func newEvents(handler progress.Handler) *events {
+	return &events{handler: handler}
+}
+
+// emit stamps e with the current time and delivers it.
+func (ev *events) emit(e progress.Event) {
+	if ev == nil {
+		return
+	}
+	e.Time = time.Now()
+	ev.mu.Lock()
+	defer ev.mu.Unlock()
+	ev.handler(e)
+}
+
+// emitFrom delivers e attributed to the discoverer running under ctx, if any.
+func (ev *events) emitFrom(ctx context.Context, e progress.Event) {
+	if d, ok := ctx.Value(discovererKey{}).(Discoverer); ok {
+		e.Discoverer, e.Label = d.Name(), d.Label()
+	}
+	ev.emit(e)
+}
+
+type discovererKey struct{}
+
+// withDiscoverer marks ctx as running d, so retries and cache problems in its
+// calls are attributed to it.
+func withDiscoverer(ctx context.Context, d Discoverer) context.Context {
+	return context.WithValue(ctx, discovererKey{}, d)
+}
+
+// countStored calls store on result and returns how many resources it added:
+// the items of each slice, map and extension it set, and one for each object.
+func countStored(result *Result, store func(*Result)) int {
+	before := *result
+	extensions := maps.Clone(result.Extensions)
+	store(result)
+
+	var n int
+	old, now := reflect.ValueOf(before), reflect.ValueOf(*result)
+	for i := range now.NumField() {
+		if now.Type().Field(i).Name == "Extensions" {
+			continue
+		}
+		if !reflect.DeepEqual(old.Field(i).Interface(), now.Field(i).Interface()) {
+			n += items(now.Field(i))
+		}
+	}
+	for name, v := range result.Extensions {
+		if prev, ok := extensions[name]; !ok || !reflect.DeepEqual(prev, v) {
+			n += items(reflect.ValueOf(v))
+		}
+	}
+	return n
+}
+
+// items counts the resources in v: the length of a slice or map, otherwise
+// one unless v is nil or zero.
+func items(v reflect.Value) int {
+	switch {
+	case !v.IsValid():
+		return 0
+	case v.Kind() == reflect.Slice || v.Kind() == reflect.Array || v.Kind() == reflect.Map:
+		return v.Len()
+	case v.IsZero():
+		return 0
+	default:
+		return 1
+	}
+}
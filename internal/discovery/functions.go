@@ -0,0 +1,56 @@
+package discovery
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+)
+
+// Registry is the tenancy's Container Registry (OCIR) in the discovery region.
+type Registry struct {
+	Namespace    string                `json:"namespace"`  // Tenancy Object Storage namespace, also the OCIR namespace
+	RegionKey    string                `json:"region_key"` // Short region key, e.g. "IAD"
+	Endpoint     string                `json:"endpoint"`   // Registry host, e.g. "iad.ocir.io"
+	Repositories []ContainerRepository `json:"repositories"`
+}
+
+// URL returns the fully qualified registry prefix images are pushed under,
+// e.g. "iad.ocir.io/axaxnpcrorw5".
+func (r Registry) URL() string {
+	return r.Endpoint + "/" + r.Namespace
+}
+
+type ContainerRepository struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	CompartmentID string `json:"compartment_id"`
+	IsPublic      bool   `json:"is_public"`
+	ImageCount    int    `json:"image_count"`
+	Tags
+}
+
+// FunctionsApplication is an OCI Functions application and the subnets its
+// functions run in.
+type FunctionsApplication struct {
+	ID            string   `json:"id"`
+	Name          string   `json:"name"`
+	CompartmentID string   `json:"compartment_id"`
+	SubnetIDs     []string `json:"subnet_ids"`
+	Shape         string   `json:"shape"` // GENERIC_X86, GENERIC_ARM or GENERIC_X86_ARM
+	Tags
+}
+
+// ocirEndpoint returns the registry host for a region key. The commercial realm
+// uses <key>.ocir.io; other realms use ocir.<realm domain>.
+func ocirEndpoint(regionKey, region string) (string, error) {
+	if regionKey == "" {
+		return "", fmt.Errorf("no region key for region %q", region)
+	}
+	domain := common.StringToRegion(region).SecondLevelDomain()
+	host := "ocir.io"
+	if domain != "" && domain != "oraclecloud.com" {
+		host = "ocir." + domain
+	}
+	return strings.ToLower(regionKey) + "." + host, nil
+}
@@ -0,0 +1,28 @@
+package discovery
+
+type Group struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Tags
+}
+
+type DynamicGroup struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	MatchingRule string `json:"matching_rule"`
+	Tags
+}
+
+// Policy is an IAM policy attributed to the compartment it is attached to.
+// CompartmentName is "root" for policies attached to the tenancy.
+type Policy struct {
+	ID              string   `json:"id"`
+	Name            string   `json:"name"`
+	Description     string   `json:"description"`
+	CompartmentID   string   `json:"compartment_id"`
+	CompartmentName string   `json:"compartment_name"`
+	Statements      []string `json:"statements"`
+	Tags
+}
@@ -3,10 +3,18 @@ package discovery
 import (
 	"context"
 
+	"github.com/oracle/oci-go-sdk/v65/artifacts"
+	"github.com/oracle/oci-go-sdk/v65/budget"
 	"github.com/oracle/oci-go-sdk/v65/containerengine"
 	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/oracle/oci-go-sdk/v65/dns"
+	"github.com/oracle/oci-go-sdk/v65/functions"
 	"github.com/oracle/oci-go-sdk/v65/identity"
 	lim "github.com/oracle/oci-go-sdk/v65/limits"
+	"github.com/oracle/oci-go-sdk/v65/logging"
+	"github.com/oracle/oci-go-sdk/v65/monitoring"
+	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+	"github.com/oracle/oci-go-sdk/v65/ons"
 )
 
 // IdentityAPI abstracts the identity client methods used by discovery.
@@ -15,12 +23,21 @@ type IdentityAPI interface {
 	ListAvailabilityDomains(ctx context.Context, request identity.ListAvailabilityDomainsRequest) (identity.ListAvailabilityDomainsResponse, error)
 	ListFaultDomains(ctx context.Context, request identity.ListFaultDomainsRequest) (identity.ListFaultDomainsResponse, error)
 	GetTenancy(ctx context.Context, request identity.GetTenancyRequest) (identity.GetTenancyResponse, error)
+	ListGroups(ctx context.Context, request identity.ListGroupsRequest) (identity.ListGroupsResponse, error)
+	ListDynamicGroups(ctx context.Context, request identity.ListDynamicGroupsRequest) (identity.ListDynamicGroupsResponse, error)
+	ListPolicies(ctx context.Context, request identity.ListPoliciesRequest) (identity.ListPoliciesResponse, error)
+	ListTagNamespaces(ctx context.Context, request identity.ListTagNamespacesRequest) (identity.ListTagNamespacesResponse, error)
+	ListTags(ctx context.Context, request identity.ListTagsRequest) (identity.ListTagsResponse, error)
+	GetTag(ctx context.Context, request identity.GetTagRequest) (identity.GetTagResponse, error)
+	ListTagDefaults(ctx context.Context, request identity.ListTagDefaultsRequest) (identity.ListTagDefaultsResponse, error)
+	ListRegions(ctx context.Context) (identity.ListRegionsResponse, error)
 }
 
 // ComputeAPI abstracts the compute client methods used by discovery.
 type ComputeAPI interface {
 	ListShapes(ctx context.Context, request core.ListShapesRequest) (core.ListShapesResponse, error)
 	ListImages(ctx context.Context, request core.ListImagesRequest) (core.ListImagesResponse, error)
+	CreateComputeCapacityReport(ctx context.Context, request core.CreateComputeCapacityReportRequest) (core.CreateComputeCapacityReportResponse, error)
 }
 
 // VirtualNetworkAPI abstracts the virtual network client methods used by discovery.
@@ -36,6 +53,9 @@ type VirtualNetworkAPI interface {
 // BlockstorageAPI abstracts the blockstorage client methods used by discovery.
 type BlockstorageAPI interface {
 	ListVolumes(ctx context.Context, request core.ListVolumesRequest) (core.ListVolumesResponse, error)
+	ListBootVolumes(ctx context.Context, request core.ListBootVolumesRequest) (core.ListBootVolumesResponse, error)
+	ListVolumeBackupPolicies(ctx context.Context, request core.ListVolumeBackupPoliciesRequest) (core.ListVolumeBackupPoliciesResponse, error)
+	ListVolumeGroups(ctx context.Context, request core.ListVolumeGroupsRequest) (core.ListVolumeGroupsResponse, error)
 }
 
 // LimitsAPI abstracts the limits client methods used by discovery.
@@ -46,6 +66,58 @@ type LimitsAPI interface {
 // ContainerEngineAPI abstracts the container engine client methods used by discovery.
 type ContainerEngineAPI interface {
 	GetNodePoolOptions(ctx context.Context, request containerengine.GetNodePoolOptionsRequest) (containerengine.GetNodePoolOptionsResponse, error)
+	ListClusters(ctx context.Context, request containerengine.ListClustersRequest) (containerengine.ListClustersResponse, error)
+	ListNodePools(ctx context.Context, request containerengine.ListNodePoolsRequest) (containerengine.ListNodePoolsResponse, error)
+}
+
+// ArtifactsAPI abstracts the artifacts (Container Registry) client methods used by discovery.
+type ArtifactsAPI interface {
+	ListContainerRepositories(ctx context.Context, request artifacts.ListContainerRepositoriesRequest) (artifacts.ListContainerRepositoriesResponse, error)
+}
+
+// ObjectStorageAPI abstracts the object storage client methods used by discovery.
+type ObjectStorageAPI interface {
+	GetNamespace(ctx context.Context, request objectstorage.GetNamespaceRequest) (objectstorage.GetNamespaceResponse, error)
+}
+
+// FunctionsAPI abstracts the functions management client methods used by discovery.
+type FunctionsAPI interface {
+	ListApplications(ctx context.Context, request functions.ListApplicationsRequest) (functions.ListApplicationsResponse, error)
+}
+
+// DNSAPI abstracts the DNS client methods used by discovery.
+type DNSAPI interface {
+	ListZones(ctx context.Context, request dns.ListZonesRequest) (dns.ListZonesResponse, error)
+	ListViews(ctx context.Context, request dns.ListViewsRequest) (dns.ListViewsResponse, error)
+	ListResolvers(ctx context.Context, request dns.ListResolversRequest) (dns.ListResolversResponse, error)
+	ListResolverEndpoints(ctx context.Context, request dns.ListResolverEndpointsRequest) (dns.ListResolverEndpointsResponse, error)
+}
+
+// LoggingAPI abstracts the logging management client methods used by discovery.
+type LoggingAPI interface {
+	ListLogGroups(ctx context.Context, request logging.ListLogGroupsRequest) (logging.ListLogGroupsResponse, error)
+}
+
+// NotificationAPI abstracts the notification control plane client methods used by discovery.
+type NotificationAPI interface {
+	ListTopics(ctx context.Context, request ons.ListTopicsRequest) (ons.ListTopicsResponse, error)
+}
+
+// MonitoringAPI abstracts the monitoring client methods used by discovery.
+type MonitoringAPI interface {
+	ListAlarms(ctx context.Context, request monitoring.ListAlarmsRequest) (monitoring.ListAlarmsResponse, error)
+}
+
+// BudgetAPI abstracts the budget client methods used by discovery.
+type BudgetAPI interface {
+	ListBudgets(ctx context.Context, request budget.ListBudgetsRequest) (budget.ListBudgetsResponse, error)
+	ListAlertRules(ctx context.Context, request budget.ListAlertRulesRequest) (budget.ListAlertRulesResponse, error)
+}
+
+// QuotasAPI abstracts the quotas client methods used by discovery.
+type QuotasAPI interface {
+	ListQuotas(ctx context.Context, request lim.ListQuotasRequest) (lim.ListQuotasResponse, error)
+	GetQuota(ctx context.Context, request lim.GetQuotaRequest) (lim.GetQuotaResponse, error)
 }
 
 // Compile-time interface satisfaction checks.
@@ -56,4 +128,13 @@ var (
 	_ BlockstorageAPI    = core.BlockstorageClient{}
 	_ LimitsAPI          = lim.LimitsClient{}
 	_ ContainerEngineAPI = containerengine.ContainerEngineClient{}
+	_ ArtifactsAPI       = artifacts.ArtifactsClient{}
+	_ ObjectStorageAPI   = objectstorage.ObjectStorageClient{}
+	_ FunctionsAPI       = functions.FunctionsManagementClient{}
+	_ DNSAPI             = dns.DnsClient{}
+	_ LoggingAPI         = logging.LoggingManagementClient{}
+	_ NotificationAPI    = ons.NotificationControlPlaneClient{}
+	_ MonitoringAPI      = monitoring.MonitoringClient{}
+	_ BudgetAPI          = budget.BudgetClient{}
+	_ QuotasAPI          = lim.QuotasClient{}
 )
@@ -11,6 +11,7 @@ type VCN struct {
 	RouteTables     []RouteTable     `json:"route_tables"`
 	InternetGateway *InternetGateway `json:"internet_gateway,omitempty"`
 	NATGateway      *NATGateway      `json:"nat_gateway,omitempty"`
+	Tags
 }
 
 type Subnet struct {
@@ -20,6 +21,7 @@ type Subnet struct {
 	AvailabilityDomain string `json:"availability_domain"`
 	IsPublic           bool   `json:"is_public"`
 	DNSLabel           string `json:"dns_label"`
+	Tags
 }
 
 type SecurityList struct {
@@ -27,6 +29,7 @@ type SecurityList struct {
 	DisplayName  string         `json:"display_name"`
 	IngressRules []SecurityRule `json:"ingress_rules"`
 	EgressRules  []SecurityRule `json:"egress_rules"`
+	Tags
 }
 
 type SecurityRule struct {
@@ -42,6 +45,7 @@ type RouteTable struct {
 	ID          string      `json:"id"`
 	DisplayName string      `json:"display_name"`
 	Routes      []RouteRule `json:"routes"`
+	Tags
 }
 
 type RouteRule struct {
@@ -55,6 +59,7 @@ type InternetGateway struct {
 	ID          string `json:"id"`
 	DisplayName string `json:"display_name"`
 	IsEnabled   bool   `json:"is_enabled"`
+	Tags
 }
 
 type NATGateway struct {
@@ -62,6 +67,7 @@ type NATGateway struct {
 	DisplayName  string `json:"display_name"`
 	PublicIP     string `json:"public_ip"`
 	BlockTraffic bool   `json:"block_traffic"`
+	Tags
 }
 
 type BlockVolume struct {
@@ -71,6 +77,46 @@ type BlockVolume struct {
 	AvailabilityDomain string `json:"availability_domain"`
 	VPUsPerGB          int64  `json:"vpus_per_gb"`
 	IsHydrated         bool   `json:"is_hydrated"`
+	Tags
+}
+
+type BootVolume struct {
+	ID                 string `json:"id"`
+	DisplayName        string `json:"display_name"`
+	SizeGB             int64  `json:"size_gb"`
+	AvailabilityDomain string `json:"availability_domain"`
+	VPUsPerGB          int64  `json:"vpus_per_gb"`
+	ImageID            string `json:"image_id,omitempty"`
+	VolumeGroupID      string `json:"volume_group_id,omitempty"`
+	IsHydrated         bool   `json:"is_hydrated"`
+	Tags
+}
+
+// BackupPolicy is a volume backup policy. Oracle-defined policies (gold, silver,
+// bronze) have no compartment; custom policies belong to a compartment.
+type BackupPolicy struct {
+	ID                string           `json:"id"`
+	DisplayName       string           `json:"display_name"`
+	CompartmentID     string           `json:"compartment_id,omitempty"`
+	IsOracleDefined   bool             `json:"is_oracle_defined"`
+	DestinationRegion string           `json:"destination_region,omitempty"`
+	Schedules         []BackupSchedule `json:"schedules"`
+	Tags
+}
+
+type BackupSchedule struct {
+	BackupType       string `json:"backup_type"`
+	Period           string `json:"period"`
+	RetentionSeconds int    `json:"retention_seconds"`
+}
+
+type VolumeGroup struct {
+	ID                 string   `json:"id"`
+	DisplayName        string   `json:"display_name"`
+	AvailabilityDomain string   `json:"availability_domain"`
+	SizeGB             int64    `json:"size_gb"`
+	VolumeIDs          []string `json:"volume_ids"`
+	Tags
 }
 
 type ServiceLimit struct {
@@ -0,0 +1,33 @@
+package discovery
+
+// LogGroup is a Logging service log group.
+type LogGroup struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	CompartmentID string `json:"compartment_id"`
+	Description   string `json:"description"`
+	Tags
+}
+
+// NotificationTopic is an ONS topic alarms and events can publish to.
+type NotificationTopic struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	CompartmentID string `json:"compartment_id"`
+	Description   string `json:"description"`
+	APIEndpoint   string `json:"api_endpoint"`
+	Tags
+}
+
+// Alarm is a Monitoring alarm and the topics it notifies.
+type Alarm struct {
+	ID            string   `json:"id"`
+	Name          string   `json:"name"`
+	CompartmentID string   `json:"compartment_id"`
+	Namespace     string   `json:"namespace"` // Metric namespace, e.g. "oci_computeagent"
+	Query         string   `json:"query"`
+	Severity      string   `json:"severity"` // CRITICAL, ERROR, WARNING or INFO
+	Destinations  []string `json:"destinations"`
+	IsEnabled     bool     `json:"is_enabled"`
+	Tags
+}
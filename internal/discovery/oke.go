@@ -0,0 +1,156 @@
+package discovery
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// OKECluster is an existing Container Engine for Kubernetes cluster.
+type OKECluster struct {
+	ID                 string        `json:"id"`
+	Name               string        `json:"name"`
+	CompartmentID      string        `json:"compartment_id"`
+	VCNID              string        `json:"vcn_id"`
+	KubernetesVersion  string        `json:"kubernetes_version"`
+	Type               string        `json:"type"`     // BASIC_CLUSTER or ENHANCED_CLUSTER
+	CNIType            string        `json:"cni_type"` // FLANNEL_OVERLAY or OCI_VCN_IP_NATIVE
+	EndpointSubnetID   string        `json:"endpoint_subnet_id,omitempty"`
+	IsPublicEndpoint   bool          `json:"is_public_endpoint"`
+	PublicEndpoint     string        `json:"public_endpoint,omitempty"`
+	PrivateEndpoint    string        `json:"private_endpoint,omitempty"`
+	ServiceLBSubnetIDs []string      `json:"service_lb_subnet_ids,omitempty"`
+	AvailableUpgrades  []string      `json:"available_upgrades,omitempty"`
+	NodePools          []OKENodePool `json:"node_pools"`
+	Tags
+}
+
+type OKENodePool struct {
+	ID                string   `json:"id"`
+	Name              string   `json:"name"`
+	KubernetesVersion string   `json:"kubernetes_version"`
+	NodeShape         string   `json:"node_shape"`
+	OCPUs             float32  `json:"ocpus,omitempty"`
+	MemoryGB          float32  `json:"memory_gb,omitempty"`
+	ImageID           string   `json:"image_id,omitempty"`
+	Size              int      `json:"size"`
+	SubnetIDs         []string `json:"subnet_ids"`
+	Tags
+}
+
+// OKEVersionStatus compares a cluster or node pool's Kubernetes version with
+// the version it should run: the newest supported version for a cluster, the
+// cluster's version for a node pool.
+type OKEVersionStatus struct {
+	ClusterID     string   `json:"cluster_id"`
+	Cluster       string   `json:"cluster"`
+	NodePool      string   `json:"node_pool,omitempty"` // Empty for the cluster itself
+	Version       string   `json:"version"`
+	TargetVersion string   `json:"target_version"`
+	NextUpgrade   string   `json:"next_upgrade,omitempty"` // Newest upgrade the cluster accepts directly
+	IsBehind      bool     `json:"is_behind"`
+	UpgradeImages []string `json:"upgrade_images,omitempty"` // OKE image OCIDs for TargetVersion matching the pool's architecture
+}
+
+// CompareKubernetesVersions compares versions such as "v1.32.10" and "1.31.10".
+// Returns negative if a < b, zero if equal, positive if a > b.
+func CompareKubernetesVersions(a, b string) int {
+	partsA := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	partsB := strings.Split(strings.TrimPrefix(b, "v"), ".")
+
+	for i := 0; i < len(partsA) && i < len(partsB); i++ {
+		na, errA := strconv.Atoi(partsA[i])
+		nb, errB := strconv.Atoi(partsB[i])
+		if errA != nil || errB != nil {
+			if c := strings.Compare(partsA[i], partsB[i]); c != 0 {
+				return c
+			}
+			continue
+		}
+		if na != nb {
+			return na - nb
+		}
+	}
+	return len(partsA) - len(partsB)
+}
+
+// newestVersion returns the highest version in versions, or "" when empty.
+func newestVersion(versions []string) string {
+	var newest string
+	for _, v := range versions {
+		if newest == "" || CompareKubernetesVersions(v, newest) > 0 {
+			newest = v
+		}
+	}
+	return newest
+}
+
+// OKEVersionReport lists every discovered cluster and node pool with the
+// version it should be upgraded to. Clusters target the newest supported
+// version; node pools target their cluster's version, with the node images
+// available for it.
+func OKEVersionReport(result *Result) []OKEVersionStatus {
+	latest := newestVersion(append(slices.Clone(result.OKESupportedVersions), okeImageVersions(result.OKEImages)...))
+
+	archByImage := make(map[string]string, len(result.OKEImages))
+	for _, img := range result.OKEImages {
+		archByImage[img.ID] = img.Architecture
+	}
+
+	var report []OKEVersionStatus
+	for _, c := range result.OKEClusters {
+		target := newestVersion([]string{c.KubernetesVersion, latest, newestVersion(c.AvailableUpgrades)})
+		report = append(report, OKEVersionStatus{
+			ClusterID:     c.ID,
+			Cluster:       c.Name,
+			Version:       c.KubernetesVersion,
+			TargetVersion: target,
+			NextUpgrade:   newestVersion(c.AvailableUpgrades),
+			IsBehind:      CompareKubernetesVersions(c.KubernetesVersion, target) < 0,
+		})
+
+		for _, np := range c.NodePools {
+			status := OKEVersionStatus{
+				ClusterID:     c.ID,
+				Cluster:       c.Name,
+				NodePool:      np.Name,
+				Version:       np.KubernetesVersion,
+				TargetVersion: c.KubernetesVersion,
+				IsBehind:      CompareKubernetesVersions(np.KubernetesVersion, c.KubernetesVersion) < 0,
+			}
+			if status.IsBehind {
+				arch, ok := archByImage[np.ImageID]
+				if !ok {
+					arch = shapeArchitecture(np.NodeShape)
+				}
+				for _, img := range result.OKEImages {
+					if img.Architecture == arch && img.KubernetesVersion != "" &&
+						CompareKubernetesVersions(img.KubernetesVersion, c.KubernetesVersion) == 0 {
+						status.UpgradeImages = append(status.UpgradeImages, img.ID)
+					}
+				}
+			}
+			report = append(report, status)
+		}
+	}
+	return report
+}
+
+func okeImageVersions(images []OKEImage) []string {
+	var versions []string
+	for _, img := range images {
+		if img.IsSupported {
+			versions = append(versions, img.KubernetesVersion)
+		}
+	}
+	return versions
+}
+
+// shapeArchitecture infers the image architecture for a compute shape:
+// Ampere shapes (VM.Standard.A1.Flex, BM.Standard.A1.160, ...) are aarch64.
+func shapeArchitecture(shape string) string {
+	if strings.Contains(shape, ".A1.") || strings.Contains(shape, ".A2.") || strings.Contains(shape, ".A4.") {
+		return "aarch64"
+	}
+	return "x86_64"
+}
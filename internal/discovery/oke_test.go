@@ -0,0 +1,77 @@
+package discovery
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCompareKubernetesVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int // sign only
+	}{
+		{"v1.32.10", "v1.31.10", 1},
+		{"1.31.10", "v1.31.10", 0},
+		{"v1.31.9", "v1.31.10", -1},
+		{"v1.9.0", "v1.10.0", -1},
+		{"v1.31", "v1.31.0", -1},
+	}
+	for _, tt := range tests {
+		got := CompareKubernetesVersions(tt.a, tt.b)
+		if (got > 0) != (tt.want > 0) || (got < 0) != (tt.want < 0) {
+			t.Errorf("CompareKubernetesVersions(%q, %q) = %d, want sign %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestOKEVersionReport(t *testing.T) {
+	result := &Result{
+		OKESupportedVersions: []string{"v1.30.1", "v1.31.1", "v1.32.1"},
+		OKEImages: []OKEImage{
+			{ID: "img-131-arm", KubernetesVersion: "1.31.1", Architecture: "aarch64", IsSupported: true},
+			{ID: "img-131-x86", KubernetesVersion: "1.31.1", Architecture: "x86_64", IsSupported: true},
+			{ID: "img-130-arm", KubernetesVersion: "1.30.1", Architecture: "aarch64", IsSupported: true},
+		},
+		OKEClusters: []OKECluster{
+			{
+				ID:                "cluster-1",
+				Name:              "prod",
+				KubernetesVersion: "v1.31.1",
+				AvailableUpgrades: []string{"v1.32.1"},
+				NodePools: []OKENodePool{
+					{Name: "arm", KubernetesVersion: "v1.30.1", NodeShape: "VM.Standard.A1.Flex", ImageID: "img-130-arm"},
+					{Name: "x86", KubernetesVersion: "v1.31.1", NodeShape: "VM.Standard.E4.Flex"},
+					{Name: "custom", KubernetesVersion: "v1.30.1", NodeShape: "VM.Standard.A1.Flex", ImageID: "custom-image"},
+				},
+			},
+		},
+	}
+
+	report := OKEVersionReport(result)
+	if len(report) != 4 {
+		t.Fatalf("expected 4 rows (cluster + 3 node pools), got %d", len(report))
+	}
+
+	cluster := report[0]
+	if cluster.NodePool != "" || !cluster.IsBehind || cluster.TargetVersion != "v1.32.1" || cluster.NextUpgrade != "v1.32.1" {
+		t.Errorf("cluster row = %+v, want behind with target v1.32.1", cluster)
+	}
+
+	arm := report[1]
+	if !arm.IsBehind || arm.TargetVersion != "v1.31.1" {
+		t.Errorf("arm pool = %+v, want behind with target v1.31.1", arm)
+	}
+	if !slices.Equal(arm.UpgradeImages, []string{"img-131-arm"}) {
+		t.Errorf("arm pool upgrade images = %v, want [img-131-arm]", arm.UpgradeImages)
+	}
+
+	x86 := report[2]
+	if x86.IsBehind || len(x86.UpgradeImages) != 0 {
+		t.Errorf("x86 pool = %+v, want current with no upgrade images", x86)
+	}
+
+	// Architecture falls back to the node shape for images not in OKEImages
+	if custom := report[3]; !slices.Equal(custom.UpgradeImages, []string{"img-131-arm"}) {
+		t.Errorf("custom pool upgrade images = %v, want [img-131-arm]", custom.UpgradeImages)
+	}
+}
@@ -0,0 +1,91 @@
+package discovery
+
+import (
+	"fmt"
+	"slices"
+)
+
+// PolicyStatement is an IAM permission needed by discovery, mapped to the
+// client interface methods that require it.
+type PolicyStatement struct {
+	Scope       string   // Discovery scope that makes the calls, e.g. "virtual-network"
+	Verb        string   // IAM verb: inspect, read, use or manage
+	Resource    string   // IAM resource-type, e.g. "vcns"
+	Tenancy     bool     // Granted in the tenancy rather than the target compartment
+	Methods     []string // Interface methods, e.g. "VirtualNetworkAPI.ListVcns"
+	Discoverers []string // Discoverers making the calls; the statement is needed when any is enabled
+}
+
+// Statement renders the policy statement for the given group. Compartment-scoped
+// statements target compartmentID, or the tenancy when discovering from the root.
+func (s PolicyStatement) Statement(group, tenancyID, compartmentID string) string {
+	location := "tenancy"
+	if !s.Tenancy && compartmentID != "" && compartmentID != tenancyID {
+		location = "compartment id " + compartmentID
+	}
+	return fmt.Sprintf("Allow group %s to %s %s in %s", group, s.Verb, s.Resource, location)
+}
+
+// NoPolicyMethods are interface methods any authenticated tenancy user may call.
+var NoPolicyMethods = []string{
+	"IdentityAPI.ListAvailabilityDomains",
+	"IdentityAPI.ListFaultDomains",
+	"IdentityAPI.ListRegions",
+	"ObjectStorageAPI.GetNamespace",
+}
+
+// RequiredPolicy returns the least-privilege statements for the discoverers
+// enabled by ctx (see Context.Discoverers). Every method on the client
+// interfaces that discovery calls appears in exactly one statement or in
+// NoPolicyMethods.
+func RequiredPolicy(ctx *Context) []PolicyStatement {
+	enabled := make(map[string]bool)
+	for _, d := range enabledDiscoverers(ctx) {
+		enabled[d.Name()] = true
+	}
+
+	var stmts []PolicyStatement
+	for _, s := range policyStatements {
+		if slices.ContainsFunc(s.Discoverers, func(name string) bool { return enabled[name] }) {
+			stmts = append(stmts, s)
+		}
+	}
+	return stmts
+}
+
+// policyStatements are the statements for every built-in discoverer.
+var policyStatements = []PolicyStatement{
+	{Scope: "compartments", Verb: "inspect", Resource: "tenancies", Tenancy: true, Discoverers: []string{"tenancy"}, Methods: []string{"IdentityAPI.GetTenancy"}},
+	{Scope: "compartments", Verb: "inspect", Resource: "compartments", Tenancy: true, Discoverers: []string{"compartments", "policies"}, Methods: []string{"IdentityAPI.ListCompartments"}},
+	{Scope: "capacity", Verb: "manage", Resource: "compute-capacity-reports", Tenancy: true, Discoverers: []string{"a1-capacity"}, Methods: []string{"ComputeAPI.CreateComputeCapacityReport"}},
+	{Scope: "shapes", Verb: "inspect", Resource: "instances", Discoverers: []string{"shapes"}, Methods: []string{"ComputeAPI.ListShapes"}},
+	{Scope: "images", Verb: "inspect", Resource: "instance-images", Discoverers: []string{"images"}, Methods: []string{"ComputeAPI.ListImages"}},
+	{Scope: "virtual-network", Verb: "inspect", Resource: "vcns", Discoverers: []string{"vcns"}, Methods: []string{"VirtualNetworkAPI.ListVcns"}},
+	{Scope: "virtual-network", Verb: "inspect", Resource: "subnets", Discoverers: []string{"vcns"}, Methods: []string{"VirtualNetworkAPI.ListSubnets"}},
+	{Scope: "virtual-network", Verb: "read", Resource: "security-lists", Discoverers: []string{"vcns"}, Methods: []string{"VirtualNetworkAPI.ListSecurityLists"}},
+	{Scope: "virtual-network", Verb: "read", Resource: "route-tables", Discoverers: []string{"vcns"}, Methods: []string{"VirtualNetworkAPI.ListRouteTables"}},
+	{Scope: "virtual-network", Verb: "inspect", Resource: "internet-gateways", Discoverers: []string{"vcns"}, Methods: []string{"VirtualNetworkAPI.ListInternetGateways"}},
+	{Scope: "virtual-network", Verb: "inspect", Resource: "nat-gateways", Discoverers: []string{"vcns"}, Methods: []string{"VirtualNetworkAPI.ListNatGateways"}},
+	{Scope: "volumes", Verb: "inspect", Resource: "volumes", Discoverers: []string{"block-volumes", "boot-volumes"}, Methods: []string{"BlockstorageAPI.ListVolumes", "BlockstorageAPI.ListBootVolumes"}},
+	{Scope: "volumes", Verb: "inspect", Resource: "backup-policies", Discoverers: []string{"backup-policies"}, Methods: []string{"BlockstorageAPI.ListVolumeBackupPolicies"}},
+	{Scope: "volumes", Verb: "inspect", Resource: "volume-groups", Discoverers: []string{"volume-groups"}, Methods: []string{"BlockstorageAPI.ListVolumeGroups"}},
+	{Scope: "limits", Verb: "inspect", Resource: "resource-availability", Tenancy: true, Discoverers: []string{"limits"}, Methods: []string{"LimitsAPI.ListLimitValues"}},
+	{Scope: "iam", Verb: "inspect", Resource: "groups", Tenancy: true, Discoverers: []string{"groups"}, Methods: []string{"IdentityAPI.ListGroups"}},
+	{Scope: "iam", Verb: "inspect", Resource: "dynamic-groups", Tenancy: true, Discoverers: []string{"dynamic-groups"}, Methods: []string{"IdentityAPI.ListDynamicGroups"}},
+	{Scope: "iam", Verb: "inspect", Resource: "policies", Tenancy: true, Discoverers: []string{"policies"}, Methods: []string{"IdentityAPI.ListPolicies"}},
+	{Scope: "tags", Verb: "read", Resource: "tag-namespaces", Tenancy: true, Discoverers: []string{"tag-namespaces"}, Methods: []string{"IdentityAPI.ListTagNamespaces", "IdentityAPI.ListTags", "IdentityAPI.GetTag"}},
+	{Scope: "tags", Verb: "inspect", Resource: "tag-defaults", Tenancy: true, Discoverers: []string{"tag-namespaces"}, Methods: []string{"IdentityAPI.ListTagDefaults"}},
+	{Scope: "registry", Verb: "inspect", Resource: "repos", Discoverers: []string{"registry"}, Methods: []string{"ArtifactsAPI.ListContainerRepositories"}},
+	{Scope: "functions", Verb: "inspect", Resource: "fn-app", Discoverers: []string{"functions"}, Methods: []string{"FunctionsAPI.ListApplications"}},
+	{Scope: "dns", Verb: "inspect", Resource: "dns-zones", Discoverers: []string{"dns"}, Methods: []string{"DNSAPI.ListZones"}},
+	{Scope: "dns", Verb: "inspect", Resource: "dns-views", Discoverers: []string{"dns"}, Methods: []string{"DNSAPI.ListViews"}},
+	{Scope: "dns", Verb: "inspect", Resource: "dns-resolvers", Discoverers: []string{"dns"}, Methods: []string{"DNSAPI.ListResolvers"}},
+	{Scope: "dns", Verb: "inspect", Resource: "dns-resolver-endpoints", Discoverers: []string{"dns"}, Methods: []string{"DNSAPI.ListResolverEndpoints"}},
+	{Scope: "observability", Verb: "inspect", Resource: "log-groups", Discoverers: []string{"observability"}, Methods: []string{"LoggingAPI.ListLogGroups"}},
+	{Scope: "observability", Verb: "inspect", Resource: "ons-topics", Discoverers: []string{"observability"}, Methods: []string{"NotificationAPI.ListTopics"}},
+	{Scope: "observability", Verb: "inspect", Resource: "alarms", Discoverers: []string{"observability"}, Methods: []string{"MonitoringAPI.ListAlarms"}},
+	{Scope: "cost", Verb: "read", Resource: "usage-budgets", Tenancy: true, Discoverers: []string{"budgets"}, Methods: []string{"BudgetAPI.ListBudgets", "BudgetAPI.ListAlertRules"}},
+	{Scope: "cost", Verb: "read", Resource: "quota", Tenancy: true, Discoverers: []string{"budgets"}, Methods: []string{"QuotasAPI.ListQuotas", "QuotasAPI.GetQuota"}},
+	{Scope: "oke", Verb: "inspect", Resource: "clusters", Discoverers: []string{"oke-clusters"}, Methods: []string{"ContainerEngineAPI.ListClusters"}},
+	{Scope: "oke", Verb: "read", Resource: "cluster-node-pools", Discoverers: []string{"oke-images", "oke-clusters"}, Methods: []string{"ContainerEngineAPI.GetNodePoolOptions", "ContainerEngineAPI.ListNodePools"}},
+}
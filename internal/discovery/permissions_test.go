@@ -0,0 +1,128 @@
+package discovery
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRequiredPolicyCoversInterfaces(t *testing.T) {
+	covered := make(map[string]int)
+	for _, s := range RequiredPolicy(&Context{OKE: true, AlwaysFree: true}) {
+		for _, m := range s.Methods {
+			covered[m]++
+		}
+	}
+	for _, m := range NoPolicyMethods {
+		covered[m]++
+	}
+
+	// Every client interface discovery can call is a field of Clients
+	clients := reflect.TypeOf(Clients{})
+	all := make(map[string]bool)
+	for f := 0; f < clients.NumField(); f++ {
+		iface := clients.Field(f).Type
+		for i := 0; i < iface.NumMethod(); i++ {
+			name := iface.Name() + "." + iface.Method(i).Name
+			all[name] = true
+			switch covered[name] {
+			case 0:
+				t.Errorf("%s has no policy statement", name)
+			case 1:
+			default:
+				t.Errorf("%s is mapped to %d statements, want 1", name, covered[name])
+			}
+		}
+	}
+	for m := range covered {
+		if !all[m] {
+			t.Errorf("policy maps unknown method %s", m)
+		}
+	}
+}
+
+func TestRequiredPolicyOKEScope(t *testing.T) {
+	hasOKE := func(stmts []PolicyStatement) bool {
+		for _, s := range stmts {
+			if s.Scope == "oke" {
+				return true
+			}
+		}
+		return false
+	}
+	if hasOKE(RequiredPolicy(&Context{})) {
+		t.Error("OKE statements should be omitted when OKE discovery is disabled")
+	}
+	if !hasOKE(RequiredPolicy(&Context{OKE: true})) {
+		t.Error("OKE statements should be included with OKE discovery")
+	}
+	if !hasOKE(RequiredPolicy(&Context{AlwaysFree: true})) {
+		t.Error("OKE statements should be included in always-free mode")
+	}
+}
+
+func TestRequiredPolicySelectedDiscoverers(t *testing.T) {
+	resources := func(ctx *Context) []string {
+		var names []string
+		for _, s := range RequiredPolicy(ctx) {
+			names = append(names, s.Resource)
+		}
+		return names
+	}
+
+	include, err := SelectDiscoverers([]string{"availability-domains", "images"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := resources(&Context{Discoverers: include}); !slices.Equal(got, []string{"instance-images"}) {
+		t.Errorf("expected only the images statement, got %v", got)
+	}
+
+	exclude, err := SelectDiscoverers(nil, []string{"policies", "dns", "budgets", "vcns"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := resources(&Context{Discoverers: exclude})
+	for _, r := range []string{"policies", "dns-zones", "usage-budgets", "quota", "vcns", "subnets"} {
+		if slices.Contains(got, r) {
+			t.Errorf("expected no %s statement with its discoverer excluded, got %v", r, got)
+		}
+	}
+	for _, r := range []string{"compartments", "groups", "instances"} {
+		if !slices.Contains(got, r) {
+			t.Errorf("expected the %s statement, got %v", r, got)
+		}
+	}
+}
+
+func TestPolicyStatement(t *testing.T) {
+	compScoped := PolicyStatement{Verb: "inspect", Resource: "vcns"}
+	tenancyScoped := PolicyStatement{Verb: "inspect", Resource: "compartments", Tenancy: true}
+
+	tests := []struct {
+		name          string
+		stmt          PolicyStatement
+		compartmentID string
+		expected      string
+	}{
+		{"root discovery", compScoped, "tenancy-1", "Allow group ops to inspect vcns in tenancy"},
+		{"empty compartment", compScoped, "", "Allow group ops to inspect vcns in tenancy"},
+		{"compartment discovery", compScoped, "comp-1", "Allow group ops to inspect vcns in compartment id comp-1"},
+		{"tenancy-wide statement", tenancyScoped, "comp-1", "Allow group ops to inspect compartments in tenancy"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.stmt.Statement("ops", "tenancy-1", tt.compartmentID)
+			if got != tt.expected {
+				t.Errorf("got %q, want %q", got, tt.expected)
+			}
+		})
+	}
+
+	for _, s := range RequiredPolicy(&Context{OKE: true}) {
+		if strings.Contains(s.Resource, "all-resources") || s.Verb == "manage" || s.Verb == "use" {
+			t.Errorf("statement %q is broader than read-only", s.Statement("g", "t", ""))
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/larsenclose/oci-tf-bootstrap/internal/progress"
+)
+
+// Discoverer finds one kind of resource. RunWithClients runs every enabled
+// discoverer concurrently; Register adds discoverers beyond the built-in ones
+// when the package is used as a library.
+type Discoverer interface {
+	// Name identifies the discoverer, e.g. "vcns". Names are unique.
+	Name() string
+	// Label is shown in the progress output, e.g. "VCNs".
+	Label() string
+	// Services lists the clients Discover calls by service name ("identity",
+	// "compute", "network", ...). Run constructs only the clients needed.
+	Services() []string
+	// Fatal reports whether a failure aborts discovery. Other failures are
+	// printed as warnings and discovery continues without the resources.
+	Fatal() bool
+	// Enabled reports whether the discoverer applies to dctx, e.g. OKE
+	// clusters only when OKE discovery was requested.
+	Enabled(dctx *Context) bool
+	// Discover queries the API and returns a function that stores the
+	// resources in their slot of the Result. The function is called with the
+	// Result locked and may be nil when there is nothing to store.
+	Discover(ctx context.Context, env *Env) (func(*Result), error)
+}
+
+// Env is what a Discoverer runs with: the discovery context, clients whose
+// calls are scheduled and retried, and lookups shared between discoverers.
+type Env struct {
+	Context *Context
+	Clients *Clients
+
+	events              *events
+	discoverer          Discoverer // Running with this Env; nil for lookups shared between discoverers
+	cache               *scopeCache
+	compartments        func() ([]Compartment, error)
+	availabilityDomains func() ([]AvailabilityDomain, error)
+}
+
+func newEnv(ctx context.Context, dctx *Context, clients *Clients, ev *events, cache *scopeCache) *Env {
+	return &Env{
+		Context: dctx,
+		Clients: clients,
+		events:  ev,
+		cache:   cache,
+		compartments: sync.OnceValues(func() ([]Compartment, error) {
+			return cached(ctx, cache, "compartments", func() ([]Compartment, error) {
+				return discoverCompartments(ctx, clients.Identity, dctx.TenancyID)
+			})
+		}),
+		availabilityDomains: sync.OnceValues(func() ([]AvailabilityDomain, error) {
+			return cached(ctx, cache, "availability_domains", func() ([]AvailabilityDomain, error) {
+				return discoverADs(ctx, clients.Identity, dctx.TenancyID)
+			})
+		}),
+	}
+}
+
+// forDiscoverer returns a copy of e for running d, sharing its lookups.
+func (e *Env) forDiscoverer(d Discoverer) *Env {
+	c := *e
+	c.discoverer = d
+	return &c
+}
+
+// Warn reports a problem that leaves a discoverer's output incomplete.
+func (e *Env) Warn(err error) {
+	event := progress.Event{Kind: progress.Warning, Err: err}
+	if e.discoverer != nil {
+		event.Discoverer, event.Label = e.discoverer.Name(), e.discoverer.Label()
+	}
+	e.events.emit(event)
+}
+
+// Compartments returns the compartment tree, listed once per run however
+// many discoverers need it.
+func (e *Env) Compartments() ([]Compartment, error) {
+	return e.compartments()
+}
+
+// AvailabilityDomains returns the tenancy's availability domains, listed once
+// per run however many discoverers need them.
+func (e *Env) AvailabilityDomains() ([]AvailabilityDomain, error) {
+	return e.availabilityDomains()
+}
+
+var registry struct {
+	mu          sync.Mutex
+	discoverers []Discoverer
+}
+
+// Register adds d to the discoverers run by RunWithClients. It panics if a
+// discoverer with the same name is already registered.
+func Register(d Discoverer) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	for _, r := range registry.discoverers {
+		if r.Name() == d.Name() {
+			panic("discovery: Register called twice for discoverer " + d.Name())
+		}
+	}
+	registry.discoverers = append(registry.discoverers, d)
+}
+
+// Discoverers returns the registered discoverers in registration order, the
+// built-in ones first.
+func Discoverers() []Discoverer {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	return append([]Discoverer(nil), registry.discoverers...)
+}
+
+// SelectDiscoverers returns the registered discoverers named in include (all
+// of them when include is empty) less those named in exclude, for
+// Context.Discoverers. Unknown names are an error.
+func SelectDiscoverers(include, exclude []string) ([]Discoverer, error) {
+	all := Discoverers()
+	known := make(map[string]bool, len(all))
+	names := make([]string, 0, len(all))
+	for _, d := range all {
+		known[d.Name()] = true
+		names = append(names, d.Name())
+	}
+	for _, name := range slices.Concat(include, exclude) {
+		if !known[name] {
+			return nil, fmt.Errorf("unknown resource kind %q (valid kinds: %s)", name, strings.Join(names, ", "))
+		}
+	}
+
+	selected := []Discoverer{} // Non-nil: selecting none is not selecting all
+	for _, d := range all {
+		if (len(include) == 0 || slices.Contains(include, d.Name())) && !slices.Contains(exclude, d.Name()) {
+			selected = append(selected, d)
+		}
+	}
+	return selected, nil
+}
+
+// enabledDiscoverers returns the discoverers RunWithClients runs for dctx:
+// dctx.Discoverers, or every registered one, filtered by Enabled.
+func enabledDiscoverers(dctx *Context) []Discoverer {
+	all := dctx.Discoverers
+	if all == nil {
+		all = Discoverers()
+	}
+	var enabled []Discoverer
+	for _, d := range all {
+		if d.Enabled(dctx) {
+			enabled = append(enabled, d)
+		}
+	}
+	return enabled
+}
+
+// has reports whether clients has the client for a built-in service. Other
+// service names belong to discoverers that bring their own clients.
+func (clients *Clients) has(service string) bool {
+	switch service {
+	case "identity":
+		return clients.Identity != nil
+	case "compute":
+		return clients.Compute != nil
+	case "network":
+		return clients.VirtualNetwork != nil
+	case "blockstorage":
+		return clients.Blockstorage != nil
+	case "limits":
+		return clients.Limits != nil
+	case "containerengine":
+		return clients.ContainerEngine != nil
+	case "artifacts":
+		return clients.Artifacts != nil
+	case "objectstorage":
+		return clients.ObjectStorage != nil
+	case "functions":
+		return clients.Functions != nil
+	case "dns":
+		return clients.DNS != nil
+	case "logging":
+		return clients.Logging != nil
+	case "notifications":
+		return clients.Notifications != nil
+	case "monitoring":
+		return clients.Monitoring != nil
+	case "budget":
+		return clients.Budget != nil
+	case "quotas":
+		return clients.Quotas != nil
+	}
+	return true
+}
@@ -0,0 +1,92 @@
+package discovery
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	names := make(map[string]bool)
+	for _, d := range Discoverers() {
+		if names[d.Name()] {
+			t.Errorf("duplicate discoverer %q", d.Name())
+		}
+		names[d.Name()] = true
+		for _, service := range d.Services() {
+			if _, ok := clientFactories[service]; !ok {
+				t.Errorf("discoverer %q uses unknown service %q", d.Name(), service)
+			}
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected registering a duplicate name to panic")
+		}
+	}()
+	Register(&discoverer{name: "vcns"})
+}
+
+func TestEnabledDiscoverers(t *testing.T) {
+	enabled := func(dctx *Context) []string {
+		var names []string
+		for _, d := range enabledDiscoverers(dctx) {
+			names = append(names, d.Name())
+		}
+		return names
+	}
+
+	standard := enabled(&Context{})
+	for _, name := range []string{"a1-capacity", "oke-images", "oke-clusters"} {
+		if slices.Contains(standard, name) {
+			t.Errorf("%s should be disabled by default", name)
+		}
+	}
+	if oke := enabled(&Context{OKE: true}); !slices.Contains(oke, "oke-clusters") || slices.Contains(oke, "a1-capacity") {
+		t.Errorf("unexpected discoverers with OKE: %v", oke)
+	}
+	if free := enabled(&Context{AlwaysFree: true}); !slices.Contains(free, "a1-capacity") || !slices.Contains(free, "oke-images") {
+		t.Errorf("unexpected discoverers in always-free mode: %v", free)
+	}
+
+	only := enabled(&Context{Discoverers: []Discoverer{builtinDiscoverers[0], builtinDiscoverers[3]}})
+	if !slices.Equal(only, []string{"tenancy"}) {
+		t.Errorf("expected dctx.Discoverers filtered by Enabled, got %v", only)
+	}
+}
+
+func TestSelectDiscoverers(t *testing.T) {
+	names := func(ds []Discoverer) []string {
+		var names []string
+		for _, d := range ds {
+			names = append(names, d.Name())
+		}
+		return names
+	}
+
+	selected, err := SelectDiscoverers([]string{"images", "availability-domains"}, nil)
+	if err != nil {
+		t.Fatalf("SelectDiscoverers failed: %v", err)
+	}
+	if got := names(selected); !slices.Equal(got, []string{"availability-domains", "images"}) {
+		t.Errorf("expected registration order, got %v", got)
+	}
+
+	selected, err = SelectDiscoverers(nil, []string{"compartments", "policies"})
+	if err != nil {
+		t.Fatalf("SelectDiscoverers failed: %v", err)
+	}
+	if got := names(selected); len(got) != len(Discoverers())-2 || slices.Contains(got, "compartments") {
+		t.Errorf("expected all but the excluded kinds, got %v", got)
+	}
+
+	// Excluding everything included runs nothing rather than everything
+	selected, err = SelectDiscoverers([]string{"vcns"}, []string{"vcns"})
+	if err != nil || selected == nil || len(selected) != 0 {
+		t.Errorf("expected an empty, non-nil selection, got %v, %v", selected, err)
+	}
+
+	if _, err := SelectDiscoverers([]string{"vnics"}, nil); err == nil {
+		t.Error("expected an unknown kind to be an error")
+	}
+}
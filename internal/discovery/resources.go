@@ -6,6 +6,7 @@ type Compartment struct {
 	Description string `json:"description"`
 	ParentID    string `json:"parent_id"`
 	Path        string `json:"path"`
+	Tags
 }
 
 type AvailabilityDomain struct {
@@ -34,6 +35,7 @@ type Image struct {
 	TimeCreated      string   `json:"time_created"`
 	SizeGB           float64  `json:"size_gb"`
 	CompatibleShapes []string `json:"compatible_shapes"`
+	Tags
 }
 
 type OKEImage struct {
@@ -41,4 +43,5 @@ type OKEImage struct {
 	SourceName        string `json:"source_name"`
 	KubernetesVersion string `json:"kubernetes_version"`
 	Architecture      string `json:"architecture"`
+	IsSupported       bool   `json:"is_supported"` // Version is in the node pool options' supported list
 }
@@ -0,0 +1,310 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+
+	"github.com/larsenclose/oci-tf-bootstrap/internal/progress"
+)
+
+// RetryPolicy configures how failed API calls are retried. The zero value
+// makes a single attempt with no circuit breaking.
+type RetryPolicy struct {
+	MaxRetries       int           // Retries after the first attempt; 0 disables retries
+	BaseDelay        time.Duration // Backoff ceiling for the first retry; doubles with each retry
+	MaxDelay         time.Duration // Upper bound on any single delay, including Retry-After
+	BreakerThreshold int           // Consecutive retryable failures that open a service's circuit; 0 disables
+	BreakerCooldown  time.Duration // How long an open circuit rejects calls before a trial call
+	RequestTimeout   time.Duration // Deadline for each attempt; a timed-out attempt is retried. 0 is none
+}
+
+// DefaultRetryPolicy returns the policy used by NewContext.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:       4,
+		BaseDelay:        500 * time.Millisecond,
+		MaxDelay:         30 * time.Second,
+		BreakerThreshold: 8,
+		BreakerCooldown:  30 * time.Second,
+		RequestTimeout:   time.Minute,
+	}
+}
+
+// backoff returns the delay before retry n (1-based): a uniformly random
+// duration up to BaseDelay*2^(n-1), capped at MaxDelay ("full jitter").
+func (p RetryPolicy) backoff(n int) time.Duration {
+	ceiling := p.BaseDelay << min(n-1, 30)
+	if ceiling <= 0 || (p.MaxDelay > 0 && ceiling > p.MaxDelay) {
+		ceiling = p.MaxDelay
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return rand.N(ceiling) // #nosec G404 -- jitter does not need a secure source
+}
+
+// retryable reports whether err is worth retrying: throttling, OCI-side
+// failures and network errors. Client errors (4xx other than 429) and
+// cancellation are returned immediately.
+func retryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var svcErr common.ServiceError
+	if errors.As(err, &svcErr) {
+		code := svcErr.GetHTTPStatusCode()
+		return code == http.StatusTooManyRequests || code >= 500
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// retryAfter returns the delay requested by a Retry-After header on the OCI
+// response, in either delta-seconds or HTTP-date form.
+func retryAfter(resp any) (time.Duration, bool) {
+	r, ok := resp.(common.OCIResponse)
+	if !ok || r.HTTPResponse() == nil {
+		return 0, false
+	}
+	header := r.HTTPResponse().Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(header); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if at, err := http.ParseTime(header); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
+
+// circuitBreaker stops calls to a service after BreakerThreshold consecutive
+// retryable failures. After BreakerCooldown one trial call is let through:
+// success closes the circuit, failure opens it for another cooldown.
+type circuitBreaker struct {
+	failures  int
+	openUntil time.Time
+	trial     bool  // A trial call is in flight
+	lastErr   error // Failure that opened the circuit
+}
+
+// errCircuitOpen is returned for calls rejected by an open circuit. It wraps
+// the failure that opened the circuit so classifyOCIError can explain it.
+type errCircuitOpen struct {
+	service string
+	cause   error
+}
+
+func (e *errCircuitOpen) Error() string {
+	return fmt.Sprintf("%s API unavailable (circuit open after repeated failures): %v", e.service, e.cause)
+}
+
+func (e *errCircuitOpen) Unwrap() error { return e.cause }
+
+// callStats counts the API calls made to one service.
+type callStats struct {
+	Calls       int // Calls made by discovery, each counted once however often it was retried
+	Retries     int // Additional attempts after retryable failures
+	Failures    int // Calls that failed after exhausting retries or with a non-retryable error
+	CircuitOpen int // Calls rejected by an open circuit
+}
+
+// caller makes API calls on behalf of the wrapped Clients, admitting each
+// attempt through a shared scheduler, retrying with backoff and tracking
+// per-service circuit state and statistics.
+type caller struct {
+	policy RetryPolicy
+	sched  *scheduler
+	sleep  func(ctx context.Context, d time.Duration) error // Replaced in tests
+	events *events                                          // Where retries are reported; nil drops them
+
+	mu       sync.Mutex
+	breakers map[string]*circuitBreaker
+	stats    map[string]*callStats
+}
+
+func newCaller(policy RetryPolicy, schedule SchedulePolicy) *caller {
+	return &caller{
+		policy:   policy,
+		sched:    newScheduler(schedule),
+		sleep:    sleepContext,
+		breakers: make(map[string]*circuitBreaker),
+		stats:    make(map[string]*callStats),
+	}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// serviceStats returns the counters for service. c.mu must be held.
+func (c *caller) serviceStats(service string) *callStats {
+	s, ok := c.stats[service]
+	if !ok {
+		s = &callStats{}
+		c.stats[service] = s
+	}
+	return s
+}
+
+// allow reports whether the circuit for service lets a call through.
+func (c *caller) allow(service string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b, ok := c.breakers[service]
+	if !ok || b.openUntil.IsZero() {
+		return nil
+	}
+	if time.Now().Before(b.openUntil) || b.trial {
+		c.serviceStats(service).CircuitOpen++
+		return &errCircuitOpen{service: service, cause: b.lastErr}
+	}
+	b.trial = true
+	return nil
+}
+
+// record updates the circuit for service with the outcome of one attempt:
+// transient failures count toward opening it, anything else closes it.
+func (c *caller) record(service string, err error, transient bool) {
+	if c.policy.BreakerThreshold <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b, ok := c.breakers[service]
+	if !ok {
+		b = &circuitBreaker{}
+		c.breakers[service] = b
+	}
+	if !transient {
+		// Success, or a client error that says nothing about service health
+		b.failures, b.openUntil, b.trial = 0, time.Time{}, false
+		return
+	}
+	b.failures++
+	b.lastErr = err
+	if b.trial || b.failures >= c.policy.BreakerThreshold {
+		b.openUntil = time.Now().Add(c.policy.BreakerCooldown)
+		b.trial = false
+	}
+}
+
+// invoke calls fn, retrying retryable failures with exponential backoff and
+// jitter, or the delay in the response's Retry-After header when it has one.
+// Each attempt waits for the scheduler; backoff delays do not hold a slot.
+func invoke[T any](ctx context.Context, c *caller, service string, fn func(context.Context) (T, error)) (T, error) {
+	c.mu.Lock()
+	c.serviceStats(service).Calls++
+	c.mu.Unlock()
+
+	for attempt := 0; ; attempt++ {
+		if err := c.allow(service); err != nil {
+			var zero T
+			return zero, err
+		}
+		if err := c.sched.acquire(ctx); err != nil {
+			var zero T
+			return zero, err
+		}
+		resp, err := callWithTimeout(ctx, c.policy.RequestTimeout, fn)
+		c.sched.release()
+		transient := retryable(err)
+		if err != nil && ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+			// The attempt timed out, not the caller: worth another try
+			err = fmt.Errorf("request timed out after %v: %w", c.policy.RequestTimeout, err)
+			transient = true
+		}
+		c.record(service, err, transient)
+		if err == nil {
+			return resp, nil
+		}
+		if !transient || attempt >= c.policy.MaxRetries {
+			c.mu.Lock()
+			c.serviceStats(service).Failures++
+			c.mu.Unlock()
+			return resp, err
+		}
+
+		delay := c.policy.backoff(attempt + 1)
+		if d, ok := retryAfter(resp); ok {
+			delay = d
+			if c.policy.MaxDelay > 0 {
+				delay = min(delay, c.policy.MaxDelay)
+			}
+		}
+		c.mu.Lock()
+		c.serviceStats(service).Retries++
+		c.mu.Unlock()
+		c.events.emitFrom(ctx, progress.Event{Kind: progress.Retry, Service: service, Attempt: attempt + 1, Delay: delay, Err: err})
+		if err := c.sleep(ctx, delay); err != nil {
+			return resp, err
+		}
+	}
+}
+
+// callWithTimeout makes one call to fn, bounded by timeout when it is set.
+func callWithTimeout[T any](ctx context.Context, timeout time.Duration, fn func(context.Context) (T, error)) (T, error) {
+	if timeout <= 0 {
+		return fn(ctx)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return fn(ctx)
+}
+
+// snapshot returns a copy of the per-service call statistics.
+func (c *caller) snapshot() map[string]callStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := make(map[string]callStats, len(c.stats))
+	for service, s := range c.stats {
+		stats[service] = *s
+	}
+	return stats
+}
+
+// callSummary totals the API calls and retries and lists, for services that
+// needed them, retries, failures and calls rejected by an open circuit.
+func callSummary(stats map[string]callStats) *progress.Summary {
+	summary := &progress.Summary{}
+	services := make([]string, 0, len(stats))
+	for service, s := range stats {
+		summary.Calls += s.Calls
+		summary.Retries += s.Retries
+		services = append(services, service)
+	}
+	slices.Sort(services)
+
+	for _, service := range services {
+		s := stats[service]
+		if s.Retries == 0 && s.CircuitOpen == 0 {
+			continue
+		}
+		summary.Services = append(summary.Services, progress.ServiceCalls{
+			Service:     service,
+			Calls:       s.Calls,
+			Retries:     s.Retries,
+			Failures:    s.Failures,
+			CircuitOpen: s.CircuitOpen,
+		})
+	}
+	return summary
+}
@@ -0,0 +1,282 @@
+package discovery
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/oracle/oci-go-sdk/v65/identity"
+
+	"github.com/larsenclose/oci-tf-bootstrap/internal/progress"
+)
+
+// testCaller returns a caller that records its delays instead of sleeping.
+func testCaller(policy RetryPolicy) (*caller, *[]time.Duration) {
+	c := newCaller(policy, SchedulePolicy{})
+	var delays []time.Duration
+	c.sleep = func(_ context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+	return c, &delays
+}
+
+// failing returns a call that fails with errs in order, then succeeds.
+func failing(errs ...error) (func(context.Context) (core.ListImagesResponse, error), *int) {
+	var attempts int
+	return func(context.Context) (core.ListImagesResponse, error) {
+		attempts++
+		if attempts <= len(errs) {
+			return core.ListImagesResponse{}, errs[attempts-1]
+		}
+		return core.ListImagesResponse{Items: []core.Image{{}}}, nil
+	}, &attempts
+}
+
+var (
+	errThrottled   = &mockServiceError{statusCode: 429, code: "TooManyRequests", message: "too many requests"}
+	errUnavailable = &mockServiceError{statusCode: 503, code: "ServiceUnavailable", message: "service unavailable"}
+	errNotFound    = &mockServiceError{statusCode: 404, code: "NotAuthorizedOrNotFound", message: "not found"}
+)
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{errThrottled, true},
+		{errUnavailable, true},
+		{errNotFound, false},
+		{context.Canceled, false},
+		{errors.New("malformed request"), false},
+	}
+	for _, tt := range tests {
+		if got := retryable(tt.err); got != tt.want {
+			t.Errorf("retryable(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestInvokeRetries(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 3, BaseDelay: time.Second, MaxDelay: 4 * time.Second}
+
+	t.Run("retries until success", func(t *testing.T) {
+		c, delays := testCaller(policy)
+		fn, attempts := failing(errThrottled, errUnavailable)
+		resp, err := invoke(context.Background(), c, "compute", fn)
+		if err != nil || len(resp.Items) != 1 {
+			t.Fatalf("expected success after retries, got %v", err)
+		}
+		if *attempts != 3 {
+			t.Errorf("expected 3 attempts, got %d", *attempts)
+		}
+		// Full jitter: retry n waits at most BaseDelay*2^(n-1)
+		for i, d := range *delays {
+			if ceiling := time.Second << i; d < 0 || d >= ceiling {
+				t.Errorf("delay %d = %v, want [0, %v)", i, d, ceiling)
+			}
+		}
+		if s := c.snapshot()["compute"]; s.Calls != 1 || s.Retries != 2 || s.Failures != 0 {
+			t.Errorf("unexpected stats %+v", s)
+		}
+	})
+
+	t.Run("gives up after MaxRetries", func(t *testing.T) {
+		c, _ := testCaller(policy)
+		fn, attempts := failing(errThrottled, errThrottled, errThrottled, errThrottled, errThrottled)
+		if _, err := invoke(context.Background(), c, "compute", fn); !errors.Is(err, errThrottled) {
+			t.Fatalf("expected the last error, got %v", err)
+		}
+		if *attempts != 4 {
+			t.Errorf("expected 4 attempts, got %d", *attempts)
+		}
+		if s := c.snapshot()["compute"]; s.Retries != 3 || s.Failures != 1 {
+			t.Errorf("unexpected stats %+v", s)
+		}
+	})
+
+	t.Run("client errors are not retried", func(t *testing.T) {
+		c, _ := testCaller(policy)
+		fn, attempts := failing(errNotFound)
+		if _, err := invoke(context.Background(), c, "compute", fn); !errors.Is(err, errNotFound) {
+			t.Fatalf("expected the 404, got %v", err)
+		}
+		if *attempts != 1 {
+			t.Errorf("expected a single attempt, got %d", *attempts)
+		}
+	})
+
+	t.Run("zero policy makes one attempt", func(t *testing.T) {
+		c, _ := testCaller(RetryPolicy{})
+		fn, attempts := failing(errThrottled)
+		if _, err := invoke(context.Background(), c, "compute", fn); err == nil {
+			t.Fatal("expected an error")
+		}
+		if *attempts != 1 {
+			t.Errorf("expected a single attempt, got %d", *attempts)
+		}
+	})
+}
+
+func TestInvokeRetryAfter(t *testing.T) {
+	c, delays := testCaller(RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Second})
+	var attempts int
+	_, err := invoke(context.Background(), c, "compute", func(context.Context) (core.ListImagesResponse, error) {
+		attempts++
+		if attempts == 1 {
+			raw := &http.Response{Header: http.Header{"Retry-After": []string{"7"}}}
+			return core.ListImagesResponse{RawResponse: raw}, errThrottled
+		}
+		if attempts == 2 {
+			raw := &http.Response{Header: http.Header{"Retry-After": []string{"60"}}}
+			return core.ListImagesResponse{RawResponse: raw}, errThrottled
+		}
+		return core.ListImagesResponse{}, nil
+	})
+	if err != nil {
+		t.Fatalf("invoke failed: %v", err)
+	}
+	want := []time.Duration{7 * time.Second, 10 * time.Second} // Capped at MaxDelay
+	if len(*delays) != 2 || (*delays)[0] != want[0] || (*delays)[1] != want[1] {
+		t.Errorf("delays = %v, want %v", *delays, want)
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	c, _ := testCaller(RetryPolicy{MaxRetries: 1, BreakerThreshold: 3, BreakerCooldown: time.Hour})
+
+	// Two calls of two attempts each: the third consecutive failure opens the circuit
+	fn, attempts := failing(errUnavailable, errUnavailable, errUnavailable)
+	_, _ = invoke(context.Background(), c, "network", fn)
+	_, err := invoke(context.Background(), c, "network", fn)
+
+	var open *errCircuitOpen
+	if !errors.As(err, &open) {
+		t.Fatalf("expected the circuit to open, got %v", err)
+	}
+	if *attempts != 3 {
+		t.Errorf("expected 3 attempts before the circuit opened, got %d", *attempts)
+	}
+	// The open-circuit error wraps the service error for classification
+	if msg := classifyOCIError("VCN discovery", err).Error(); !strings.Contains(msg, "OCI service error") {
+		t.Errorf("expected service error guidance, got %q", msg)
+	}
+
+	// Other services are unaffected
+	other, _ := failing()
+	if _, err := invoke(context.Background(), c, "compute", other); err != nil {
+		t.Errorf("compute should not share the network circuit: %v", err)
+	}
+
+	// After the cooldown one trial call closes the circuit again
+	c.breakers["network"].openUntil = time.Now().Add(-time.Second)
+	if _, err := invoke(context.Background(), c, "network", fn); err != nil {
+		t.Errorf("trial call should succeed after the cooldown: %v", err)
+	}
+	if s := c.snapshot()["network"]; s.CircuitOpen != 1 {
+		t.Errorf("expected 1 rejected call, got %+v", s)
+	}
+}
+
+func TestCallSummary(t *testing.T) {
+	summary := callSummary(map[string]callStats{
+		"identity": {Calls: 10},
+		"compute":  {Calls: 4, Retries: 3, Failures: 1, CircuitOpen: 2},
+	})
+	if summary.Calls != 14 || summary.Retries != 3 {
+		t.Errorf("expected totals of 14 calls and 3 retries, got %+v", summary)
+	}
+	want := []progress.ServiceCalls{{Service: "compute", Calls: 4, Retries: 3, Failures: 1, CircuitOpen: 2}}
+	if !slices.Equal(summary.Services, want) {
+		t.Errorf("expected only the compute details, got %+v", summary.Services)
+	}
+}
+
+func TestInvokeRequestTimeout(t *testing.T) {
+	c, _ := testCaller(RetryPolicy{MaxRetries: 2, RequestTimeout: 5 * time.Millisecond})
+	var attempts int
+	_, err := invoke(context.Background(), c, "compute", func(ctx context.Context) (core.ListImagesResponse, error) {
+		attempts++
+		if attempts < 3 {
+			<-ctx.Done()
+			return core.ListImagesResponse{}, ctx.Err()
+		}
+		return core.ListImagesResponse{}, nil
+	})
+	if err != nil {
+		t.Fatalf("expected success after timed-out attempts were retried, got %v", err)
+	}
+	if s := c.snapshot()["compute"]; s.Retries != 2 {
+		t.Errorf("expected 2 retries, got %+v", s)
+	}
+
+	// Cancellation by the caller ends the call without retrying
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	attempts = 0
+	_, err = invoke(ctx, c, "network", func(ctx context.Context) (core.ListVcnsResponse, error) {
+		attempts++
+		return core.ListVcnsResponse{}, ctx.Err()
+	})
+	if !errors.Is(err, context.Canceled) || attempts > 1 {
+		t.Errorf("expected one canceled attempt, got %d attempts and %v", attempts, err)
+	}
+}
+
+func TestSDKClientsRetriedOnlyByCaller(t *testing.T) {
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = io.WriteString(w, `{"code":"TooManyRequests","message":"too many requests"}`)
+	}))
+	defer srv.Close()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	provider := common.NewRawConfigurationProvider("ocid1.tenancy.oc1..test", "ocid1.user.oc1..test", "us-ashburn-1", "aa:bb", string(pemKey), nil)
+
+	clients := &Clients{}
+	for service, newClient := range clientFactories {
+		if err := newClient(provider, clients); err != nil {
+			t.Fatalf("%s client: %v", service, err)
+		}
+	}
+	fields := reflect.ValueOf(clients).Elem()
+	for i := range fields.NumField() {
+		base := reflect.ValueOf(fields.Field(i).Interface()).FieldByName("BaseClient").Interface().(common.BaseClient)
+		if p := base.Configuration.RetryPolicy; p == nil || p.MaximumNumberAttempts != 1 || base.Configuration.CircuitBreaker != nil {
+			t.Errorf("%s: expected the SDK's retries and circuit breaker to be off", fields.Type().Field(i).Name)
+		}
+	}
+
+	client := clients.Identity.(identity.IdentityClient)
+	client.Host = srv.URL
+	clients.Identity = client
+	c, _ := testCaller(RetryPolicy{MaxRetries: 2})
+	if _, err := clients.wrap(c).Identity.ListAvailabilityDomains(context.Background(), identity.ListAvailabilityDomainsRequest{CompartmentId: common.String("ocid1.tenancy.oc1..test")}); err == nil {
+		t.Fatal("expected the throttled call to fail")
+	}
+	if got := attempts.Load(); got != 3 {
+		t.Errorf("expected MaxRetries+1 = 3 attempts, got %d", got)
+	}
+}
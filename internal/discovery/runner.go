@@ -5,13 +5,24 @@ import (
 	"fmt"
 	"os"
 	"sync"
+	"time"
 
+	"github.com/oracle/oci-go-sdk/v65/artifacts"
+	"github.com/oracle/oci-go-sdk/v65/budget"
 	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/oracle/oci-go-sdk/v65/containerengine"
 	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/oracle/oci-go-sdk/v65/dns"
+	"github.com/oracle/oci-go-sdk/v65/functions"
 	"github.com/oracle/oci-go-sdk/v65/identity"
 	lim "github.com/oracle/oci-go-sdk/v65/limits"
+	"github.com/oracle/oci-go-sdk/v65/logging"
+	"github.com/oracle/oci-go-sdk/v65/monitoring"
+	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+	"github.com/oracle/oci-go-sdk/v65/ons"
 	"golang.org/x/sync/errgroup"
+
+	"github.com/larsenclose/oci-tf-bootstrap/internal/progress"
 )
 
 // Clients holds the OCI API clients used during discovery.
@@ -23,202 +34,252 @@ type Clients struct {
 	Blockstorage    BlockstorageAPI
 	Limits          LimitsAPI
 	ContainerEngine ContainerEngineAPI
+	Artifacts       ArtifactsAPI
+	ObjectStorage   ObjectStorageAPI
+	Functions       FunctionsAPI
+	DNS             DNSAPI
+	Logging         LoggingAPI
+	Notifications   NotificationAPI
+	Monitoring      MonitoringAPI
+	Budget          BudgetAPI
+	Quotas          QuotasAPI
 }
 
-// Run creates concrete OCI clients from the config provider and delegates to RunWithClients.
-func Run(ctx *Context) (*Result, error) {
-	configProvider, err := common.ConfigurationProviderFromFileWithProfile(ctx.ConfigPath, ctx.Profile, "")
+// Run creates the OCI clients needed by the enabled discoverers from the
+// config provider and delegates to RunWithClients.
+func Run(ctx context.Context, dctx *Context) (*Result, error) {
+	configProvider, err := common.ConfigurationProviderFromFileWithProfile(dctx.ConfigPath, dctx.Profile, "")
 	if err != nil {
 		return nil, err
 	}
 
-	identityClient, err := identity.NewIdentityClientWithConfigurationProvider(configProvider)
-	if err != nil {
-		return nil, fmt.Errorf("identity client: %w", err)
-	}
-
-	computeClient, err := core.NewComputeClientWithConfigurationProvider(configProvider)
-	if err != nil {
-		return nil, fmt.Errorf("compute client: %w", err)
-	}
-
-	networkClient, err := core.NewVirtualNetworkClientWithConfigurationProvider(configProvider)
-	if err != nil {
-		return nil, fmt.Errorf("network client: %w", err)
+	clients := &Clients{}
+	for _, d := range enabledDiscoverers(dctx) {
+		for _, service := range d.Services() {
+			newClient, ok := clientFactories[service]
+			if !ok || clients.has(service) {
+				continue
+			}
+			if err := newClient(configProvider, clients); err != nil {
+				return nil, fmt.Errorf("%s client: %w", service, err)
+			}
+		}
 	}
 
-	blockstorageClient, err := core.NewBlockstorageClientWithConfigurationProvider(configProvider)
-	if err != nil {
-		return nil, fmt.Errorf("blockstorage client: %w", err)
-	}
+	return RunWithClients(ctx, dctx, clients)
+}
 
-	limitsClient, err := lim.NewLimitsClientWithConfigurationProvider(configProvider)
-	if err != nil {
-		return nil, fmt.Errorf("limits client: %w", err)
-	}
+// callerOnly turns off the SDK's own retries and circuit breaker, so every
+// call is retried and its circuit tracked once, by the caller wrapping the
+// client, as RetryPolicy and the progress events describe.
+func callerOnly(client *common.BaseClient) {
+	noRetry := common.NoRetryPolicy()
+	client.Configuration.RetryPolicy = &noRetry
+	client.Configuration.CircuitBreaker = nil
+}
 
-	ceClient, err := containerengine.NewContainerEngineClientWithConfigurationProvider(configProvider)
-	if err != nil {
-		return nil, fmt.Errorf("containerengine client: %w", err)
-	}
+// clientFactories create the client for each built-in service, by the names
+// used in Discoverer.Services.
+var clientFactories = map[string]func(common.ConfigurationProvider, *Clients) error{
+	"identity": func(p common.ConfigurationProvider, c *Clients) error {
+		client, err := identity.NewIdentityClientWithConfigurationProvider(p)
+		callerOnly(&client.BaseClient)
+		c.Identity = client
+		return err
+	},
+	"compute": func(p common.ConfigurationProvider, c *Clients) error {
+		client, err := core.NewComputeClientWithConfigurationProvider(p)
+		callerOnly(&client.BaseClient)
+		c.Compute = client
+		return err
+	},
+	"network": func(p common.ConfigurationProvider, c *Clients) error {
+		client, err := core.NewVirtualNetworkClientWithConfigurationProvider(p)
+		callerOnly(&client.BaseClient)
+		c.VirtualNetwork = client
+		return err
+	},
+	"blockstorage": func(p common.ConfigurationProvider, c *Clients) error {
+		client, err := core.NewBlockstorageClientWithConfigurationProvider(p)
+		callerOnly(&client.BaseClient)
+		c.Blockstorage = client
+		return err
+	},
+	"limits": func(p common.ConfigurationProvider, c *Clients) error {
+		client, err := lim.NewLimitsClientWithConfigurationProvider(p)
+		callerOnly(&client.BaseClient)
+		c.Limits = client
+		return err
+	},
+	"containerengine": func(p common.ConfigurationProvider, c *Clients) error {
+		client, err := containerengine.NewContainerEngineClientWithConfigurationProvider(p)
+		callerOnly(&client.BaseClient)
+		c.ContainerEngine = client
+		return err
+	},
+	"artifacts": func(p common.ConfigurationProvider, c *Clients) error {
+		client, err := artifacts.NewArtifactsClientWithConfigurationProvider(p)
+		callerOnly(&client.BaseClient)
+		c.Artifacts = client
+		return err
+	},
+	"objectstorage": func(p common.ConfigurationProvider, c *Clients) error {
+		client, err := objectstorage.NewObjectStorageClientWithConfigurationProvider(p)
+		callerOnly(&client.BaseClient)
+		c.ObjectStorage = client
+		return err
+	},
+	"functions": func(p common.ConfigurationProvider, c *Clients) error {
+		client, err := functions.NewFunctionsManagementClientWithConfigurationProvider(p)
+		callerOnly(&client.BaseClient)
+		c.Functions = client
+		return err
+	},
+	"dns": func(p common.ConfigurationProvider, c *Clients) error {
+		client, err := dns.NewDnsClientWithConfigurationProvider(p)
+		callerOnly(&client.BaseClient)
+		c.DNS = client
+		return err
+	},
+	"logging": func(p common.ConfigurationProvider, c *Clients) error {
+		client, err := logging.NewLoggingManagementClientWithConfigurationProvider(p)
+		callerOnly(&client.BaseClient)
+		c.Logging = client
+		return err
+	},
+	"notifications": func(p common.ConfigurationProvider, c *Clients) error {
+		client, err := ons.NewNotificationControlPlaneClientWithConfigurationProvider(p)
+		callerOnly(&client.BaseClient)
+		c.Notifications = client
+		return err
+	},
+	"monitoring": func(p common.ConfigurationProvider, c *Clients) error {
+		client, err := monitoring.NewMonitoringClientWithConfigurationProvider(p)
+		callerOnly(&client.BaseClient)
+		c.Monitoring = client
+		return err
+	},
+	"budget": func(p common.ConfigurationProvider, c *Clients) error {
+		client, err := budget.NewBudgetClientWithConfigurationProvider(p)
+		callerOnly(&client.BaseClient)
+		c.Budget = client
+		return err
+	},
+	"quotas": func(p common.ConfigurationProvider, c *Clients) error {
+		client, err := lim.NewQuotasClientWithConfigurationProvider(p)
+		callerOnly(&client.BaseClient)
+		c.Quotas = client
+		return err
+	},
+}
 
-	clients := &Clients{
-		Identity:        identityClient,
-		Compute:         computeClient,
-		VirtualNetwork:  networkClient,
-		Blockstorage:    blockstorageClient,
-		Limits:          limitsClient,
-		ContainerEngine: ceClient,
+// RunWithClients runs the enabled discoverers (dctx.Discoverers, or every
+// registered one) concurrently using the provided clients. This enables
+// mock-based testing of the discovery orchestration. A failing fatal
+// discoverer aborts discovery; other failures are printed as warnings.
+//
+// When dctx.TagFilters is set, tenancy-owned resources are limited to those
+// carrying every filter tag. Shapes, availability domains, limits, platform
+// images, Oracle-defined backup policies, OKE images, tag namespaces and VCN
+// resolvers carry no tenancy tags and are never filtered.
+//
+// Every call through clients is admitted by a scheduler shared across services
+// (dctx.Schedule) and retried according to dctx.Retry; the number of calls and
+// retries is reported when discovery finishes.
+//
+// Progress is reported to dctx.Progress as each discoverer starts and
+// finishes, with warnings and retries in between and a Done event carrying
+// the call and cache summary last; handlers are called one event at a time.
+// Without a handler the events are written as text to dctx.ProgressWriter.
+//
+// With dctx.Cache set, each discoverer's output is read from and stored in
+// the on-disk cache; the A1.Flex capacity report is always fetched live.
+//
+// Cancelling ctx, or passing its deadline, abandons in-flight calls. The
+// resources gathered so far are returned with an error wrapping ctx's cause.
+func RunWithClients(ctx context.Context, dctx *Context, clients *Clients) (*Result, error) {
+	discoverers := enabledDiscoverers(dctx)
+	for _, d := range discoverers {
+		for _, service := range d.Services() {
+			if !clients.has(service) {
+				return nil, fmt.Errorf("discoverer %s needs the %s client", d.Name(), service)
+			}
+		}
 	}
 
-	return RunWithClients(ctx, clients)
-}
-
-// RunWithClients runs the full discovery pipeline using the provided clients.
-// This enables mock-based testing of the discovery orchestration.
-func RunWithClients(ctx *Context, clients *Clients) (*Result, error) {
 	result := &Result{
-		CompartmentID: ctx.CompartmentID,
+		CompartmentID: dctx.CompartmentID,
 		Tenancy: TenancyInfo{
-			ID:         ctx.TenancyID,
-			HomeRegion: ctx.Region,
+			ID:         dctx.TenancyID,
+			HomeRegion: dctx.Region,
 		},
 	}
 	var mu sync.Mutex
-	g, gctx := errgroup.WithContext(context.Background())
+	g, gctx := errgroup.WithContext(ctx)
 
-	w := ctx.ProgressWriter
-	if w == nil {
-		w = os.Stdout
+	handler := dctx.Progress
+	if handler == nil {
+		w := dctx.ProgressWriter
+		if w == nil {
+			w = os.Stdout
+		}
+		handler = progress.Text(w)
 	}
+	ev := newEvents(handler)
 
-	fmt.Fprintln(w, "Discovering resources...")
+	calls := newCaller(dctx.Retry, dctx.Schedule)
+	calls.events = ev
+	cache := newScopeCache(dctx.Cache, dctx, ev)
+	env := newEnv(gctx, dctx, clients.wrap(calls), ev, cache)
 
-	g.Go(func() error {
-		fmt.Fprintln(w, "  → Tenancy Details")
-		tenancy, err := discoverTenancy(gctx, clients.Identity, ctx.TenancyID)
-		if err != nil {
-			return classifyOCIError("tenancy details", err)
-		}
-		mu.Lock()
-		result.Tenancy = tenancy
-		result.Tenancy.HomeRegion = ctx.Region
-		mu.Unlock()
-		return nil
-	})
-
-	g.Go(func() error {
-		fmt.Fprintln(w, "  → Compartments")
-		comps, err := discoverCompartments(gctx, clients.Identity, ctx.TenancyID)
-		if err != nil {
-			return classifyOCIError("compartments", err)
-		}
-		mu.Lock()
-		result.Compartments = comps
-		mu.Unlock()
-		return nil
-	})
-
-	g.Go(func() error {
-		fmt.Fprintln(w, "  → Availability Domains")
-		ads, err := discoverADs(gctx, clients.Identity, ctx.TenancyID)
-		if err != nil {
-			return classifyOCIError("availability domains", err)
-		}
-		mu.Lock()
-		result.AvailabilityDomains = ads
-		mu.Unlock()
-		return nil
-	})
-
-	g.Go(func() error {
-		fmt.Fprintln(w, "  → Shapes")
-		shapes, err := discoverShapes(gctx, clients.Compute, ctx.CompartmentID)
-		if err != nil {
-			return classifyOCIError("shapes", err)
-		}
-		mu.Lock()
-		result.Shapes = shapes
-		mu.Unlock()
-		return nil
-	})
-
-	g.Go(func() error {
-		fmt.Fprintln(w, "  → Images")
-		images, err := discoverImages(gctx, clients.Compute, ctx.CompartmentID)
-		if err != nil {
-			return classifyOCIError("images", err)
-		}
-		mu.Lock()
-		result.Images = images
-		mu.Unlock()
-		return nil
-	})
-
-	g.Go(func() error {
-		fmt.Fprintln(w, "  → VCNs")
-		vcns, err := discoverVCNs(gctx, clients.VirtualNetwork, ctx.CompartmentID)
-		if err != nil {
-			fmt.Fprintf(w, "    ⚠ %v\n", classifyOCIError("VCN discovery", err))
-			return nil
-		}
-		mu.Lock()
-		result.VCNs = vcns
-		mu.Unlock()
-		return nil
-	})
-
-	g.Go(func() error {
-		fmt.Fprintln(w, "  → Service Limits")
-		limits, err := discoverLimits(gctx, clients.Limits, ctx.TenancyID)
-		if err != nil {
-			fmt.Fprintf(w, "    ⚠ %v\n", classifyOCIError("service limits", err))
-			return nil
-		}
-		mu.Lock()
-		result.Limits = limits
-		mu.Unlock()
-		return nil
-	})
-
-	g.Go(func() error {
-		fmt.Fprintln(w, "  → Block Volumes")
-		volumes, err := discoverBlockVolumes(gctx, clients.Blockstorage, ctx.CompartmentID)
-		if err != nil {
-			fmt.Fprintf(w, "    ⚠ %v\n", classifyOCIError("block volume discovery", err))
-			return nil
-		}
-		mu.Lock()
-		result.BlockVolumes = volumes
-		mu.Unlock()
-		return nil
-	})
-
-	// Discover OKE images when explicitly requested or in always-free mode
-	if ctx.AlwaysFree || ctx.OKE {
+	for _, d := range discoverers {
 		g.Go(func() error {
-			fmt.Fprintln(w, "  → OKE Node Images")
-			okeImages, err := discoverOKEImages(gctx, clients.ContainerEngine, ctx.CompartmentID)
+			start := time.Now()
+			ev.emit(progress.Event{Kind: progress.Started, Discoverer: d.Name(), Label: d.Label()})
+			finished := progress.Event{Kind: progress.Finished, Discoverer: d.Name(), Label: d.Label()}
+			denv := env.forDiscoverer(d)
+			store, err := d.Discover(withDiscoverer(gctx, d), denv)
 			if err != nil {
-				fmt.Fprintf(w, "    ⚠ %v\n", classifyOCIError("OKE image discovery", err))
+				if !d.Fatal() {
+					denv.Warn(err)
+				}
+				finished.Duration, finished.Err = time.Since(start), err
+				ev.emit(finished)
+				if d.Fatal() {
+					return err
+				}
 				return nil
 			}
-			mu.Lock()
-			result.OKEImages = okeImages
-			mu.Unlock()
+			if store != nil {
+				mu.Lock()
+				finished.Count = countStored(result, store)
+				mu.Unlock()
+			}
+			finished.Duration = time.Since(start)
+			ev.emit(finished)
 			return nil
 		})
 	}
 
-	if err := g.Wait(); err != nil {
+	err := g.Wait()
+	summary := callSummary(calls.snapshot())
+	summary.Cache = cache.use()
+	ev.emit(progress.Event{Kind: progress.Done, Summary: summary})
+	interrupted := ctx.Err() != nil
+	if err != nil && !interrupted {
 		return nil, err
 	}
 
+	result.OKEVersions = OKEVersionReport(result)
+
 	// Apply always-free filtering if requested
-	if ctx.AlwaysFree {
+	if dctx.AlwaysFree {
 		result.Shapes = FilterShapesForAlwaysFree(result.Shapes)
 		result.Images = FilterImagesForAlwaysFree(result.Images)
 	}
 
+	if interrupted {
+		return result, fmt.Errorf("discovery interrupted, result is partial: %w", context.Cause(ctx))
+	}
 	return result, nil
 }
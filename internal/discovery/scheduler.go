@@ -0,0 +1,95 @@
+package discovery
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// SchedulePolicy bounds the load discovery puts on the OCI API. The budget is
+// shared by every service, so concurrent discoverers queue for it instead of
+// tripping throttling. The zero value is unlimited.
+type SchedulePolicy struct {
+	MaxInFlight       int     // Concurrent API calls; 0 is unlimited
+	RequestsPerSecond float64 // Sustained call rate, bursting up to one second's worth; 0 is unlimited
+}
+
+// DefaultSchedulePolicy returns the policy used by NewContext.
+func DefaultSchedulePolicy() SchedulePolicy {
+	return SchedulePolicy{MaxInFlight: 8, RequestsPerSecond: 10}
+}
+
+// scheduler admits API calls within a SchedulePolicy: a semaphore for calls
+// in flight and a token bucket for the request rate.
+type scheduler struct {
+	slots chan struct{} // nil when in-flight calls are unlimited
+	rate  float64       // Tokens per second; 0 when the rate is unlimited
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newScheduler(policy SchedulePolicy) *scheduler {
+	s := &scheduler{}
+	if policy.MaxInFlight > 0 {
+		s.slots = make(chan struct{}, policy.MaxInFlight)
+	}
+	if policy.RequestsPerSecond > 0 {
+		s.rate = policy.RequestsPerSecond
+		s.burst = math.Max(1, math.Ceil(policy.RequestsPerSecond))
+		s.tokens = s.burst
+		s.last = time.Now()
+	}
+	return s
+}
+
+// acquire blocks until a call may start: first a slot, then a token. Every
+// successful acquire must be paired with release.
+func (s *scheduler) acquire(ctx context.Context) error {
+	if s.slots != nil {
+		select {
+		case s.slots <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if err := s.take(ctx); err != nil {
+		s.release()
+		return err
+	}
+	return nil
+}
+
+// release frees the slot taken by acquire.
+func (s *scheduler) release() {
+	if s.slots != nil {
+		<-s.slots
+	}
+}
+
+// take removes one token from the bucket, waiting for it to refill if empty.
+func (s *scheduler) take(ctx context.Context) error {
+	if s.rate == 0 {
+		return nil
+	}
+	for {
+		s.mu.Lock()
+		now := time.Now()
+		s.tokens = math.Min(s.burst, s.tokens+now.Sub(s.last).Seconds()*s.rate)
+		s.last = now
+		if s.tokens >= 1 {
+			s.tokens--
+			s.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - s.tokens) / s.rate * float64(time.Second))
+		s.mu.Unlock()
+
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package discovery
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/oracle/oci-go-sdk/v65/core"
+)
+
+func TestSchedulerMaxInFlight(t *testing.T) {
+	c := newCaller(RetryPolicy{}, SchedulePolicy{MaxInFlight: 3})
+
+	var inFlight, peak atomic.Int32
+	call := func(context.Context) (core.ListSubnetsResponse, error) {
+		n := inFlight.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		inFlight.Add(-1)
+		return core.ListSubnetsResponse{}, nil
+	}
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Go(func() {
+			if _, err := invoke(context.Background(), c, "network", call); err != nil {
+				t.Errorf("invoke failed: %v", err)
+			}
+		})
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got != 3 {
+		t.Errorf("expected at most 3 calls in flight and the limit reached, peak was %d", got)
+	}
+}
+
+func TestSchedulerRate(t *testing.T) {
+	s := newScheduler(SchedulePolicy{RequestsPerSecond: 100})
+
+	// The first second's worth of calls is a burst; the next 20 are paced at 10ms
+	start := time.Now()
+	for range 120 {
+		if err := s.acquire(context.Background()); err != nil {
+			t.Fatalf("acquire failed: %v", err)
+		}
+		s.release()
+	}
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("expected 120 calls at 100/s with a burst of 100 to take ~200ms, took %v", elapsed)
+	}
+}
+
+func TestSchedulerCanceled(t *testing.T) {
+	s := newScheduler(SchedulePolicy{MaxInFlight: 1})
+	if err := s.acquire(context.Background()); err != nil {
+		t.Fatalf("acquire failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := s.acquire(ctx); err != context.Canceled {
+		t.Errorf("expected a waiting acquire to return on cancel, got %v", err)
+	}
+
+	// An unlimited scheduler never blocks
+	unlimited := newScheduler(SchedulePolicy{})
+	for range 1000 {
+		if err := unlimited.acquire(context.Background()); err != nil {
+			t.Fatalf("unlimited acquire failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package discovery
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Tags holds the freeform and defined tags recorded on a discovered resource.
+// Defined tag values are flattened to strings.
+type Tags struct {
+	FreeformTags map[string]string            `json:"freeform_tags,omitempty"`
+	DefinedTags  map[string]map[string]string `json:"defined_tags,omitempty"`
+}
+
+func newTags(freeform map[string]string, defined map[string]map[string]interface{}) Tags {
+	t := Tags{}
+	if len(freeform) > 0 {
+		t.FreeformTags = freeform
+	}
+	if len(defined) > 0 {
+		t.DefinedTags = make(map[string]map[string]string, len(defined))
+		for ns, kv := range defined {
+			t.DefinedTags[ns] = make(map[string]string, len(kv))
+			for k, v := range kv {
+				t.DefinedTags[ns][k] = fmt.Sprint(v)
+			}
+		}
+	}
+	return t
+}
+
+type TagNamespace struct {
+	ID            string          `json:"id"`
+	Name          string          `json:"name"`
+	Description   string          `json:"description"`
+	CompartmentID string          `json:"compartment_id"`
+	IsRetired     bool            `json:"is_retired"`
+	Definitions   []TagDefinition `json:"definitions"`
+	Tags
+}
+
+type TagDefinition struct {
+	ID             string   `json:"id"`
+	Name           string   `json:"name"`
+	Description    string   `json:"description"`
+	IsCostTracking bool     `json:"is_cost_tracking"`
+	IsRetired      bool     `json:"is_retired"`
+	AllowedValues  []string `json:"allowed_values,omitempty"` // Set when the tag uses an enum validator
+}
+
+// TagDefault is a value applied automatically to resources created in a
+// compartment. Required defaults must be supplied by the caller instead.
+type TagDefault struct {
+	ID            string `json:"id"`
+	CompartmentID string `json:"compartment_id"`
+	Namespace     string `json:"namespace"`
+	Key           string `json:"key"`
+	Value         string `json:"value"`
+	IsRequired    bool   `json:"is_required"`
+}
+
+// ParseDefinedTags parses a comma-separated list of Namespace.Key=Value pairs
+// into a map keyed by "Namespace.Key".
+func ParseDefinedTags(s string) (map[string]string, error) {
+	tags := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid tag %q: expected Namespace.Key=Value", pair)
+		}
+		ns, name, ok := strings.Cut(key, ".")
+		if !ok || ns == "" || name == "" {
+			return nil, fmt.Errorf("invalid tag %q: defined tags need a namespace (Namespace.Key=Value)", pair)
+		}
+		tags[key] = value
+	}
+	return tags, nil
+}
+
+// ValidateTags checks defined tags against the discovered tag namespaces and
+// returns a warning for each unknown or retired tag, each value outside an enum,
+// and each required tag default that is not supplied. Validation is skipped when
+// no tag namespaces were discovered.
+func ValidateTags(result *Result, tags map[string]string) []string {
+	if len(result.TagNamespaces) == 0 {
+		return nil
+	}
+
+	defs := make(map[string]TagDefinition)
+	namespaces := make(map[string]TagNamespace)
+	for _, ns := range result.TagNamespaces {
+		namespaces[ns.Name] = ns
+		for _, d := range ns.Definitions {
+			defs[ns.Name+"."+d.Name] = d
+		}
+	}
+
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var warnings []string
+	for _, key := range keys {
+		nsName, _, _ := strings.Cut(key, ".")
+		ns, ok := namespaces[nsName]
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("tag namespace %q not found", nsName))
+			continue
+		}
+		if ns.IsRetired {
+			warnings = append(warnings, fmt.Sprintf("tag namespace %q is retired", nsName))
+			continue
+		}
+		def, ok := defs[key]
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("tag %q not defined in namespace %q", key, nsName))
+			continue
+		}
+		if def.IsRetired {
+			warnings = append(warnings, fmt.Sprintf("tag %q is retired", key))
+			continue
+		}
+		if len(def.AllowedValues) > 0 && !slices.Contains(def.AllowedValues, tags[key]) {
+			warnings = append(warnings, fmt.Sprintf("tag %q value %q not in allowed values: %s", key, tags[key], strings.Join(def.AllowedValues, ", ")))
+		}
+	}
+
+	for _, d := range result.TagDefaults {
+		key := d.Namespace + "." + d.Key
+		if _, ok := tags[key]; d.IsRequired && !ok {
+			warnings = append(warnings, fmt.Sprintf("required tag %q not supplied", key))
+		}
+	}
+	return warnings
+}
+
+// TagFilter restricts discovery to resources carrying a tag with the given
+// value. An empty Namespace matches a freeform tag.
+type TagFilter struct {
+	Namespace string
+	Key       string
+	Value     string
+}
+
+func (f TagFilter) String() string {
+	if f.Namespace == "" {
+		return f.Key + "=" + f.Value
+	}
+	return f.Namespace + "." + f.Key + "=" + f.Value
+}
+
+// ParseTagFilters parses a comma-separated list of Namespace.Key=Value (defined)
+// and Key=Value (freeform) filters.
+func ParseTagFilters(s string) ([]TagFilter, error) {
+	var filters []TagFilter
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid tag filter %q: expected Namespace.Key=Value or Key=Value", pair)
+		}
+		f := TagFilter{Key: key, Value: value}
+		if ns, name, ok := strings.Cut(key, "."); ok {
+			if ns == "" || name == "" {
+				return nil, fmt.Errorf("invalid tag filter %q: expected Namespace.Key=Value or Key=Value", pair)
+			}
+			f.Namespace, f.Key = ns, name
+		}
+		filters = append(filters, f)
+	}
+	return filters, nil
+}
+
+// MatchesTagFilters reports whether the tags satisfy every filter. Namespace and
+// key names are compared case-insensitively, as OCI does; values are exact.
+func (t Tags) MatchesTagFilters(filters []TagFilter) bool {
+	for _, f := range filters {
+		if !t.matches(f) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t Tags) matches(f TagFilter) bool {
+	if f.Namespace == "" {
+		for k, v := range t.FreeformTags {
+			if strings.EqualFold(k, f.Key) && v == f.Value {
+				return true
+			}
+		}
+		return false
+	}
+	for ns, kv := range t.DefinedTags {
+		if !strings.EqualFold(ns, f.Namespace) {
+			continue
+		}
+		for k, v := range kv {
+			if strings.EqualFold(k, f.Key) && v == f.Value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type taggedResource interface {
+	MatchesTagFilters(filters []TagFilter) bool
+}
+
+// filterByTags returns the items matching every filter; with no filters the
+// items are returned unchanged.
+func filterByTags[T taggedResource](items []T, filters []TagFilter) []T {
+	if len(filters) == 0 {
+		return items
+	}
+	var matched []T
+	for _, item := range items {
+		if item.MatchesTagFilters(filters) {
+			matched = append(matched, item)
+		}
+	}
+	return matched
+}
@@ -0,0 +1,162 @@
+package discovery
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseDefinedTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected map[string]string
+		wantErr  bool
+	}{
+		{"single", "Operations.CostCenter=42", map[string]string{"Operations.CostCenter": "42"}, false},
+		{"multiple with spaces", "Operations.CostCenter=42, Operations.Owner=alice", map[string]string{"Operations.CostCenter": "42", "Operations.Owner": "alice"}, false},
+		{"empty value", "Operations.Owner=", map[string]string{"Operations.Owner": ""}, false},
+		{"value with equals", "Ops.Note=a=b", map[string]string{"Ops.Note": "a=b"}, false},
+		{"empty input", "", map[string]string{}, false},
+		{"missing value", "Operations.CostCenter", nil, true},
+		{"missing namespace", "CostCenter=42", nil, true},
+		{"empty key", "Operations.=42", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDefinedTags(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != len(tt.expected) {
+				t.Fatalf("got %v, want %v", got, tt.expected)
+			}
+			for k, v := range tt.expected {
+				if got[k] != v {
+					t.Errorf("%s = %q, want %q", k, got[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateTags(t *testing.T) {
+	result := &Result{
+		TagNamespaces: []TagNamespace{
+			{Name: "Operations", Definitions: []TagDefinition{
+				{Name: "CostCenter", AllowedValues: []string{"eng", "ops"}},
+				{Name: "Owner"},
+				{Name: "Legacy", IsRetired: true},
+			}},
+			{Name: "Old", IsRetired: true},
+		},
+		TagDefaults: []TagDefault{
+			{Namespace: "Operations", Key: "CostCenter", IsRequired: true},
+			{Namespace: "Operations", Key: "Owner", Value: "${iam.principal.name}"},
+		},
+	}
+
+	if w := ValidateTags(result, map[string]string{"Operations.CostCenter": "eng", "Operations.Owner": "alice"}); len(w) != 0 {
+		t.Errorf("expected no warnings, got %v", w)
+	}
+
+	warnings := ValidateTags(result, map[string]string{
+		"Operations.Owner":  "alice",
+		"Operations.Legacy": "x",
+		"Operations.Team":   "x",
+		"Missing.Key":       "x",
+		"Old.Key":           "x",
+	})
+	joined := strings.Join(warnings, "\n")
+	for _, e := range []string{
+		`tag namespace "Missing" not found`,
+		`tag namespace "Old" is retired`,
+		`tag "Operations.Legacy" is retired`,
+		`tag "Operations.Team" not defined`,
+		`required tag "Operations.CostCenter" not supplied`,
+	} {
+		if !strings.Contains(joined, e) {
+			t.Errorf("warnings should contain %q, got:\n%s", e, joined)
+		}
+	}
+
+	enum := ValidateTags(result, map[string]string{"Operations.CostCenter": "sales"})
+	if len(enum) != 1 || !strings.Contains(enum[0], "not in allowed values: eng, ops") {
+		t.Errorf("expected enum warning, got %v", enum)
+	}
+
+	if w := ValidateTags(&Result{}, map[string]string{"Any.Key": "x"}); w != nil {
+		t.Errorf("validation should be skipped without discovered namespaces, got %v", w)
+	}
+}
+
+func TestParseTagFilters(t *testing.T) {
+	filters, err := ParseTagFilters("Operations.Team=platform, env=prod")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []TagFilter{
+		{Namespace: "Operations", Key: "Team", Value: "platform"},
+		{Key: "env", Value: "prod"},
+	}
+	if len(filters) != len(expected) {
+		t.Fatalf("got %+v, want %+v", filters, expected)
+	}
+	for i := range expected {
+		if filters[i] != expected[i] {
+			t.Errorf("filter %d = %+v, want %+v", i, filters[i], expected[i])
+		}
+		if filters[i].String() == "" {
+			t.Errorf("filter %d should render", i)
+		}
+	}
+	if filters[0].String() != "Operations.Team=platform" || filters[1].String() != "env=prod" {
+		t.Errorf("unexpected rendering: %s, %s", filters[0], filters[1])
+	}
+
+	for _, bad := range []string{"Operations.Team", "=x", ".Team=x", "Operations.=x"} {
+		if _, err := ParseTagFilters(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestMatchesTagFilters(t *testing.T) {
+	tags := Tags{
+		FreeformTags: map[string]string{"env": "prod"},
+		DefinedTags:  map[string]map[string]string{"Operations": {"Team": "platform"}},
+	}
+
+	tests := []struct {
+		name     string
+		filters  []TagFilter
+		expected bool
+	}{
+		{"no filters", nil, true},
+		{"freeform", []TagFilter{{Key: "env", Value: "prod"}}, true},
+		{"freeform key case-insensitive", []TagFilter{{Key: "ENV", Value: "prod"}}, true},
+		{"freeform value mismatch", []TagFilter{{Key: "env", Value: "dev"}}, false},
+		{"defined", []TagFilter{{Namespace: "operations", Key: "team", Value: "platform"}}, true},
+		{"defined value case-sensitive", []TagFilter{{Namespace: "Operations", Key: "Team", Value: "Platform"}}, false},
+		{"defined key is not freeform", []TagFilter{{Key: "Team", Value: "platform"}}, false},
+		{"all must match", []TagFilter{{Key: "env", Value: "prod"}, {Namespace: "Operations", Key: "Team", Value: "data"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tags.MatchesTagFilters(tt.filters); got != tt.expected {
+				t.Errorf("MatchesTagFilters(%v) = %v, want %v", tt.filters, got, tt.expected)
+			}
+		})
+	}
+
+	// Resources embedding Tags are filtered through the promoted method.
+	vols := []BlockVolume{{ID: "a", Tags: tags}, {ID: "b"}}
+	if got := filterByTags(vols, []TagFilter{{Key: "env", Value: "prod"}}); len(got) != 1 || got[0].ID != "a" {
+		t.Errorf("filterByTags returned %+v", got)
+	}
+	if got := filterByTags(vols, nil); len(got) != 2 {
+		t.Errorf("filterByTags without filters should keep all items, got %d", len(got))
+	}
+}
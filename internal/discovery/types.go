@@ -1,6 +1,10 @@
 package discovery
 
-import "io"
+import (
+	"io"
+
+	"github.com/larsenclose/oci-tf-bootstrap/internal/progress"
+)
 
 type Context struct {
 	TenancyID      string
@@ -10,22 +14,52 @@ type Context struct {
 	ConfigPath     string // Full path to config file (e.g., ~/.oci/config)
 	ConfigDir      string // Directory containing config file (e.g., ~/.oci)
 	AlwaysFree     bool
-	OKE            bool      // Explicitly enable OKE image discovery
-	CompartmentID  string    // Target compartment (defaults to TenancyID for root)
-	ProgressWriter io.Writer // Where to write progress/diagnostic output (default: os.Stdout)
+	OKE            bool             // Explicitly enable OKE image discovery
+	CompartmentID  string           // Target compartment (defaults to TenancyID for root)
+	ProgressWriter io.Writer        // Where to write progress/diagnostic output (default: os.Stdout)
+	Progress       progress.Handler // Receives progress events (nil: written as text to ProgressWriter)
+	TagFilters     []TagFilter      // Only discover resources carrying all of these tags
+	Retry          RetryPolicy      // Retry, backoff and circuit breaking for every API call (zero value: no retries)
+	Schedule       SchedulePolicy   // Concurrency and rate budget shared by every API call (zero value: unlimited)
+	Cache          *Cache           // On-disk cache of discoverer output (nil: always query the API)
+	Discoverers    []Discoverer     // Discoverers to run (nil: every registered one)
 }
 
 type Result struct {
-	CompartmentID       string               `json:"compartment_id"` // Compartment used for discovery
-	Tenancy             TenancyInfo          `json:"tenancy"`
-	Compartments        []Compartment        `json:"compartments"`
-	AvailabilityDomains []AvailabilityDomain `json:"availability_domains"`
-	Shapes              []Shape              `json:"shapes"`
-	Images              []Image              `json:"images"`
-	OKEImages           []OKEImage           `json:"oke_images,omitempty"`
-	VCNs                []VCN                `json:"vcns"`
-	BlockVolumes        []BlockVolume        `json:"block_volumes"`
-	Limits              []ServiceLimit       `json:"limits"`
+	FormatVersion         string                 `json:"format_version"` // Set by renderer.OutputJSON
+	CompartmentID         string                 `json:"compartment_id"` // Compartment used for discovery
+	Tenancy               TenancyInfo            `json:"tenancy"`
+	Compartments          []Compartment          `json:"compartments"`
+	AvailabilityDomains   []AvailabilityDomain   `json:"availability_domains"`
+	A1Capacity            *CapacityReport        `json:"a1_capacity,omitempty"` // Always-free mode only
+	Shapes                []Shape                `json:"shapes"`
+	Images                []Image                `json:"images"`
+	OKEImages             []OKEImage             `json:"oke_images,omitempty"`
+	OKEClusters           []OKECluster           `json:"oke_clusters,omitempty"`
+	OKESupportedVersions  []string               `json:"oke_supported_versions,omitempty"`
+	OKEVersions           []OKEVersionStatus     `json:"oke_versions,omitempty"` // Derived from OKEClusters and OKEImages
+	VCNs                  []VCN                  `json:"vcns"`
+	BlockVolumes          []BlockVolume          `json:"block_volumes"`
+	BootVolumes           []BootVolume           `json:"boot_volumes"`
+	BackupPolicies        []BackupPolicy         `json:"backup_policies"`
+	VolumeGroups          []VolumeGroup          `json:"volume_groups"`
+	Limits                []ServiceLimit         `json:"limits"`
+	Groups                []Group                `json:"groups"`
+	DynamicGroups         []DynamicGroup         `json:"dynamic_groups"`
+	Policies              []Policy               `json:"policies"`
+	TagNamespaces         []TagNamespace         `json:"tag_namespaces"`
+	TagDefaults           []TagDefault           `json:"tag_defaults"`
+	Registry              *Registry              `json:"registry,omitempty"`
+	FunctionsApplications []FunctionsApplication `json:"functions_applications"`
+	DNSZones              []DNSZone              `json:"dns_zones"`
+	DNSViews              []DNSView              `json:"dns_views"`
+	DNSResolvers          []DNSResolver          `json:"dns_resolvers"`
+	LogGroups             []LogGroup             `json:"log_groups"`
+	NotificationTopics    []NotificationTopic    `json:"notification_topics"`
+	Alarms                []Alarm                `json:"alarms"`
+	Budgets               []Budget               `json:"budgets"`
+	Quotas                []Quota                `json:"quotas"`
+	Extensions            map[string]any         `json:"extensions,omitempty"` // Output of discoverers registered outside this package, by name
 }
 
 type TenancyInfo struct {
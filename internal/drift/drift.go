@@ -0,0 +1,243 @@
+// Package drift compares two discovery results and reports the resources
+// added, removed and changed between them.
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/larsenclose/oci-tf-bootstrap/internal/discovery"
+	"github.com/larsenclose/oci-tf-bootstrap/internal/renderer"
+)
+
+// Report lists the differences between an old and a new discovery result.
+// Resources are identified by OCID, or by name for those without one.
+type Report struct {
+	Added   []Resource `json:"added"`
+	Removed []Resource `json:"removed"`
+	Changed []Resource `json:"changed"`
+	Skipped []string   `json:"skipped"` // Kinds not compared because their discoverer did not run
+}
+
+// Resource is one added, removed or changed resource.
+type Resource struct {
+	Kind    string        `json:"kind"` // Result JSON field, e.g. "subnets"
+	Key     string        `json:"key"`  // OCID, or the identifying name
+	Name    string        `json:"name,omitempty"`
+	Changes []FieldChange `json:"changes,omitempty"` // Changed resources only
+}
+
+// FieldChange is a field whose value differs between the results.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+// Drifted reports whether the results differ at all.
+func (r *Report) Drifted() bool {
+	return len(r.Added) > 0 || len(r.Removed) > 0 || len(r.Changed) > 0
+}
+
+// kind is a collection of resources compared between results.
+type kind struct {
+	name       string
+	discoverer string   // Discoverer listing the resources
+	path       []string // JSON fields leading to the resources; nested arrays are flattened
+	key        []string // Fields identifying a resource, joined with spaces
+	ignore     []string // Fields that change without the resource changing, or compared as their own kind
+}
+
+// kinds lists the compared collections in report order. VCN children are
+// their own kinds so that, say, a removed subnet is reported as such rather
+// than as a change to its VCN. Images are keyed by operating system and
+// version, so a newer image for a pinned OS shows up as a changed image.
+// Derived and point-in-time data (OKE version report, A1 capacity, limit
+// usage) is not compared.
+var kinds = []kind{
+	{name: "compartments", discoverer: "compartments", path: []string{"compartments"}},
+	{name: "availability_domains", discoverer: "availability-domains", path: []string{"availability_domains"}, key: []string{"name"}},
+	{name: "shapes", discoverer: "shapes", path: []string{"shapes"}, key: []string{"name"}, ignore: []string{"available_limit"}},
+	{name: "images", discoverer: "images", path: []string{"images"}, key: []string{"operating_system", "operating_system_version"}},
+	{name: "vcns", discoverer: "vcns", path: []string{"vcns"}, ignore: []string{"subnets", "security_lists", "route_tables", "internet_gateway", "nat_gateway"}},
+	{name: "subnets", discoverer: "vcns", path: []string{"vcns", "subnets"}},
+	{name: "security_lists", discoverer: "vcns", path: []string{"vcns", "security_lists"}},
+	{name: "route_tables", discoverer: "vcns", path: []string{"vcns", "route_tables"}},
+	{name: "internet_gateways", discoverer: "vcns", path: []string{"vcns", "internet_gateway"}},
+	{name: "nat_gateways", discoverer: "vcns", path: []string{"vcns", "nat_gateway"}},
+	{name: "block_volumes", discoverer: "block-volumes", path: []string{"block_volumes"}},
+	{name: "boot_volumes", discoverer: "boot-volumes", path: []string{"boot_volumes"}},
+	{name: "volume_groups", discoverer: "volume-groups", path: []string{"volume_groups"}},
+	{name: "backup_policies", discoverer: "backup-policies", path: []string{"backup_policies"}},
+	{name: "limits", discoverer: "limits", path: []string{"limits"}, key: []string{"service_name", "limit_name", "scope"}, ignore: []string{"available", "used"}},
+	{name: "groups", discoverer: "groups", path: []string{"groups"}},
+	{name: "dynamic_groups", discoverer: "dynamic-groups", path: []string{"dynamic_groups"}},
+	{name: "policies", discoverer: "policies", path: []string{"policies"}},
+	{name: "tag_namespaces", discoverer: "tag-namespaces", path: []string{"tag_namespaces"}},
+	{name: "tag_defaults", discoverer: "tag-namespaces", path: []string{"tag_defaults"}},
+	{name: "container_repositories", discoverer: "registry", path: []string{"registry", "repositories"}, ignore: []string{"image_count"}},
+	{name: "functions_applications", discoverer: "functions", path: []string{"functions_applications"}},
+	{name: "dns_zones", discoverer: "dns", path: []string{"dns_zones"}},
+	{name: "dns_views", discoverer: "dns", path: []string{"dns_views"}},
+	{name: "dns_resolvers", discoverer: "dns", path: []string{"dns_resolvers"}},
+	{name: "log_groups", discoverer: "observability", path: []string{"log_groups"}},
+	{name: "notification_topics", discoverer: "observability", path: []string{"notification_topics"}},
+	{name: "alarms", discoverer: "observability", path: []string{"alarms"}},
+	{name: "budgets", discoverer: "budgets", path: []string{"budgets"}, ignore: []string{"actual_spend", "forecasted_spend"}},
+	{name: "quotas", discoverer: "budgets", path: []string{"quotas"}},
+	{name: "oke_clusters", discoverer: "oke-clusters", path: []string{"oke_clusters"}},
+	{name: "oke_images", discoverer: "oke-images", path: []string{"oke_images"}},
+}
+
+// Compare returns the differences from old to new. Kinds listed by a
+// discoverer in notRun are not compared, since new has none of them, and are
+// reported as skipped instead.
+func Compare(old, new *discovery.Result, notRun []string) (*Report, error) {
+	oldDoc, err := document(old)
+	if err != nil {
+		return nil, err
+	}
+	newDoc, err := document(new)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Added: []Resource{}, Removed: []Resource{}, Changed: []Resource{}, Skipped: []string{}}
+	for _, k := range kinds {
+		if slices.Contains(notRun, k.discoverer) {
+			report.Skipped = append(report.Skipped, k.name)
+			continue
+		}
+		before, after := k.resources(oldDoc), k.resources(newDoc)
+		for _, key := range slices.Sorted(maps.Keys(before)) {
+			if _, ok := after[key]; !ok {
+				report.Removed = append(report.Removed, Resource{Kind: k.name, Key: key, Name: displayName(before[key])})
+			}
+		}
+		for _, key := range slices.Sorted(maps.Keys(after)) {
+			a := after[key]
+			b, ok := before[key]
+			if !ok {
+				report.Added = append(report.Added, Resource{Kind: k.name, Key: key, Name: displayName(a)})
+				continue
+			}
+			if changes := k.compare(b, a); len(changes) > 0 {
+				report.Changed = append(report.Changed, Resource{Kind: k.name, Key: key, Name: displayName(a), Changes: changes})
+			}
+		}
+	}
+	return report, nil
+}
+
+// document returns result as generic JSON, the form resources are compared in.
+func document(result *discovery.Result) (map[string]any, error) {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// resources returns the kind's resources in doc by key.
+func (k kind) resources(doc map[string]any) map[string]map[string]any {
+	byKey := make(map[string]map[string]any)
+	for _, v := range collect(doc, k.path) {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+		byKey[k.keyOf(obj)] = obj
+	}
+	return byKey
+}
+
+// collect follows path through v, flattening arrays on the way.
+func collect(v any, path []string) []any {
+	switch v := v.(type) {
+	case []any:
+		var out []any
+		for _, item := range v {
+			out = append(out, collect(item, path)...)
+		}
+		return out
+	case map[string]any:
+		if len(path) == 0 {
+			return []any{v}
+		}
+		return collect(v[path[0]], path[1:])
+	}
+	return nil
+}
+
+func (k kind) keyOf(obj map[string]any) string {
+	fields := k.key
+	if fields == nil {
+		fields = []string{"id"}
+	}
+	parts := make([]string, len(fields))
+	for i, f := range fields {
+		parts[i] = fmt.Sprint(obj[f])
+	}
+	return strings.Join(parts, " ")
+}
+
+// compare returns the fields that differ between two versions of a resource.
+func (k kind) compare(old, new map[string]any) []FieldChange {
+	fields := slices.Sorted(maps.Keys(old))
+	for f := range new {
+		if _, ok := old[f]; !ok {
+			fields = append(fields, f)
+		}
+	}
+	slices.Sort(fields)
+
+	var changes []FieldChange
+	for _, f := range fields {
+		if slices.Contains(k.ignore, f) || reflect.DeepEqual(old[f], new[f]) {
+			continue
+		}
+		changes = append(changes, FieldChange{Field: f, Old: old[f], New: new[f]})
+	}
+	return changes
+}
+
+func displayName(obj map[string]any) string {
+	for _, f := range []string{"display_name", "name", "source_name"} {
+		if s, ok := obj[f].(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// LoadSnapshot reads a discovery result written by --json. Snapshots from
+// another major format version are an error; those written before the
+// format version was recorded are read as they are.
+func LoadSnapshot(path string) (*discovery.Result, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is a user-specified snapshot
+	if err != nil {
+		return nil, err
+	}
+	var result discovery.Result
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("%s: not a discovery result: %w", path, err)
+	}
+	if result.FormatVersion != "" && major(result.FormatVersion) != major(renderer.FormatVersion) {
+		return nil, fmt.Errorf("%s: format version %s is not compatible with %s", path, result.FormatVersion, renderer.FormatVersion)
+	}
+	return &result, nil
+}
+
+func major(version string) string {
+	m, _, _ := strings.Cut(version, ".")
+	return m
+}
@@ -0,0 +1,208 @@
+package drift
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/larsenclose/oci-tf-bootstrap/internal/discovery"
+	"github.com/larsenclose/oci-tf-bootstrap/internal/renderer"
+)
+
+func snapshot() *discovery.Result {
+	return &discovery.Result{
+		Images: []discovery.Image{
+			{ID: "image-1", DisplayName: "Oracle-Linux-9.4-2024.09.30-0", OS: "Oracle Linux", OSVersion: "9"},
+		},
+		VCNs: []discovery.VCN{{
+			ID:          "vcn-1",
+			DisplayName: "main",
+			CIDRBlock:   "10.0.0.0/16",
+			Subnets: []discovery.Subnet{
+				{ID: "subnet-1", DisplayName: "public", CIDRBlock: "10.0.1.0/24"},
+				{ID: "subnet-2", DisplayName: "private", CIDRBlock: "10.0.2.0/24"},
+			},
+			SecurityLists: []discovery.SecurityList{{
+				ID:           "seclist-1",
+				DisplayName:  "default",
+				IngressRules: []discovery.SecurityRule{{Protocol: "6", Source: "0.0.0.0/0", PortMin: 22, PortMax: 22}},
+			}},
+		}},
+		Limits: []discovery.ServiceLimit{
+			{ServiceName: "compute", LimitName: "standard-a1-core-count", Value: 4, AvailableAmt: 4, Scope: "AD-1"},
+		},
+	}
+}
+
+func TestCompare(t *testing.T) {
+	old := snapshot()
+	current := snapshot()
+	current.Images[0].ID = "image-2"
+	current.Images[0].DisplayName = "Oracle-Linux-9.5-2025.01.31-0"
+	current.VCNs[0].Subnets = current.VCNs[0].Subnets[:1]
+	current.VCNs[0].SecurityLists[0].IngressRules = append(current.VCNs[0].SecurityLists[0].IngressRules,
+		discovery.SecurityRule{Protocol: "6", Source: "0.0.0.0/0", PortMin: 443, PortMax: 443})
+	current.VCNs = append(current.VCNs, discovery.VCN{ID: "vcn-2", DisplayName: "staging", CIDRBlock: "10.1.0.0/16"})
+	current.Limits[0].AvailableAmt = 0
+	current.Limits[0].UsedAmt = 4
+
+	report, err := Compare(old, current, nil)
+	if err != nil {
+		t.Fatalf("Compare failed: %v", err)
+	}
+	if !report.Drifted() {
+		t.Fatal("expected drift")
+	}
+
+	if len(report.Added) != 1 || report.Added[0].Kind != "vcns" || report.Added[0].Key != "vcn-2" || report.Added[0].Name != "staging" {
+		t.Errorf("expected the staging VCN to be added, got %+v", report.Added)
+	}
+	if len(report.Removed) != 1 || report.Removed[0].Kind != "subnets" || report.Removed[0].Key != "subnet-2" {
+		t.Errorf("expected the private subnet to be removed, got %+v", report.Removed)
+	}
+
+	changed := make(map[string]Resource)
+	for _, r := range report.Changed {
+		changed[r.Kind] = r
+	}
+	if len(changed) != 2 {
+		t.Errorf("expected an image and a security list to change, got %+v", report.Changed)
+	}
+	image := changed["images"]
+	if image.Key != "Oracle Linux 9" || len(image.Changes) != 2 || image.Changes[0].Field != "display_name" || image.Changes[1].Field != "id" {
+		t.Errorf("expected a newer image for the same OS and version, got %+v", image)
+	}
+	seclist := changed["security_lists"]
+	if len(seclist.Changes) != 1 || seclist.Changes[0].Field != "ingress_rules" {
+		t.Errorf("expected the ingress rules to change, got %+v", seclist)
+	}
+	if _, ok := changed["limits"]; ok {
+		t.Error("expected limit usage to be ignored")
+	}
+	if _, ok := changed["vcns"]; ok {
+		t.Error("expected VCN children not to be reported as a change to the VCN")
+	}
+
+	t.Run("identical results", func(t *testing.T) {
+		report, err := Compare(snapshot(), snapshot(), nil)
+		if err != nil {
+			t.Fatalf("Compare failed: %v", err)
+		}
+		if report.Drifted() {
+			t.Errorf("expected no drift, got %+v", report)
+		}
+		var buf bytes.Buffer
+		report.WriteText(&buf)
+		if buf.String() != "No drift.\n" {
+			t.Errorf("unexpected text report: %q", buf.String())
+		}
+	})
+
+	t.Run("discoverers not run", func(t *testing.T) {
+		current := snapshot()
+		current.VCNs, current.Limits = nil, nil
+		report, err := Compare(snapshot(), current, []string{"vcns", "limits"})
+		if err != nil {
+			t.Fatalf("Compare failed: %v", err)
+		}
+		if report.Drifted() {
+			t.Errorf("expected kinds of discoverers not run to be left out, got %+v", report)
+		}
+		want := []string{"vcns", "subnets", "security_lists", "route_tables", "internet_gateways", "nat_gateways", "limits"}
+		if !slices.Equal(report.Skipped, want) {
+			t.Errorf("expected skipped kinds %v, got %v", want, report.Skipped)
+		}
+		var buf bytes.Buffer
+		report.WriteText(&buf)
+		if want := "No drift.\n\nNot compared (discoverer not run): vcns, subnets, security_lists, route_tables, internet_gateways, nat_gateways, limits\n"; buf.String() != want {
+			t.Errorf("unexpected text report: %q", buf.String())
+		}
+	})
+
+	t.Run("text report", func(t *testing.T) {
+		var buf bytes.Buffer
+		report.WriteText(&buf)
+		out := buf.String()
+		for _, want := range []string{
+			"Drift: 1 added, 1 removed, 2 changed",
+			"+ vcns                   vcn-2 (staging)",
+			"- subnets                subnet-2 (private)",
+			`id: "image-1" → "image-2"`,
+			"ingress_rules: 1 → 2 items",
+		} {
+			if !strings.Contains(out, want) {
+				t.Errorf("text report missing %q:\n%s", want, out)
+			}
+		}
+	})
+
+	t.Run("JSON report", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := report.WriteJSON(&buf); err != nil {
+			t.Fatalf("WriteJSON failed: %v", err)
+		}
+		var decoded Report
+		if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+			t.Fatalf("invalid JSON report: %v", err)
+		}
+		if len(decoded.Added) != 1 || len(decoded.Removed) != 1 || len(decoded.Changed) != 2 {
+			t.Errorf("unexpected JSON report: %s", buf.String())
+		}
+	})
+}
+
+func TestKindDiscoverers(t *testing.T) {
+	registered := make(map[string]bool)
+	for _, d := range discovery.Discoverers() {
+		registered[d.Name()] = true
+	}
+	for _, k := range kinds {
+		if !registered[k.discoverer] {
+			t.Errorf("%s: discoverer %q is not registered", k.name, k.discoverer)
+		}
+	}
+}
+
+func TestLoadSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "snapshot.json")
+	var buf bytes.Buffer
+	if err := renderer.OutputJSON(snapshot(), &buf); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadSnapshot(path)
+	if err != nil {
+		t.Fatalf("LoadSnapshot failed: %v", err)
+	}
+	report, err := Compare(snapshot(), loaded, nil)
+	if err != nil {
+		t.Fatalf("Compare failed: %v", err)
+	}
+	if report.Drifted() {
+		t.Errorf("expected a loaded snapshot to match the original, got %+v", report)
+	}
+
+	future := filepath.Join(dir, "future.json")
+	if err := os.WriteFile(future, []byte(`{"format_version": "99.0.0"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSnapshot(future); err == nil || !strings.Contains(err.Error(), "not compatible") {
+		t.Errorf("expected an error for another major format version, got %v", err)
+	}
+
+	bad := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(bad, []byte("not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSnapshot(bad); err == nil || !strings.Contains(err.Error(), "not a discovery result") {
+		t.Errorf("expected an error for a file that is not a result, got %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteJSON writes the report as indented JSON for machine consumption.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteText writes the report for people: one line per resource, with the
+// changed fields of changed resources beneath it. Lists and objects are
+// summarized; WriteJSON has their full values. Skipped kinds are listed last.
+func (r *Report) WriteText(w io.Writer) {
+	defer func() {
+		if len(r.Skipped) > 0 {
+			fmt.Fprintf(w, "\nNot compared (discoverer not run): %s\n", strings.Join(r.Skipped, ", "))
+		}
+	}()
+	if !r.Drifted() {
+		fmt.Fprintln(w, "No drift.")
+		return
+	}
+	fmt.Fprintf(w, "Drift: %d added, %d removed, %d changed\n", len(r.Added), len(r.Removed), len(r.Changed))
+
+	sections := []struct {
+		title     string
+		mark      string
+		resources []Resource
+	}{
+		{"Added", "+", r.Added},
+		{"Removed", "-", r.Removed},
+		{"Changed", "~", r.Changed},
+	}
+	for _, s := range sections {
+		if len(s.resources) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n%s:\n", s.title)
+		for _, res := range s.resources {
+			fmt.Fprintf(w, "  %s %-22s %s", s.mark, res.Kind, res.Key)
+			if res.Name != "" && res.Name != res.Key {
+				fmt.Fprintf(w, " (%s)", res.Name)
+			}
+			fmt.Fprintln(w)
+			for _, c := range res.Changes {
+				fmt.Fprintf(w, "      %s: %s\n", c.Field, describeChange(c.Old, c.New))
+			}
+		}
+	}
+}
+
+// describeChange summarizes a field change on one line.
+func describeChange(old, new any) string {
+	oldList, oldIsList := old.([]any)
+	newList, newIsList := new.([]any)
+	if oldIsList || newIsList {
+		if len(oldList) == len(newList) {
+			return fmt.Sprintf("%d items, contents changed", len(newList))
+		}
+		return fmt.Sprintf("%d → %d items", len(oldList), len(newList))
+	}
+	_, oldIsObj := old.(map[string]any)
+	_, newIsObj := new.(map[string]any)
+	if oldIsObj || newIsObj {
+		return "changed"
+	}
+	return formatValue(old) + " → " + formatValue(new)
+}
+
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "(none)"
+	case string:
+		return fmt.Sprintf("%q", v)
+	}
+	return fmt.Sprint(v)
+}
@@ -2,18 +2,34 @@ package integration
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/oracle/oci-go-sdk/v65/artifacts"
+	"github.com/oracle/oci-go-sdk/v65/budget"
+	"github.com/oracle/oci-go-sdk/v65/common"
 	"github.com/oracle/oci-go-sdk/v65/containerengine"
 	"github.com/oracle/oci-go-sdk/v65/core"
+	"github.com/oracle/oci-go-sdk/v65/dns"
+	"github.com/oracle/oci-go-sdk/v65/functions"
 	"github.com/oracle/oci-go-sdk/v65/identity"
 	lim "github.com/oracle/oci-go-sdk/v65/limits"
+	"github.com/oracle/oci-go-sdk/v65/logging"
+	"github.com/oracle/oci-go-sdk/v65/monitoring"
+	"github.com/oracle/oci-go-sdk/v65/objectstorage"
+	"github.com/oracle/oci-go-sdk/v65/ons"
 
 	"github.com/larsenclose/oci-tf-bootstrap/internal/discovery"
+	"github.com/larsenclose/oci-tf-bootstrap/internal/progress"
 	"github.com/larsenclose/oci-tf-bootstrap/internal/renderer"
 )
 
@@ -31,10 +47,24 @@ type mockIdentityClient struct {
 	compartmentErr error
 	ads            []identity.AvailabilityDomain
 	adErr          error
+	adCalls        atomic.Int32
 	faultDomains   []identity.FaultDomain
 	faultDomainErr error
 	tenancy        identity.Tenancy
 	tenancyErr     error
+	groups         []identity.Group
+	groupErr       error
+	dynamicGroups  []identity.DynamicGroup
+	dynamicGrpErr  error
+	policies       []identity.Policy
+	policyErrs     map[string]error // keyed by compartment OCID
+	tagNamespaces  []identity.TagNamespaceSummary
+	tagNsErr       error
+	tags           map[string][]identity.TagSummary               // keyed by namespace OCID
+	tagValidators  map[string]identity.BaseTagDefinitionValidator // keyed by tag name
+	tagDefaults    []identity.TagDefaultSummary
+	tagDefaultErr  error
+	regions        []identity.Region
 }
 
 func (m *mockIdentityClient) ListCompartments(_ context.Context, _ identity.ListCompartmentsRequest) (identity.ListCompartmentsResponse, error) {
@@ -45,6 +75,7 @@ func (m *mockIdentityClient) ListCompartments(_ context.Context, _ identity.List
 }
 
 func (m *mockIdentityClient) ListAvailabilityDomains(_ context.Context, _ identity.ListAvailabilityDomainsRequest) (identity.ListAvailabilityDomainsResponse, error) {
+	m.adCalls.Add(1)
 	if m.adErr != nil {
 		return identity.ListAvailabilityDomainsResponse{}, m.adErr
 	}
@@ -65,6 +96,65 @@ func (m *mockIdentityClient) GetTenancy(_ context.Context, _ identity.GetTenancy
 	return identity.GetTenancyResponse{Tenancy: m.tenancy}, nil
 }
 
+func (m *mockIdentityClient) ListGroups(_ context.Context, _ identity.ListGroupsRequest) (identity.ListGroupsResponse, error) {
+	if m.groupErr != nil {
+		return identity.ListGroupsResponse{}, m.groupErr
+	}
+	return identity.ListGroupsResponse{Items: m.groups}, nil
+}
+
+func (m *mockIdentityClient) ListDynamicGroups(_ context.Context, _ identity.ListDynamicGroupsRequest) (identity.ListDynamicGroupsResponse, error) {
+	if m.dynamicGrpErr != nil {
+		return identity.ListDynamicGroupsResponse{}, m.dynamicGrpErr
+	}
+	return identity.ListDynamicGroupsResponse{Items: m.dynamicGroups}, nil
+}
+
+func (m *mockIdentityClient) ListPolicies(_ context.Context, req identity.ListPoliciesRequest) (identity.ListPoliciesResponse, error) {
+	if err := m.policyErrs[*req.CompartmentId]; err != nil {
+		return identity.ListPoliciesResponse{}, err
+	}
+	var items []identity.Policy
+	for _, p := range m.policies {
+		if *p.CompartmentId == *req.CompartmentId {
+			items = append(items, p)
+		}
+	}
+	return identity.ListPoliciesResponse{Items: items}, nil
+}
+
+func (m *mockIdentityClient) ListTagNamespaces(_ context.Context, _ identity.ListTagNamespacesRequest) (identity.ListTagNamespacesResponse, error) {
+	if m.tagNsErr != nil {
+		return identity.ListTagNamespacesResponse{}, m.tagNsErr
+	}
+	return identity.ListTagNamespacesResponse{Items: m.tagNamespaces}, nil
+}
+
+func (m *mockIdentityClient) ListTags(_ context.Context, req identity.ListTagsRequest) (identity.ListTagsResponse, error) {
+	return identity.ListTagsResponse{Items: m.tags[*req.TagNamespaceId]}, nil
+}
+
+func (m *mockIdentityClient) GetTag(_ context.Context, req identity.GetTagRequest) (identity.GetTagResponse, error) {
+	return identity.GetTagResponse{Tag: identity.Tag{Name: req.TagName, Validator: m.tagValidators[*req.TagName]}}, nil
+}
+
+func (m *mockIdentityClient) ListTagDefaults(_ context.Context, req identity.ListTagDefaultsRequest) (identity.ListTagDefaultsResponse, error) {
+	if m.tagDefaultErr != nil {
+		return identity.ListTagDefaultsResponse{}, m.tagDefaultErr
+	}
+	var items []identity.TagDefaultSummary
+	for _, d := range m.tagDefaults {
+		if *d.CompartmentId == *req.CompartmentId {
+			items = append(items, d)
+		}
+	}
+	return identity.ListTagDefaultsResponse{Items: items}, nil
+}
+
+func (m *mockIdentityClient) ListRegions(_ context.Context) (identity.ListRegionsResponse, error) {
+	return identity.ListRegionsResponse{Items: m.regions}, nil
+}
+
 // --- Mock Compute Client ---
 
 type mockComputeClient struct {
@@ -72,6 +162,7 @@ type mockComputeClient struct {
 	shapeErr error
 	images   []core.Image
 	imageErr error
+	capacity map[string]core.CapacityReportShapeAvailability // keyed by fault domain
 }
 
 func (m *mockComputeClient) ListShapes(_ context.Context, _ core.ListShapesRequest) (core.ListShapesResponse, error) {
@@ -88,6 +179,16 @@ func (m *mockComputeClient) ListImages(_ context.Context, _ core.ListImagesReque
 	return core.ListImagesResponse{Items: m.images}, nil
 }
 
+func (m *mockComputeClient) CreateComputeCapacityReport(_ context.Context, req core.CreateComputeCapacityReportRequest) (core.CreateComputeCapacityReportResponse, error) {
+	var items []core.CapacityReportShapeAvailability
+	for _, a := range req.ShapeAvailabilities {
+		item := m.capacity[*a.FaultDomain]
+		item.FaultDomain = a.FaultDomain
+		items = append(items, item)
+	}
+	return core.CreateComputeCapacityReportResponse{ComputeCapacityReport: core.ComputeCapacityReport{ShapeAvailabilities: items}}, nil
+}
+
 // --- Mock VirtualNetwork Client ---
 
 type mockVirtualNetworkClient struct {
@@ -150,8 +251,14 @@ func (m *mockVirtualNetworkClient) ListNatGateways(_ context.Context, _ core.Lis
 // --- Mock Blockstorage Client ---
 
 type mockBlockstorageClient struct {
-	volumes   []core.Volume
-	volumeErr error
+	volumes         []core.Volume
+	volumeErr       error
+	bootVolumes     []core.BootVolume
+	bootVolumeErr   error
+	backupPolicies  []core.VolumeBackupPolicy
+	backupPolicyErr error
+	volumeGroups    []core.VolumeGroup
+	volumeGroupErr  error
 }
 
 func (m *mockBlockstorageClient) ListVolumes(_ context.Context, _ core.ListVolumesRequest) (core.ListVolumesResponse, error) {
@@ -161,6 +268,27 @@ func (m *mockBlockstorageClient) ListVolumes(_ context.Context, _ core.ListVolum
 	return core.ListVolumesResponse{Items: m.volumes}, nil
 }
 
+func (m *mockBlockstorageClient) ListBootVolumes(_ context.Context, _ core.ListBootVolumesRequest) (core.ListBootVolumesResponse, error) {
+	if m.bootVolumeErr != nil {
+		return core.ListBootVolumesResponse{}, m.bootVolumeErr
+	}
+	return core.ListBootVolumesResponse{Items: m.bootVolumes}, nil
+}
+
+func (m *mockBlockstorageClient) ListVolumeBackupPolicies(_ context.Context, _ core.ListVolumeBackupPoliciesRequest) (core.ListVolumeBackupPoliciesResponse, error) {
+	if m.backupPolicyErr != nil {
+		return core.ListVolumeBackupPoliciesResponse{}, m.backupPolicyErr
+	}
+	return core.ListVolumeBackupPoliciesResponse{Items: m.backupPolicies}, nil
+}
+
+func (m *mockBlockstorageClient) ListVolumeGroups(_ context.Context, _ core.ListVolumeGroupsRequest) (core.ListVolumeGroupsResponse, error) {
+	if m.volumeGroupErr != nil {
+		return core.ListVolumeGroupsResponse{}, m.volumeGroupErr
+	}
+	return core.ListVolumeGroupsResponse{Items: m.volumeGroups}, nil
+}
+
 // --- Mock Limits Client ---
 
 type mockLimitsClient struct {
@@ -178,8 +306,11 @@ func (m *mockLimitsClient) ListLimitValues(_ context.Context, _ lim.ListLimitVal
 // --- Mock ContainerEngine Client ---
 
 type mockContainerEngineClient struct {
-	sources []containerengine.NodeSourceOption
-	ceErr   error
+	sources    []containerengine.NodeSourceOption
+	ceErr      error
+	clusters   []containerengine.ClusterSummary
+	clusterErr error
+	nodePools  []containerengine.NodePoolSummary
 }
 
 func (m *mockContainerEngineClient) GetNodePoolOptions(_ context.Context, _ containerengine.GetNodePoolOptionsRequest) (containerengine.GetNodePoolOptionsResponse, error) {
@@ -193,6 +324,134 @@ func (m *mockContainerEngineClient) GetNodePoolOptions(_ context.Context, _ cont
 	}, nil
 }
 
+func (m *mockContainerEngineClient) ListClusters(_ context.Context, _ containerengine.ListClustersRequest) (containerengine.ListClustersResponse, error) {
+	if m.clusterErr != nil {
+		return containerengine.ListClustersResponse{}, m.clusterErr
+	}
+	return containerengine.ListClustersResponse{
+		Items: m.clusters,
+	}, nil
+}
+
+func (m *mockContainerEngineClient) ListNodePools(_ context.Context, req containerengine.ListNodePoolsRequest) (containerengine.ListNodePoolsResponse, error) {
+	var items []containerengine.NodePoolSummary
+	for _, np := range m.nodePools {
+		if *np.ClusterId == *req.ClusterId {
+			items = append(items, np)
+		}
+	}
+	return containerengine.ListNodePoolsResponse{
+		Items: items,
+	}, nil
+}
+
+// --- Mock Registry and Functions Clients ---
+
+type mockArtifactsClient struct {
+	repos []artifacts.ContainerRepositorySummary
+}
+
+func (m *mockArtifactsClient) ListContainerRepositories(_ context.Context, _ artifacts.ListContainerRepositoriesRequest) (artifacts.ListContainerRepositoriesResponse, error) {
+	return artifacts.ListContainerRepositoriesResponse{
+		ContainerRepositoryCollection: artifacts.ContainerRepositoryCollection{Items: m.repos},
+	}, nil
+}
+
+type mockObjectStorageClient struct {
+	namespace string
+}
+
+func (m *mockObjectStorageClient) GetNamespace(_ context.Context, _ objectstorage.GetNamespaceRequest) (objectstorage.GetNamespaceResponse, error) {
+	return objectstorage.GetNamespaceResponse{Value: strPtr(m.namespace)}, nil
+}
+
+type mockFunctionsClient struct {
+	apps []functions.ApplicationSummary
+}
+
+func (m *mockFunctionsClient) ListApplications(_ context.Context, _ functions.ListApplicationsRequest) (functions.ListApplicationsResponse, error) {
+	return functions.ListApplicationsResponse{Items: m.apps}, nil
+}
+
+type mockDNSClient struct {
+	zones     []dns.ZoneSummary
+	resolvers []dns.ResolverSummary
+}
+
+func (m *mockDNSClient) ListZones(_ context.Context, req dns.ListZonesRequest) (dns.ListZonesResponse, error) {
+	var items []dns.ZoneSummary
+	for _, z := range m.zones {
+		if string(z.Scope) == string(req.Scope) {
+			items = append(items, z)
+		}
+	}
+	return dns.ListZonesResponse{Items: items}, nil
+}
+
+func (m *mockDNSClient) ListViews(_ context.Context, _ dns.ListViewsRequest) (dns.ListViewsResponse, error) {
+	return dns.ListViewsResponse{}, nil
+}
+
+func (m *mockDNSClient) ListResolvers(_ context.Context, _ dns.ListResolversRequest) (dns.ListResolversResponse, error) {
+	return dns.ListResolversResponse{Items: m.resolvers}, nil
+}
+
+func (m *mockDNSClient) ListResolverEndpoints(_ context.Context, _ dns.ListResolverEndpointsRequest) (dns.ListResolverEndpointsResponse, error) {
+	return dns.ListResolverEndpointsResponse{}, nil
+}
+
+// --- Mock Observability Clients ---
+
+type mockLoggingClient struct {
+	groups []logging.LogGroupSummary
+}
+
+func (m *mockLoggingClient) ListLogGroups(_ context.Context, _ logging.ListLogGroupsRequest) (logging.ListLogGroupsResponse, error) {
+	return logging.ListLogGroupsResponse{Items: m.groups}, nil
+}
+
+type mockNotificationClient struct {
+	topics []ons.NotificationTopicSummary
+}
+
+func (m *mockNotificationClient) ListTopics(_ context.Context, _ ons.ListTopicsRequest) (ons.ListTopicsResponse, error) {
+	return ons.ListTopicsResponse{Items: m.topics}, nil
+}
+
+type mockMonitoringClient struct {
+	alarms []monitoring.AlarmSummary
+}
+
+func (m *mockMonitoringClient) ListAlarms(_ context.Context, _ monitoring.ListAlarmsRequest) (monitoring.ListAlarmsResponse, error) {
+	return monitoring.ListAlarmsResponse{Items: m.alarms}, nil
+}
+
+// --- Mock Budget and Quotas Clients ---
+
+type mockBudgetClient struct {
+	budgets []budget.BudgetSummary
+}
+
+func (m *mockBudgetClient) ListBudgets(_ context.Context, _ budget.ListBudgetsRequest) (budget.ListBudgetsResponse, error) {
+	return budget.ListBudgetsResponse{Items: m.budgets}, nil
+}
+
+func (m *mockBudgetClient) ListAlertRules(_ context.Context, _ budget.ListAlertRulesRequest) (budget.ListAlertRulesResponse, error) {
+	return budget.ListAlertRulesResponse{}, nil
+}
+
+type mockQuotasClient struct {
+	quotas []lim.QuotaSummary
+}
+
+func (m *mockQuotasClient) ListQuotas(_ context.Context, _ lim.ListQuotasRequest) (lim.ListQuotasResponse, error) {
+	return lim.ListQuotasResponse{Items: m.quotas}, nil
+}
+
+func (m *mockQuotasClient) GetQuota(_ context.Context, req lim.GetQuotaRequest) (lim.GetQuotaResponse, error) {
+	return lim.GetQuotaResponse{Quota: lim.Quota{Id: req.QuotaId}}, nil
+}
+
 // Compile-time interface satisfaction checks.
 var (
 	_ discovery.IdentityAPI        = (*mockIdentityClient)(nil)
@@ -201,6 +460,15 @@ var (
 	_ discovery.BlockstorageAPI    = (*mockBlockstorageClient)(nil)
 	_ discovery.LimitsAPI          = (*mockLimitsClient)(nil)
 	_ discovery.ContainerEngineAPI = (*mockContainerEngineClient)(nil)
+	_ discovery.ArtifactsAPI       = (*mockArtifactsClient)(nil)
+	_ discovery.ObjectStorageAPI   = (*mockObjectStorageClient)(nil)
+	_ discovery.FunctionsAPI       = (*mockFunctionsClient)(nil)
+	_ discovery.DNSAPI             = (*mockDNSClient)(nil)
+	_ discovery.LoggingAPI         = (*mockLoggingClient)(nil)
+	_ discovery.NotificationAPI    = (*mockNotificationClient)(nil)
+	_ discovery.MonitoringAPI      = (*mockMonitoringClient)(nil)
+	_ discovery.BudgetAPI          = (*mockBudgetClient)(nil)
+	_ discovery.QuotasAPI          = (*mockQuotasClient)(nil)
 )
 
 // --- Client builders ---
@@ -225,6 +493,10 @@ func buildStandardClients() *discovery.Clients {
 				Id: strPtr("tenancy-1"), Name: strPtr("test-tenancy"),
 				Description: strPtr("Test"), HomeRegionKey: strPtr("IAD"),
 			},
+			regions: []identity.Region{
+				{Key: strPtr("IAD"), Name: strPtr("us-ashburn-1")},
+				{Key: strPtr("PHX"), Name: strPtr("us-phoenix-1")},
+			},
 		},
 		Compute: &mockComputeClient{
 			shapes: []core.Shape{
@@ -280,6 +552,48 @@ func buildStandardClients() *discovery.Clients {
 				},
 			},
 		},
+		Artifacts: &mockArtifactsClient{
+			repos: []artifacts.ContainerRepositorySummary{
+				{Id: strPtr("repo-1"), DisplayName: strPtr("team/api"), CompartmentId: strPtr("tenancy-1"), IsPublic: boolPtr(false), ImageCount: common.Int(3)},
+			},
+		},
+		ObjectStorage: &mockObjectStorageClient{namespace: "testns"},
+		Functions: &mockFunctionsClient{
+			apps: []functions.ApplicationSummary{
+				{Id: strPtr("fnapp-1"), DisplayName: strPtr("billing"), CompartmentId: strPtr("tenancy-1"), SubnetIds: []string{"sub-priv"}, Shape: functions.ApplicationSummaryShapeX86},
+			},
+		},
+		DNS: &mockDNSClient{
+			zones: []dns.ZoneSummary{
+				{Id: strPtr("zone-1"), Name: strPtr("example.com"), CompartmentId: strPtr("tenancy-1"), ZoneType: dns.ZoneSummaryZoneTypePrimary, Scope: dns.ScopeGlobal},
+			},
+			resolvers: []dns.ResolverSummary{
+				{Id: strPtr("resolver-1"), DisplayName: strPtr("main-vcn"), CompartmentId: strPtr("tenancy-1"), AttachedVcnId: strPtr("vcn-1")},
+			},
+		},
+		Logging: &mockLoggingClient{
+			groups: []logging.LogGroupSummary{
+				{Id: strPtr("lg-1"), DisplayName: strPtr("app-logs"), CompartmentId: strPtr("tenancy-1")},
+			},
+		},
+		Notifications: &mockNotificationClient{
+			topics: []ons.NotificationTopicSummary{
+				{TopicId: strPtr("topic-1"), Name: strPtr("ops-alerts"), CompartmentId: strPtr("tenancy-1")},
+			},
+		},
+		Monitoring: &mockMonitoringClient{
+			alarms: []monitoring.AlarmSummary{
+				{Id: strPtr("alarm-1"), DisplayName: strPtr("cpu-high"), Namespace: strPtr("oci_computeagent"), Severity: monitoring.AlarmSummarySeverityCritical, Destinations: []string{"topic-1"}, IsEnabled: boolPtr(true)},
+			},
+		},
+		Budget: &mockBudgetClient{
+			budgets: []budget.BudgetSummary{
+				{Id: strPtr("budget-1"), DisplayName: strPtr("monthly"), Amount: f32Ptr(100), ResetPeriod: budget.ResetPeriodMonthly, TargetType: budget.TargetTypeCompartment, Targets: []string{"tenancy-1"}},
+			},
+		},
+		Quotas: &mockQuotasClient{
+			quotas: []lim.QuotaSummary{{Id: strPtr("quota-1"), Name: strPtr("shapes"), CompartmentId: strPtr("tenancy-1")}},
+		},
 	}
 }
 
@@ -302,8 +616,16 @@ func buildAlwaysFreeClients() *discovery.Clients {
 				Id: strPtr("tenancy-1"), Name: strPtr("test-tenancy"),
 				Description: strPtr("Test"), HomeRegionKey: strPtr("IAD"),
 			},
+			regions: []identity.Region{
+				{Key: strPtr("IAD"), Name: strPtr("us-ashburn-1")},
+				{Key: strPtr("PHX"), Name: strPtr("us-phoenix-1")},
+			},
 		},
 		Compute: &mockComputeClient{
+			capacity: map[string]core.CapacityReportShapeAvailability{
+				"FAULT-DOMAIN-1": {AvailabilityStatus: core.CapacityReportShapeAvailabilityAvailabilityStatusOutOfHostCapacity},
+				"FAULT-DOMAIN-2": {AvailabilityStatus: core.CapacityReportShapeAvailabilityAvailabilityStatusAvailable, AvailableCount: intPtr(2)},
+			},
 			shapes: []core.Shape{
 				{Shape: strPtr("VM.Standard.A1.Flex"), Ocpus: f32Ptr(4), MemoryInGBs: f32Ptr(24), OcpuOptions: &core.ShapeOcpuOptions{Max: f32Ptr(80)}, MemoryOptions: &core.ShapeMemoryOptions{MaxInGBs: f32Ptr(512)}},
 				{Shape: strPtr("VM.Standard.E2.1.Micro"), Ocpus: f32Ptr(1), MemoryInGBs: f32Ptr(1)},
@@ -337,6 +659,15 @@ func buildAlwaysFreeClients() *discovery.Clients {
 				},
 			},
 		},
+		Artifacts:     &mockArtifactsClient{},
+		ObjectStorage: &mockObjectStorageClient{namespace: "testns"},
+		Functions:     &mockFunctionsClient{},
+		DNS:           &mockDNSClient{},
+		Logging:       &mockLoggingClient{},
+		Notifications: &mockNotificationClient{},
+		Monitoring:    &mockMonitoringClient{},
+		Budget:        &mockBudgetClient{},
+		Quotas:        &mockQuotasClient{},
 	}
 }
 
@@ -367,7 +698,7 @@ func TestFullPipeline(t *testing.T) {
 	}
 
 	clients := buildStandardClients()
-	result, err := discovery.RunWithClients(dctx, clients)
+	result, err := discovery.RunWithClients(context.Background(), dctx, clients)
 	if err != nil {
 		t.Fatalf("RunWithClients failed: %v", err)
 	}
@@ -437,6 +768,24 @@ func TestFullPipeline(t *testing.T) {
 		t.Errorf("expected 2 OKE images, got %d", len(result.OKEImages))
 	}
 
+	if result.Registry == nil {
+		t.Error("expected container registry to be discovered")
+	} else if got := result.Registry.URL(); got != "iad.ocir.io/testns" {
+		t.Errorf("expected registry URL iad.ocir.io/testns, got %q", got)
+	}
+	if len(result.FunctionsApplications) != 1 {
+		t.Errorf("expected 1 Functions application, got %d", len(result.FunctionsApplications))
+	}
+	if len(result.DNSZones) != 1 || len(result.DNSResolvers) != 1 {
+		t.Errorf("expected 1 DNS zone and 1 resolver, got %d and %d", len(result.DNSZones), len(result.DNSResolvers))
+	}
+	if len(result.LogGroups) != 1 || len(result.NotificationTopics) != 1 || len(result.Alarms) != 1 {
+		t.Errorf("expected 1 log group, topic and alarm, got %d, %d and %d", len(result.LogGroups), len(result.NotificationTopics), len(result.Alarms))
+	}
+	if len(result.Budgets) != 1 || len(result.Quotas) != 1 {
+		t.Errorf("expected 1 budget and 1 quota, got %d and %d", len(result.Budgets), len(result.Quotas))
+	}
+
 	// --- Phase 3: Render Terraform ---
 
 	tmpDir, err := os.MkdirTemp("", "pipeline-test-standard-*")
@@ -452,7 +801,7 @@ func TestFullPipeline(t *testing.T) {
 
 	// --- Phase 4: Verify .tf files exist ---
 
-	expectedFiles := []string{"provider.tf", "locals.tf", "data.tf", "instance_example.tf"}
+	expectedFiles := []string{"provider.tf", "locals.tf", "data.tf", "instance_example.tf", "cost_estimate.md"}
 	for _, fname := range expectedFiles {
 		path := filepath.Join(tmpDir, fname)
 		if _, err := os.Stat(path); os.IsNotExist(err) {
@@ -498,6 +847,15 @@ func TestFullPipeline(t *testing.T) {
 		t.Error("instance_example.tf should reference the public subnet")
 	}
 
+	// Verify functions_example.tf binds the application to the private subnet
+	fnContent, err := os.ReadFile(filepath.Join(tmpDir, "functions_example.tf"))
+	if err != nil {
+		t.Fatalf("failed to read functions_example.tf: %v", err)
+	}
+	if !strings.Contains(string(fnContent), "subnet_ids     = [local.subnet_private_subnet]") {
+		t.Error("functions_example.tf should reference the private subnet")
+	}
+
 	// Verify data.tf contains OKE data source
 	dataContent, err := os.ReadFile(filepath.Join(tmpDir, "data.tf"))
 	if err != nil {
@@ -551,7 +909,7 @@ func TestFullPipelineAlwaysFree(t *testing.T) {
 	}
 
 	clients := buildAlwaysFreeClients()
-	result, err := discovery.RunWithClients(dctx, clients)
+	result, err := discovery.RunWithClients(context.Background(), dctx, clients)
 	if err != nil {
 		t.Fatalf("RunWithClients failed: %v", err)
 	}
@@ -573,6 +931,14 @@ func TestFullPipelineAlwaysFree(t *testing.T) {
 		t.Errorf("expected 0 VCNs, got %d", len(result.VCNs))
 	}
 
+	// AlwaysFree checks A1.Flex capacity and picks the fault domain with room
+	if result.A1Capacity == nil {
+		t.Fatal("expected an A1.Flex capacity report in always-free mode")
+	}
+	if result.A1Capacity.SelectedAD != "GqIf:US-ASHBURN-AD-1" || result.A1Capacity.SelectedFaultDomain != "FAULT-DOMAIN-2" {
+		t.Errorf("expected AD-1 FAULT-DOMAIN-2, got %q %q", result.A1Capacity.SelectedAD, result.A1Capacity.SelectedFaultDomain)
+	}
+
 	// AlwaysFree triggers OKE discovery
 	if len(result.OKEImages) != 2 {
 		t.Errorf("expected 2 OKE images (AlwaysFree triggers OKE discovery), got %d", len(result.OKEImages))
@@ -658,6 +1024,9 @@ func TestFullPipelineAlwaysFree(t *testing.T) {
 	if !strings.Contains(instanceStr, "VM.Standard.A1.Flex") {
 		t.Error("instance_example.tf should use A1.Flex shape")
 	}
+	if !strings.Contains(instanceStr, `fault_domain        = "FAULT-DOMAIN-2"`) {
+		t.Error("instance_example.tf should place the instance in the fault domain with capacity")
+	}
 	// instance_example.tf should reference the bootstrap subnet from network.tf
 	if !strings.Contains(instanceStr, "oci_core_subnet.public.id") {
 		t.Error("instance_example.tf should reference oci_core_subnet.public.id (from generated network.tf)")
@@ -681,3 +1050,366 @@ func TestFullPipelineAlwaysFree(t *testing.T) {
 
 	t.Logf("tofu validate (always-free) passed: %s", strings.TrimSpace(string(valOut)))
 }
+
+func TestRunWithClientsTagFilters(t *testing.T) {
+	clients := buildStandardClients()
+	team := map[string]map[string]interface{}{"Operations": {"Team": "platform"}}
+	identityMock := clients.Identity.(*mockIdentityClient)
+	identityMock.compartments[0].DefinedTags = team
+	bsMock := clients.Blockstorage.(*mockBlockstorageClient)
+	bsMock.volumes = append(bsMock.volumes, core.Volume{Id: strPtr("vol-2"), DisplayName: strPtr("team-data"), DefinedTags: team})
+
+	dctx := &discovery.Context{
+		TenancyID:      "tenancy-1",
+		Region:         "us-ashburn-1",
+		CompartmentID:  "tenancy-1",
+		ProgressWriter: io.Discard,
+		TagFilters:     []discovery.TagFilter{{Namespace: "Operations", Key: "Team", Value: "platform"}},
+	}
+	result, err := discovery.RunWithClients(context.Background(), dctx, clients)
+	if err != nil {
+		t.Fatalf("RunWithClients failed: %v", err)
+	}
+
+	if len(result.Compartments) != 1 || result.Compartments[0].ID != "comp-net" {
+		t.Errorf("expected only the tagged compartment, got %+v", result.Compartments)
+	}
+	if len(result.BlockVolumes) != 1 || result.BlockVolumes[0].ID != "vol-2" {
+		t.Errorf("expected only the tagged volume, got %+v", result.BlockVolumes)
+	}
+	if len(result.VCNs) != 0 {
+		t.Errorf("untagged VCNs should be filtered out, got %d", len(result.VCNs))
+	}
+	// Untaggable resources are never filtered
+	if len(result.Shapes) != 3 || len(result.Images) == 0 || len(result.AvailabilityDomains) != 1 {
+		t.Errorf("shapes, platform images and ADs should not be filtered: %d shapes, %d images, %d ADs",
+			len(result.Shapes), len(result.Images), len(result.AvailabilityDomains))
+	}
+}
+
+// throttledError is a 429 from the OCI API.
+type throttledError struct{}
+
+func (throttledError) GetHTTPStatusCode() int  { return 429 }
+func (throttledError) GetCode() string         { return "TooManyRequests" }
+func (throttledError) GetMessage() string      { return "too many requests" }
+func (throttledError) Error() string           { return "too many requests" }
+func (throttledError) GetOpcRequestID() string { return "test-request-id" }
+
+// throttledCompute fails the first n ListImages calls with a 429.
+type throttledCompute struct {
+	discovery.ComputeAPI
+	n int
+}
+
+func (c *throttledCompute) ListImages(ctx context.Context, req core.ListImagesRequest) (core.ListImagesResponse, error) {
+	if c.n > 0 {
+		c.n--
+		return core.ListImagesResponse{}, throttledError{}
+	}
+	return c.ComputeAPI.ListImages(ctx, req)
+}
+
+// lockedBuffer is a progress writer safe for the concurrent discoverers.
+type lockedBuffer struct {
+	mu sync.Mutex
+	b  strings.Builder
+}
+
+func (l *lockedBuffer) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.b.Write(p)
+}
+
+func (l *lockedBuffer) String() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.b.String()
+}
+
+func TestRunWithClientsRetries(t *testing.T) {
+	clients := buildStandardClients()
+	clients.Compute = &throttledCompute{ComputeAPI: clients.Compute, n: 2}
+
+	var progress lockedBuffer
+	dctx := &discovery.Context{
+		TenancyID:      "tenancy-1",
+		Region:         "us-ashburn-1",
+		CompartmentID:  "tenancy-1",
+		ProgressWriter: &progress,
+		Retry:          discovery.RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond},
+	}
+	result, err := discovery.RunWithClients(context.Background(), dctx, clients)
+	if err != nil {
+		t.Fatalf("RunWithClients should recover from throttling: %v", err)
+	}
+	if len(result.Images) == 0 {
+		t.Error("expected images after retrying ListImages")
+	}
+	if !strings.Contains(progress.String(), "retries: 2") {
+		t.Errorf("expected the retry count in the run summary, got:\n%s", progress.String())
+	}
+
+	// Without retries the throttled operating system's images are skipped
+	clients = buildStandardClients()
+	clients.Compute = &throttledCompute{ComputeAPI: clients.Compute, n: 1}
+	dctx.Retry = discovery.RetryPolicy{}
+	dctx.ProgressWriter = io.Discard
+	unretried, err := discovery.RunWithClients(context.Background(), dctx, clients)
+	if err != nil {
+		t.Fatalf("RunWithClients failed: %v", err)
+	}
+	if len(unretried.Images) >= len(result.Images) {
+		t.Errorf("expected fewer images without retries, got %d and %d", len(unretried.Images), len(result.Images))
+	}
+}
+
+func TestRunWithClientsProgress(t *testing.T) {
+	clients := buildStandardClients()
+	clients.Compute = &throttledCompute{ComputeAPI: clients.Compute, n: 2}
+
+	var text lockedBuffer
+	var events []progress.Event
+	dctx := &discovery.Context{
+		TenancyID:      "tenancy-1",
+		Region:         "us-ashburn-1",
+		CompartmentID:  "tenancy-1",
+		ProgressWriter: &text,
+		Progress:       func(e progress.Event) { events = append(events, e) },
+		Retry:          discovery.RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond},
+	}
+	result, err := discovery.RunWithClients(context.Background(), dctx, clients)
+	if err != nil {
+		t.Fatalf("RunWithClients failed: %v", err)
+	}
+
+	started := make(map[string]bool)
+	finished := make(map[string]progress.Event)
+	var retries []progress.Event
+	var done *progress.Summary
+	for i, e := range events {
+		if e.Time.IsZero() {
+			t.Errorf("expected every event to be timestamped, got %+v", e)
+		}
+		switch e.Kind {
+		case progress.Started:
+			started[e.Discoverer] = true
+		case progress.Finished:
+			if !started[e.Discoverer] {
+				t.Errorf("%s finished before it started", e.Discoverer)
+			}
+			finished[e.Discoverer] = e
+		case progress.Retry:
+			retries = append(retries, e)
+		case progress.Done:
+			if i != len(events)-1 {
+				t.Errorf("expected done to be the last event, got it at %d of %d", i, len(events))
+			}
+			done = e.Summary
+		}
+	}
+	if len(started) == 0 || len(finished) != len(started) {
+		t.Errorf("expected every started discoverer to finish, got %d started and %d finished", len(started), len(finished))
+	}
+	if images := finished["images"]; images.Count != len(result.Images) || images.Err != nil || images.Label != "Images" {
+		t.Errorf("expected images to finish with %d images, got %+v", len(result.Images), images)
+	}
+	if vcns := finished["vcns"]; vcns.Count != len(result.VCNs) {
+		t.Errorf("expected vcns to finish with %d VCNs, got %d", len(result.VCNs), vcns.Count)
+	}
+	if len(retries) != 2 || retries[0].Discoverer != "images" || retries[0].Service != "compute" || retries[1].Attempt != 2 {
+		t.Errorf("expected two compute retries attributed to images, got %+v", retries)
+	}
+
+	if done == nil || done.Retries != 2 || len(done.Services) != 1 || done.Services[0].Service != "compute" || done.Cache != nil {
+		t.Errorf("expected a done event with two compute retries and no cache, got %+v", done)
+	}
+
+	// The handler replaces all text output
+	if text.String() != "" {
+		t.Errorf("expected nothing in the progress writer, got:\n%s", text.String())
+	}
+}
+
+// hangingCompute never answers ListImages until the caller gives up.
+type hangingCompute struct {
+	discovery.ComputeAPI
+}
+
+func (c *hangingCompute) ListImages(ctx context.Context, req core.ListImagesRequest) (core.ListImagesResponse, error) {
+	<-ctx.Done()
+	return core.ListImagesResponse{}, ctx.Err()
+}
+
+func TestRunWithClientsTimeout(t *testing.T) {
+	clients := buildStandardClients()
+	clients.Compute = &hangingCompute{ComputeAPI: clients.Compute}
+
+	dctx := &discovery.Context{
+		TenancyID:      "tenancy-1",
+		Region:         "us-ashburn-1",
+		CompartmentID:  "tenancy-1",
+		ProgressWriter: &lockedBuffer{},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	result, err := discovery.RunWithClients(ctx, dctx, clients)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline to end discovery, got %v", err)
+	}
+	if result == nil {
+		t.Fatal("expected the partial result alongside the error")
+	}
+	if len(result.VCNs) == 0 || len(result.Compartments) == 0 {
+		t.Error("expected resources discovered before the deadline in the partial result")
+	}
+	if len(result.Images) != 0 {
+		t.Errorf("expected no images from the hanging ListImages, got %d", len(result.Images))
+	}
+}
+
+func TestRunWithClientsRequestTimeout(t *testing.T) {
+	clients := buildStandardClients()
+	clients.Compute = &hangingCompute{ComputeAPI: clients.Compute}
+
+	// Each hanging request times out and is retried, then its discoverer
+	// gives up; the run as a whole completes.
+	var progress lockedBuffer
+	dctx := &discovery.Context{
+		TenancyID:      "tenancy-1",
+		Region:         "us-ashburn-1",
+		CompartmentID:  "tenancy-1",
+		ProgressWriter: &progress,
+		Retry:          discovery.RetryPolicy{MaxRetries: 1, RequestTimeout: 10 * time.Millisecond},
+	}
+	result, err := discovery.RunWithClients(context.Background(), dctx, clients)
+	if err != nil {
+		t.Fatalf("request timeouts should not fail the run: %v", err)
+	}
+	if len(result.VCNs) == 0 {
+		t.Error("expected other discoverers to complete")
+	}
+	if len(result.Images) != 0 {
+		t.Errorf("expected no images from the hanging ListImages, got %d", len(result.Images))
+	}
+	if !strings.Contains(progress.String(), "retries, ") || !strings.Contains(progress.String(), "compute") {
+		t.Errorf("expected the timed-out compute calls to be retried, got:\n%s", progress.String())
+	}
+}
+
+func TestRunWithClientsCache(t *testing.T) {
+	cache := &discovery.Cache{Dir: t.TempDir(), TTL: time.Hour}
+	run := func(alwaysFree bool) (*discovery.Result, string) {
+		t.Helper()
+		var progress lockedBuffer
+		dctx := &discovery.Context{
+			TenancyID:      "tenancy-1",
+			Region:         "us-ashburn-1",
+			CompartmentID:  "tenancy-1",
+			OKE:            true,
+			AlwaysFree:     alwaysFree,
+			ProgressWriter: &progress,
+			Cache:          cache,
+		}
+		result, err := discovery.RunWithClients(context.Background(), dctx, buildStandardClients())
+		if err != nil {
+			t.Fatalf("RunWithClients failed: %v", err)
+		}
+		return result, progress.String()
+	}
+
+	first, progress := run(false)
+	if !strings.Contains(progress, "Cache: 0 of ") {
+		t.Errorf("expected every discoverer to miss an empty cache, got:\n%s", progress)
+	}
+
+	second, progress := run(false)
+	if !strings.Contains(progress, "API calls: 0,") {
+		t.Errorf("expected a warm cache to make no API calls, got:\n%s", progress)
+	}
+	want, _ := json.Marshal(first)
+	got, _ := json.Marshal(second)
+	if string(got) != string(want) {
+		t.Errorf("cached result differs from the live one:\n got %s\nwant %s", got, want)
+	}
+
+	// Switching to always-free reuses the cache; only the capacity report is live
+	free, progress := run(true)
+	if !strings.Contains(progress, "API calls: 1,") {
+		t.Errorf("expected only the capacity report to be fetched, got:\n%s", progress)
+	}
+	if len(free.Shapes) >= len(first.Shapes) {
+		t.Errorf("expected always-free filtering of cached shapes, got %d of %d", len(free.Shapes), len(first.Shapes))
+	}
+}
+
+// bucketDiscoverer is a discoverer registered from outside the discovery
+// package, storing its output as a Result extension.
+type bucketDiscoverer struct{}
+
+func (bucketDiscoverer) Name() string                         { return "buckets" }
+func (bucketDiscoverer) Label() string                        { return "Buckets" }
+func (bucketDiscoverer) Services() []string                   { return []string{"objectstorage"} }
+func (bucketDiscoverer) Fatal() bool                          { return false }
+func (bucketDiscoverer) Enabled(dctx *discovery.Context) bool { return true }
+
+func (bucketDiscoverer) Discover(ctx context.Context, env *discovery.Env) (func(*discovery.Result), error) {
+	ns, err := env.Clients.ObjectStorage.GetNamespace(ctx, objectstorage.GetNamespaceRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return func(r *discovery.Result) {
+		r.Extensions = map[string]any{"buckets": map[string]string{"namespace": *ns.Value}}
+	}, nil
+}
+
+func TestRunWithClientsDiscoverers(t *testing.T) {
+	var progress lockedBuffer
+	dctx := &discovery.Context{
+		TenancyID:      "tenancy-1",
+		Region:         "us-ashburn-1",
+		CompartmentID:  "tenancy-1",
+		ProgressWriter: &progress,
+		Discoverers:    append(discovery.Discoverers(), bucketDiscoverer{}),
+	}
+	clients := buildStandardClients()
+	result, err := discovery.RunWithClients(context.Background(), dctx, clients)
+	if err != nil {
+		t.Fatalf("RunWithClients failed: %v", err)
+	}
+	// AD-scoped discoverers share one listing of the availability domains
+	if n := clients.Identity.(*mockIdentityClient).adCalls.Load(); n != 1 {
+		t.Errorf("expected availability domains to be listed once, got %d calls", n)
+	}
+	if _, ok := result.Extensions["buckets"]; !ok {
+		t.Errorf("expected the extension discoverer's output, got %v", result.Extensions)
+	}
+	if !strings.Contains(progress.String(), "→ Buckets") {
+		t.Errorf("expected the extension discoverer in the progress output, got:\n%s", progress.String())
+	}
+
+	// A subset of discoverers needs only their clients
+	var vcns []discovery.Discoverer
+	for _, d := range discovery.Discoverers() {
+		if d.Name() == "vcns" {
+			vcns = append(vcns, d)
+		}
+	}
+	dctx.Discoverers = vcns
+	dctx.ProgressWriter = io.Discard
+	result, err = discovery.RunWithClients(context.Background(), dctx, &discovery.Clients{VirtualNetwork: buildStandardClients().VirtualNetwork})
+	if err != nil {
+		t.Fatalf("RunWithClients with only VCN discovery failed: %v", err)
+	}
+	if len(result.VCNs) == 0 || len(result.Shapes) != 0 {
+		t.Errorf("expected only VCNs, got %d VCNs and %d shapes", len(result.VCNs), len(result.Shapes))
+	}
+
+	// A discoverer whose client is missing is an error, not a panic
+	dctx.Discoverers = append(vcns, bucketDiscoverer{})
+	if _, err := discovery.RunWithClients(context.Background(), dctx, &discovery.Clients{VirtualNetwork: buildStandardClients().VirtualNetwork}); err == nil || !strings.Contains(err.Error(), "buckets needs the objectstorage client") {
+		t.Errorf("expected a missing client error, got %v", err)
+	}
+}
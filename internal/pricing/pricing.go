@@ -0,0 +1,191 @@
+// Package pricing estimates the monthly cost of generated resources from an
+// offline price catalog. The catalog ships with the binary and can be replaced
+// with a JSON file of the same format.
+package pricing
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+//go:embed catalog.json
+var defaultCatalog []byte
+
+// Catalog holds list prices keyed by shape, OCPU, memory and storage VPUs.
+type Catalog struct {
+	Version       string                `json:"version"`  // Date the prices were taken from the OCI price list
+	Currency      string                `json:"currency"` // ISO 4217 code
+	HoursPerMonth float64               `json:"hours_per_month"`
+	Shapes        map[string]ShapePrice `json:"shapes"`
+	BlockVolume   BlockVolumePrice      `json:"block_volume"`
+	Kubernetes    KubernetesPrice       `json:"kubernetes"`
+	AlwaysFree    AlwaysFreeAllowance   `json:"always_free"`
+}
+
+// ShapePrice is the hourly price of a compute shape. Flexible shapes are
+// billed per OCPU and GB of memory; fixed shapes per instance.
+type ShapePrice struct {
+	OCPUHour     float64 `json:"ocpu_hour,omitempty"`
+	MemoryGBHour float64 `json:"memory_gb_hour,omitempty"`
+	InstanceHour float64 `json:"instance_hour,omitempty"`
+}
+
+// BlockVolumePrice is the monthly price of block and boot volume storage.
+// Performance is billed per VPU per GB on top of the storage price.
+type BlockVolumePrice struct {
+	GBMonth    float64 `json:"gb_month"`
+	VPUGBMonth float64 `json:"vpu_gb_month"`
+}
+
+// KubernetesPrice is the hourly price of OKE resources beyond their compute.
+// BASIC_CLUSTER control planes are free.
+type KubernetesPrice struct {
+	EnhancedClusterHour float64 `json:"enhanced_cluster_hour"`
+	VirtualNodeHour     float64 `json:"virtual_node_hour"`
+}
+
+// AlwaysFreeAllowance is the tenancy-wide always-free tier.
+type AlwaysFreeAllowance struct {
+	A1OCPUs        float64 `json:"a1_ocpus"`
+	A1MemoryGBs    float64 `json:"a1_memory_gbs"`
+	MicroInstances int     `json:"micro_instances"`
+	BlockVolumeGBs float64 `json:"block_volume_gbs"`
+}
+
+// Load reads a price catalog from path, or the embedded catalog when path is empty.
+func Load(path string) (*Catalog, error) {
+	data := defaultCatalog
+	if path != "" {
+		var err error
+		data, err = os.ReadFile(path) // #nosec G304 -- path is user-specified CLI flag
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var c Catalog
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("parsing price catalog: %w", err)
+	}
+	if c.Version == "" {
+		return nil, fmt.Errorf("price catalog has no version")
+	}
+	if c.HoursPerMonth <= 0 {
+		return nil, fmt.Errorf("price catalog %s: hours_per_month must be positive", c.Version)
+	}
+	return &c, nil
+}
+
+// Default returns the embedded price catalog.
+func Default() *Catalog {
+	c, err := Load("")
+	if err != nil {
+		panic(fmt.Sprintf("embedded price catalog: %v", err))
+	}
+	return c
+}
+
+// Compute returns the monthly price of one instance of shape. ok is false when
+// the shape is not in the catalog.
+func (c *Catalog) Compute(shape string, ocpus, memoryGBs float64) (monthly float64, ok bool) {
+	p, ok := c.Shapes[shape]
+	if !ok {
+		return 0, false
+	}
+	hourly := p.InstanceHour + ocpus*p.OCPUHour + memoryGBs*p.MemoryGBHour
+	return hourly * c.HoursPerMonth, true
+}
+
+// Volume returns the monthly price of a block or boot volume.
+func (c *Catalog) Volume(sizeGBs, vpusPerGB float64) float64 {
+	return sizeGBs * (c.BlockVolume.GBMonth + vpusPerGB*c.BlockVolume.VPUGBMonth)
+}
+
+// EnhancedCluster returns the monthly price of an ENHANCED_CLUSTER control plane.
+func (c *Catalog) EnhancedCluster() float64 {
+	return c.Kubernetes.EnhancedClusterHour * c.HoursPerMonth
+}
+
+// VirtualNodes returns the monthly price of n virtual nodes, excluding the
+// OCPU and memory their pods request.
+func (c *Catalog) VirtualNodes(n int) float64 {
+	return float64(n) * c.Kubernetes.VirtualNodeHour * c.HoursPerMonth
+}
+
+// FreeTier tracks how much of the always-free allowance remains as resources
+// are priced. Resources that fit in the remaining allowance cost nothing.
+type FreeTier struct {
+	a1OCPUs, a1MemoryGBs float64
+	micro                int
+	volumeGBs            float64
+}
+
+// NewFreeTier returns the full always-free allowance from the catalog.
+func (c *Catalog) NewFreeTier() *FreeTier {
+	a := c.AlwaysFree
+	return &FreeTier{
+		a1OCPUs:     a.A1OCPUs,
+		a1MemoryGBs: a.A1MemoryGBs,
+		micro:       a.MicroInstances,
+		volumeGBs:   a.BlockVolumeGBs,
+	}
+}
+
+// Compute claims an instance from the allowance and reports whether it fits.
+// Only A1.Flex and E2.1.Micro are always-free shapes.
+func (t *FreeTier) Compute(shape string, ocpus, memoryGBs float64) bool {
+	switch shape {
+	case "VM.Standard.A1.Flex":
+		if ocpus > t.a1OCPUs || memoryGBs > t.a1MemoryGBs {
+			return false
+		}
+		t.a1OCPUs -= ocpus
+		t.a1MemoryGBs -= memoryGBs
+		return true
+	case "VM.Standard.E2.1.Micro":
+		if t.micro == 0 {
+			return false
+		}
+		t.micro--
+		return true
+	}
+	return false
+}
+
+// Volume claims block storage from the allowance and reports whether it fits.
+// Always-free volumes use the default 10 VPUs/GB.
+func (t *FreeTier) Volume(sizeGBs, vpusPerGB float64) bool {
+	if sizeGBs > t.volumeGBs || vpusPerGB > 10 {
+		return false
+	}
+	t.volumeGBs -= sizeGBs
+	return true
+}
+
+// Item is the estimated monthly cost of one generated resource.
+type Item struct {
+	Resource    string  `json:"resource"`    // Terraform address, e.g. oci_core_instance.example
+	Description string  `json:"description"` // Shape and sizing the price is based on
+	Monthly     float64 `json:"monthly"`
+	AlwaysFree  bool    `json:"always_free"` // Covered by the always-free allowance
+	Unpriced    bool    `json:"unpriced"`    // Not in the catalog; excluded from the total
+}
+
+// Estimate is the monthly cost of the generated configuration.
+type Estimate struct {
+	CatalogVersion string  `json:"catalog_version"`
+	Currency       string  `json:"currency"`
+	Items          []Item  `json:"items"`
+	Total          float64 `json:"total"`
+}
+
+// Add appends an item and adds its cost to the total.
+func (e *Estimate) Add(item Item) {
+	if item.AlwaysFree || item.Unpriced {
+		item.Monthly = 0
+	}
+	e.Items = append(e.Items, item)
+	e.Total += item.Monthly
+}
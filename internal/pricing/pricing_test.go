@@ -0,0 +1,132 @@
+package pricing
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("embedded catalog", func(t *testing.T) {
+		c, err := Load("")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if c.Version == "" || c.Currency == "" {
+			t.Errorf("embedded catalog missing version or currency: %q %q", c.Version, c.Currency)
+		}
+		for _, shape := range []string{"VM.Standard.A1.Flex", "VM.Standard.E2.1.Micro", "VM.Standard.E4.Flex"} {
+			if _, ok := c.Shapes[shape]; !ok {
+				t.Errorf("embedded catalog missing %s", shape)
+			}
+		}
+	})
+
+	t.Run("override path", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "prices.json")
+		data := `{"version": "custom", "currency": "EUR", "hours_per_month": 730, "shapes": {"VM.Standard.E4.Flex": {"ocpu_hour": 1}}}`
+		if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+		c, err := Load(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if monthly, _ := c.Compute("VM.Standard.E4.Flex", 2, 0); !almostEqual(monthly, 1460) {
+			t.Errorf("expected 1460, got %v", monthly)
+		}
+	})
+
+	t.Run("invalid catalogs", func(t *testing.T) {
+		for name, data := range map[string]string{
+			"no version": `{"hours_per_month": 744}`,
+			"no hours":   `{"version": "x"}`,
+			"not json":   `version: x`,
+		} {
+			path := filepath.Join(t.TempDir(), "prices.json")
+			if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := Load(path); err == nil {
+				t.Errorf("%s: expected error, got nil", name)
+			}
+		}
+		if _, err := Load(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+			t.Error("missing file: expected error, got nil")
+		}
+	})
+}
+
+func TestCatalogPrices(t *testing.T) {
+	c := &Catalog{
+		HoursPerMonth: 100,
+		Shapes: map[string]ShapePrice{
+			"flex":  {OCPUHour: 0.5, MemoryGBHour: 0.01},
+			"fixed": {InstanceHour: 0.2},
+		},
+		BlockVolume: BlockVolumePrice{GBMonth: 0.02, VPUGBMonth: 0.001},
+		Kubernetes:  KubernetesPrice{EnhancedClusterHour: 0.1, VirtualNodeHour: 0.02},
+	}
+
+	if got, ok := c.Compute("flex", 2, 10); !ok || !almostEqual(got, 110) {
+		t.Errorf("flex compute: got %v, %v", got, ok)
+	}
+	if got, ok := c.Compute("fixed", 0, 0); !ok || !almostEqual(got, 20) {
+		t.Errorf("fixed compute: got %v, %v", got, ok)
+	}
+	if _, ok := c.Compute("unknown", 1, 1); ok {
+		t.Error("unknown shape should not be priced")
+	}
+	if got := c.Volume(100, 10); !almostEqual(got, 3) {
+		t.Errorf("volume: got %v", got)
+	}
+	if got := c.EnhancedCluster(); !almostEqual(got, 10) {
+		t.Errorf("enhanced cluster: got %v", got)
+	}
+	if got := c.VirtualNodes(3); !almostEqual(got, 6) {
+		t.Errorf("virtual nodes: got %v", got)
+	}
+}
+
+func TestFreeTier(t *testing.T) {
+	free := Default().NewFreeTier()
+
+	if !free.Compute("VM.Standard.A1.Flex", 2, 12) || !free.Compute("VM.Standard.A1.Flex", 2, 12) {
+		t.Error("two 2 OCPU / 12 GB A1 instances should fit the allowance")
+	}
+	if free.Compute("VM.Standard.A1.Flex", 1, 1) {
+		t.Error("a third A1 instance should exceed the allowance")
+	}
+	if !free.Compute("VM.Standard.E2.1.Micro", 0, 0) || !free.Compute("VM.Standard.E2.1.Micro", 0, 0) || free.Compute("VM.Standard.E2.1.Micro", 0, 0) {
+		t.Error("exactly two E2.1.Micro instances should be free")
+	}
+	if free.Compute("VM.Standard.E4.Flex", 1, 1) {
+		t.Error("E4.Flex is never always-free")
+	}
+
+	if free.Volume(50, 20) {
+		t.Error("higher performance volumes are not always-free")
+	}
+	if !free.Volume(150, 10) || free.Volume(100, 10) {
+		t.Error("block storage should be free up to 200 GB")
+	}
+}
+
+func TestEstimateAdd(t *testing.T) {
+	var est Estimate
+	est.Add(Item{Resource: "a", Monthly: 10})
+	est.Add(Item{Resource: "b", Monthly: 5, AlwaysFree: true})
+	est.Add(Item{Resource: "c", Monthly: 7, Unpriced: true})
+
+	if !almostEqual(est.Total, 10) {
+		t.Errorf("expected total 10, got %v", est.Total)
+	}
+	if est.Items[1].Monthly != 0 || est.Items[2].Monthly != 0 {
+		t.Error("always-free and unpriced items should cost 0")
+	}
+}
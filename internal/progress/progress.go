@@ -0,0 +1,177 @@
+// Package progress defines the events discovery reports as it runs and the
+// renderers that display them: plain text, a live terminal table and JSON
+// lines.
+package progress
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Kind is what an Event reports.
+type Kind string
+
+const (
+	Started  Kind = "started"  // A discoverer began
+	Finished Kind = "finished" // A discoverer ended, with Count and Duration, or Err when it failed
+	Warning  Kind = "warning"  // A problem left a discoverer's output incomplete
+	Retry    Kind = "retry"    // An API call failed and is tried again after Delay
+	Done     Kind = "done"     // Discovery ended, with the Summary
+)
+
+// Event is one step of discovery.
+type Event struct {
+	Kind       Kind
+	Time       time.Time
+	Discoverer string        // Discoverer name, e.g. "vcns"; empty for lookups shared between discoverers
+	Label      string        // Discoverer label for display, e.g. "VCNs"
+	Count      int           // Finished: resources stored
+	Duration   time.Duration // Finished: time since the discoverer started
+	Err        error         // Finished: why the discoverer failed. Warning, Retry: the problem
+	Service    string        // Retry: API service, e.g. "compute"
+	Attempt    int           // Retry: the retry about to be made, from 1
+	Delay      time.Duration // Retry: wait before the retry
+	Summary    *Summary      // Done: API calls and cache use of the run
+}
+
+// Summary is what a Done event reports about the whole run.
+type Summary struct {
+	Calls    int            // API calls, each counted once however often it was retried
+	Retries  int            // Additional attempts after retryable failures
+	Services []ServiceCalls // Services that needed retries or had calls rejected, by name
+	Cache    *CacheUse      // nil when the cache is disabled
+}
+
+// ServiceCalls counts the API calls made to one service.
+type ServiceCalls struct {
+	Service     string `json:"service"`
+	Calls       int    `json:"calls"`
+	Retries     int    `json:"retries"`
+	Failures    int    `json:"failures"`     // Failed after exhausting retries or with a non-retryable error
+	CircuitOpen int    `json:"circuit_open"` // Rejected by an open circuit
+}
+
+// CacheUse reports how many discoverer outputs came from the cache.
+type CacheUse struct {
+	Hits  int    `json:"hits"`
+	Total int    `json:"total"`
+	Dir   string `json:"dir"`
+}
+
+// Handler receives events. Discovery calls it for one event at a time.
+type Handler func(Event)
+
+// Text returns a handler writing one line per event, for logs and terminals
+// that cannot redraw.
+func Text(w io.Writer) Handler {
+	return func(e Event) {
+		switch e.Kind {
+		case Started:
+			fmt.Fprintf(w, "  → %s\n", e.Label)
+		case Finished:
+			if e.Err != nil {
+				fmt.Fprintf(w, "  ✗ %s failed after %s\n", e.Label, formatDuration(e.Duration))
+			} else {
+				fmt.Fprintf(w, "  ✓ %s: %d in %s\n", e.Label, e.Count, formatDuration(e.Duration))
+			}
+		case Warning:
+			fmt.Fprintf(w, "    ⚠ %v\n", e.Err)
+		case Retry:
+			fmt.Fprintf(w, "    ↻ %s retry %d in %s: %v\n", retrySubject(e), e.Attempt, formatDuration(e.Delay), e.Err)
+		case Done:
+			writeSummary(w, e.Summary)
+		}
+	}
+}
+
+// writeSummary prints the number of API calls and retries, the services that
+// needed retries, and how much came from the cache.
+func writeSummary(w io.Writer, s *Summary) {
+	if s == nil {
+		return
+	}
+	fmt.Fprintf(w, "API calls: %d, retries: %d\n", s.Calls, s.Retries)
+	for _, c := range s.Services {
+		fmt.Fprintf(w, "  %-16s %d calls, %d retries, %d failed", c.Service, c.Calls, c.Retries, c.Failures)
+		if c.CircuitOpen > 0 {
+			fmt.Fprintf(w, ", %d rejected by open circuit", c.CircuitOpen)
+		}
+		fmt.Fprintln(w)
+	}
+	if s.Cache != nil {
+		fmt.Fprintf(w, "Cache: %d of %d discoverer outputs reused from %s\n", s.Cache.Hits, s.Cache.Total, s.Cache.Dir)
+	}
+}
+
+// retrySubject names what is retried: the discoverer and service, or the
+// service alone for shared lookups.
+func retrySubject(e Event) string {
+	if e.Label == "" {
+		return e.Service
+	}
+	return e.Label + ": " + e.Service
+}
+
+// jsonEvent is the JSON-lines form of an Event. Durations are milliseconds.
+type jsonEvent struct {
+	Event      Kind      `json:"event"`
+	Time       time.Time `json:"time"`
+	Discoverer string    `json:"discoverer,omitempty"`
+	Label      string    `json:"label,omitempty"`
+	Count      *int      `json:"count,omitempty"`
+	DurationMS *int64    `json:"duration_ms,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	Service    string    `json:"service,omitempty"`
+	Attempt    int       `json:"attempt,omitempty"`
+	DelayMS    *int64    `json:"delay_ms,omitempty"`
+
+	// Done
+	Calls    *int           `json:"calls,omitempty"`
+	Retries  *int           `json:"retries,omitempty"`
+	Services []ServiceCalls `json:"services,omitempty"`
+	Cache    *CacheUse      `json:"cache,omitempty"`
+}
+
+// JSONLines returns a handler writing each event as a JSON object on its own
+// line, for CI logs and other programs.
+func JSONLines(w io.Writer) Handler {
+	enc := json.NewEncoder(w)
+	return func(e Event) {
+		out := jsonEvent{
+			Event:      e.Kind,
+			Time:       e.Time.UTC(),
+			Discoverer: e.Discoverer,
+			Label:      e.Label,
+			Service:    e.Service,
+			Attempt:    e.Attempt,
+		}
+		if e.Err != nil {
+			out.Error = e.Err.Error()
+		}
+		switch e.Kind {
+		case Finished:
+			ms := e.Duration.Milliseconds()
+			out.Count, out.DurationMS = &e.Count, &ms
+		case Retry:
+			ms := e.Delay.Milliseconds()
+			out.DelayMS = &ms
+		case Done:
+			if s := e.Summary; s != nil {
+				out.Calls, out.Retries = &s.Calls, &s.Retries
+				out.Services, out.Cache = s.Services, s.Cache
+			}
+		}
+		_ = enc.Encode(out) // Progress output is best effort
+	}
+}
+
+// formatDuration rounds d for display: milliseconds under a second, then
+// hundredths of a second.
+func formatDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(10 * time.Millisecond).String()
+}
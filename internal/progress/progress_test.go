@@ -0,0 +1,123 @@
+package progress
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var start = time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+
+// sample is a run of two discoverers: VCNs with a retry and a warning, and
+// Shapes failing, then the summary.
+func sample() []Event {
+	return []Event{
+		{Kind: Started, Time: start, Discoverer: "vcns", Label: "VCNs"},
+		{Kind: Started, Time: start, Discoverer: "shapes", Label: "Shapes"},
+		{Kind: Retry, Time: start.Add(time.Second), Discoverer: "vcns", Label: "VCNs", Service: "network", Attempt: 1, Delay: 250 * time.Millisecond, Err: errors.New("429 TooManyRequests")},
+		{Kind: Warning, Time: start.Add(2 * time.Second), Discoverer: "vcns", Label: "VCNs", Err: errors.New("listing subnets: 404 NotAuthorizedOrNotFound")},
+		{Kind: Finished, Time: start.Add(3 * time.Second), Discoverer: "vcns", Label: "VCNs", Count: 3, Duration: 3*time.Second + 141*time.Millisecond},
+		{Kind: Finished, Time: start.Add(3 * time.Second), Discoverer: "shapes", Label: "Shapes", Duration: 42 * time.Millisecond, Err: errors.New("401 NotAuthenticated")},
+		{Kind: Done, Time: start.Add(3 * time.Second), Summary: &Summary{
+			Calls:    12,
+			Retries:  1,
+			Services: []ServiceCalls{{Service: "network", Calls: 5, Retries: 1}},
+			Cache:    &CacheUse{Hits: 0, Total: 2, Dir: "/cache/3f9c"},
+		}},
+	}
+}
+
+func TestText(t *testing.T) {
+	var buf bytes.Buffer
+	h := Text(&buf)
+	for _, e := range sample() {
+		h(e)
+	}
+	want := `  → VCNs
+  → Shapes
+    ↻ VCNs: network retry 1 in 250ms: 429 TooManyRequests
+    ⚠ listing subnets: 404 NotAuthorizedOrNotFound
+  ✓ VCNs: 3 in 3.14s
+  ✗ Shapes failed after 42ms
+API calls: 12, retries: 1
+  network          5 calls, 1 retries, 0 failed
+Cache: 0 of 2 discoverer outputs reused from /cache/3f9c
+`
+	if buf.String() != want {
+		t.Errorf("unexpected text output:\ngot\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestJSONLines(t *testing.T) {
+	var buf bytes.Buffer
+	h := JSONLines(&buf)
+	for _, e := range sample() {
+		h(e)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != len(sample()) {
+		t.Fatalf("expected one line per event, got %d:\n%s", len(lines), buf.String())
+	}
+
+	var events []map[string]any
+	for _, line := range lines {
+		var e map[string]any
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("line is not JSON: %s: %v", line, err)
+		}
+		events = append(events, e)
+	}
+	if events[0]["event"] != "started" || events[0]["discoverer"] != "vcns" || events[0]["time"] != "2026-10-18T09:00:00Z" {
+		t.Errorf("unexpected started event: %s", lines[0])
+	}
+	if _, ok := events[0]["count"]; ok {
+		t.Errorf("expected no count on a started event: %s", lines[0])
+	}
+	if events[2]["service"] != "network" || events[2]["attempt"] != 1.0 || events[2]["delay_ms"] != 250.0 {
+		t.Errorf("unexpected retry event: %s", lines[2])
+	}
+	if events[4]["count"] != 3.0 || events[4]["duration_ms"] != 3141.0 {
+		t.Errorf("unexpected finished event: %s", lines[4])
+	}
+	if events[5]["count"] != 0.0 || events[5]["error"] != "401 NotAuthenticated" {
+		t.Errorf("expected a failed event with its count and error: %s", lines[5])
+	}
+	if want := `"calls":12,"retries":1,"services":[{"service":"network","calls":5,"retries":1,"failures":0,"circuit_open":0}],"cache":{"hits":0,"total":2,"dir":"/cache/3f9c"}}`; !strings.HasSuffix(lines[6], want) {
+		t.Errorf("unexpected done event:\ngot  %s\nwant ...%s", lines[6], want)
+	}
+}
+
+func TestTable(t *testing.T) {
+	var buf bytes.Buffer
+	table := NewTable(&buf)
+	for _, e := range sample() {
+		table.Handle(e)
+	}
+	table.Close()
+	table.Close()
+
+	out := buf.String()
+	if n := strings.Count(out, "⚠ listing subnets"); n != 1 {
+		t.Errorf("expected the warning to be printed once above the table, got %d times:\n%q", n, out)
+	}
+
+	// The last drawing replaces the earlier ones: the cursor moves up over
+	// the table and clears the screen below it.
+	draws := strings.Split(out, "\x1b[J")
+	last := draws[len(draws)-1]
+	if !strings.HasSuffix(draws[len(draws)-2], "\r\x1b[3A") {
+		t.Errorf("expected the previous three-line table to be erased, got %q", draws[len(draws)-2])
+	}
+	for _, want := range []string{
+		"✓ VCNs                           3     3.14s  ↻ 1  ⚠ 1\n",
+		"✗ Shapes                    failed      42ms\n",
+		"2 of 2 done, 1 retries\nAPI calls: 12, retries: 1\n",
+	} {
+		if !strings.Contains(last, want) {
+			t.Errorf("expected %q in the final table:\n%s", want, last)
+		}
+	}
+}
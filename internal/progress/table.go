@@ -0,0 +1,169 @@
+package progress
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Table renders events as a status table redrawn in place, for terminals:
+// one row per discoverer with its state, resources found, time taken and
+// retries, and a total line. Warnings are printed above the table as they
+// arrive, so they stay in the scrollback once the table is gone, and the run
+// summary below the final table.
+type Table struct {
+	mu      sync.Mutex
+	w       io.Writer
+	rows    []*tableRow
+	index   map[string]*tableRow
+	retries int
+	drawn   int // Lines of the last drawing, erased by the next
+	now     func() time.Time
+
+	stop  chan struct{}
+	done  chan struct{}
+	close sync.Once
+}
+
+type tableRow struct {
+	label    string
+	started  time.Time
+	finished bool
+	failed   bool
+	count    int
+	duration time.Duration
+	warnings int
+	retries  int
+}
+
+// redrawInterval is how often running rows' times are updated.
+const redrawInterval = 200 * time.Millisecond
+
+// NewTable returns a table drawing to w, which should be a terminal that
+// understands ANSI cursor movement. Call Close when discovery ends.
+func NewTable(w io.Writer) *Table {
+	t := &Table{
+		w:     w,
+		index: make(map[string]*tableRow),
+		now:   time.Now,
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	go t.tick()
+	return t
+}
+
+// tick redraws while discoverers are running, so their times advance.
+func (t *Table) tick() {
+	defer close(t.done)
+	ticker := time.NewTicker(redrawInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.stop:
+			return
+		case <-ticker.C:
+			t.mu.Lock()
+			if t.running() {
+				t.draw()
+			}
+			t.mu.Unlock()
+		}
+	}
+}
+
+// Close stops redrawing and leaves the table as last drawn.
+func (t *Table) Close() {
+	t.close.Do(func() {
+		close(t.stop)
+		<-t.done
+	})
+}
+
+// Handle updates the table with e and redraws it.
+func (t *Table) Handle(e Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	row := t.index[e.Discoverer]
+	var above []string
+	switch e.Kind {
+	case Started:
+		row = &tableRow{label: e.Label, started: e.Time}
+		t.rows = append(t.rows, row)
+		t.index[e.Discoverer] = row
+	case Finished:
+		if row != nil {
+			row.finished, row.failed = true, e.Err != nil
+			row.count, row.duration = e.Count, e.Duration
+		}
+	case Warning:
+		if row != nil {
+			row.warnings++
+		}
+		above = append(above, fmt.Sprintf("  ⚠ %v", e.Err))
+	case Retry:
+		if row != nil {
+			row.retries++
+		}
+		t.retries++
+	case Done:
+		// The final table stays on screen with the summary below it
+		t.draw(above...)
+		var b strings.Builder
+		writeSummary(&b, e.Summary)
+		_, _ = io.WriteString(t.w, b.String())
+		t.drawn = 0
+		return
+	}
+	t.draw(above...)
+}
+
+func (t *Table) running() bool {
+	for _, r := range t.rows {
+		if !r.finished {
+			return true
+		}
+	}
+	return false
+}
+
+// draw erases the last drawing, prints the lines above, which scroll away
+// with the rest of the output, and draws the table below them. It is written
+// in one call so other writers cannot split it. t.mu must be held.
+func (t *Table) draw(above ...string) {
+	var b strings.Builder
+	if t.drawn > 0 {
+		fmt.Fprintf(&b, "\r\x1b[%dA", t.drawn)
+	}
+	b.WriteString("\x1b[J")
+	for _, line := range above {
+		b.WriteString(line + "\n")
+	}
+
+	var finished int
+	for _, r := range t.rows {
+		mark, count, elapsed := "•", "", formatDuration(t.now().Sub(r.started))
+		if r.finished {
+			finished++
+			mark, count, elapsed = "✓", fmt.Sprint(r.count), formatDuration(r.duration)
+			if r.failed {
+				mark, count = "✗", "failed"
+			}
+		}
+		fmt.Fprintf(&b, "  %s %-24s %7s %9s", mark, r.label, count, elapsed)
+		if r.retries > 0 {
+			fmt.Fprintf(&b, "  ↻ %d", r.retries)
+		}
+		if r.warnings > 0 {
+			fmt.Fprintf(&b, "  ⚠ %d", r.warnings)
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "  %d of %d done, %d retries\n", finished, len(t.rows), t.retries)
+
+	_, _ = io.WriteString(t.w, b.String()) // Progress output is best effort
+	t.drawn = len(t.rows) + 1
+}
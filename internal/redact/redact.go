@@ -0,0 +1,290 @@
+// Package redact replaces identifying values in discovery results with
+// pseudonyms, so results and the Terraform rendered from them can be shared.
+package redact
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/larsenclose/oci-tf-bootstrap/internal/discovery"
+)
+
+// Redactor derives pseudonyms from a secret key with HMAC-SHA256. The same
+// value always gets the same pseudonym under the same key, so redacted results
+// from separate runs with one key still compare. Anyone holding the key can
+// recompute the pseudonym of a guessed value, so it must not be derivable from
+// the output, as the tenancy OCID would be. A nil Redactor changes nothing.
+type Redactor struct {
+	key []byte
+}
+
+// New returns a Redactor deriving pseudonyms from key.
+func New(key string) *Redactor {
+	return &Redactor{key: []byte(key)}
+}
+
+// DefaultKeyFile returns the key file under the user's config directory
+// (e.g. ~/.config/oci-tf-bootstrap/redact.key on Linux).
+func DefaultKeyFile() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "oci-tf-bootstrap", "redact.key"), nil
+}
+
+// LoadKey returns the key stored in path. When the file does not exist it is
+// created with a random 256-bit key, readable only by the user.
+func LoadKey(path string) (string, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is the user's key file
+	if err == nil {
+		key := strings.TrimSpace(string(data))
+		if key == "" {
+			return "", fmt.Errorf("%s is empty", path)
+		}
+		return key, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	key := hex.EncodeToString(secret)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600) // #nosec G304 -- path is the user's key file
+	if errors.Is(err, fs.ErrExist) {
+		// Created by a concurrent run
+		return LoadKey(path)
+	}
+	if err != nil {
+		return "", err
+	}
+	if _, err := fmt.Fprintln(f, key); err != nil {
+		f.Close()
+		return "", err
+	}
+	return key, f.Close()
+}
+
+var (
+	// ocid1.<type>.<realm>.[region][.future use].<unique ID>; only the unique ID is replaced
+	ocidPattern  = regexp.MustCompile(`\b(ocid1\.[a-z0-9]+\.[a-z0-9]+\.[a-z0-9-]*(?:\.[a-z0-9-]*)?\.)([a-z0-9]+)\b`)
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	ipv4Pattern  = regexp.MustCompile(`\b\d{1,3}\.\d{1,3}\.\d{1,3}\.\d{1,3}(?:/\d{1,2})?\b`)
+	// Availability domain names carry a tenancy-specific prefix, e.g. "Uocm:PHX-AD-1"
+	adPattern = regexp.MustCompile(`\b[A-Za-z]{4}:([A-Z]{2,3}-AD-[0-9]+)\b`)
+	// Compartment references in policy and quota statements, e.g. "in compartment Prod:App"
+	compartmentRefPattern = regexp.MustCompile(`(?i)(\bcompartment\s+)([A-Za-z0-9_.:-]+)`)
+)
+
+// pseudonymNet is where public IPv4 addresses are mapped: 198.18.0.0/15,
+// reserved for benchmarking and never routed.
+var pseudonymNet = netip.MustParsePrefix("198.18.0.0/15")
+
+// String replaces the identifiers that can be recognized in s on their own:
+// OCIDs, email addresses, public IPv4 addresses and availability domain
+// prefixes. Private addresses are kept. Pseudonyms are left as they are, so
+// redacting twice changes nothing.
+func (r *Redactor) String(s string) string {
+	if r == nil {
+		return s
+	}
+	s = ocidPattern.ReplaceAllStringFunc(s, func(m string) string {
+		sub := ocidPattern.FindStringSubmatch(m)
+		if strings.HasPrefix(sub[2], "redacted") {
+			return m
+		}
+		return sub[1] + "redacted" + r.hash("ocid", sub[2])[:20]
+	})
+	s = emailPattern.ReplaceAllStringFunc(s, func(m string) string {
+		if strings.HasSuffix(m, "@example.com") {
+			return m
+		}
+		return "user-" + r.hash("email", strings.ToLower(m))[:8] + "@example.com"
+	})
+	s = ipv4Pattern.ReplaceAllStringFunc(s, r.ip)
+	return adPattern.ReplaceAllString(s, "XXXX:$1")
+}
+
+// ip returns the pseudonym of a public address or prefix, keeping the prefix
+// length, and private ones unchanged.
+func (r *Redactor) ip(s string) string {
+	addrText, bits, isPrefix := strings.Cut(s, "/")
+	addr, err := netip.ParseAddr(addrText)
+	if err != nil || !public(addr) {
+		return s
+	}
+	sum, _ := hex.DecodeString(r.hash("ip", addr.String()))
+	base := pseudonymNet.Addr().As4()
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], binary.BigEndian.Uint32(base[:])+binary.BigEndian.Uint32(sum)%(1<<(32-pseudonymNet.Bits())))
+	pseudo := netip.AddrFrom4(b)
+	if !isPrefix {
+		return pseudo.String()
+	}
+	prefix, err := netip.ParsePrefix(pseudo.String() + "/" + bits)
+	if err != nil {
+		return s
+	}
+	return prefix.Masked().String()
+}
+
+func public(addr netip.Addr) bool {
+	return !addr.IsPrivate() && !addr.IsLoopback() && !addr.IsUnspecified() &&
+		!addr.IsLinkLocalUnicast() && !addr.IsMulticast() && !pseudonymNet.Contains(addr)
+}
+
+// Writer returns a writer that applies String to everything written to w,
+// for progress output. Each write is redacted on its own.
+func (r *Redactor) Writer(w io.Writer) io.Writer {
+	if r == nil {
+		return w
+	}
+	return &writer{r: r, w: w}
+}
+
+type writer struct {
+	r *Redactor
+	w io.Writer
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(w.w, w.r.String(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Result redacts result in place. Besides what String replaces everywhere,
+// the tenancy and compartment names and the object storage namespace get
+// pseudonyms wherever they appear, and tenancy and compartment descriptions
+// are dropped. Identifiers keep their form (an OCID stays an OCID of the same
+// type, a CIDR block a CIDR block), so the result still renders.
+func (r *Redactor) Result(result *discovery.Result) {
+	if r == nil {
+		return
+	}
+
+	compartments := make(map[string]string)
+	for _, c := range result.Compartments {
+		compartments[c.Name] = r.name("compartment", c.Name)
+	}
+	path := func(p string) string {
+		segments := strings.Split(p, ":")
+		for i, s := range segments {
+			if pseudo, ok := compartments[s]; ok {
+				segments[i] = pseudo
+			}
+		}
+		return strings.Join(segments, ":")
+	}
+
+	result.Tenancy.Name = r.name("tenancy", result.Tenancy.Name)
+	result.Tenancy.Description = ""
+	for i := range result.Compartments {
+		c := &result.Compartments[i]
+		c.Name = compartments[c.Name]
+		c.Description = ""
+		c.Path = path(c.Path)
+	}
+	for i := range result.Policies {
+		result.Policies[i].CompartmentName = path(result.Policies[i].CompartmentName)
+	}
+
+	var namespace *regexp.Regexp
+	var namespacePseudo string
+	if result.Registry != nil && result.Registry.Namespace != "" {
+		// In OCIR and object storage paths, e.g. "iad.ocir.io/<namespace>/repo"
+		namespace = regexp.MustCompile(`/` + regexp.QuoteMeta(result.Registry.Namespace) + `(/|$)`)
+		namespacePseudo = r.name("namespace", result.Registry.Namespace)
+		result.Registry.Namespace = namespacePseudo
+	}
+
+	walk(reflect.ValueOf(result).Elem(), func(s string) string {
+		s = r.String(s)
+		s = compartmentRefPattern.ReplaceAllStringFunc(s, func(m string) string {
+			sub := compartmentRefPattern.FindStringSubmatch(m)
+			return sub[1] + path(sub[2])
+		})
+		if namespace != nil {
+			s = namespace.ReplaceAllString(s, "/"+namespacePseudo+"$1")
+		}
+		return s
+	})
+}
+
+// name returns the pseudonym of a name, e.g. "compartment-3fa2b19c".
+func (r *Redactor) name(kind, value string) string {
+	if value == "" {
+		return ""
+	}
+	return kind + "-" + r.hash(kind, value)[:8]
+}
+
+func (r *Redactor) hash(kind, value string) string {
+	mac := hmac.New(sha256.New, r.key)
+	mac.Write([]byte(kind + "\x00" + value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// walk applies f to every string reachable from v through exported fields,
+// slices, maps (values only) and interfaces.
+func walk(v reflect.Value, f func(string) string) {
+	switch v.Kind() {
+	case reflect.String:
+		if v.CanSet() {
+			v.SetString(f(v.String()))
+		}
+	case reflect.Pointer:
+		if !v.IsNil() {
+			walk(v.Elem(), f)
+		}
+	case reflect.Interface:
+		if !v.IsNil() && v.CanSet() {
+			c := settable(v.Elem())
+			walk(c, f)
+			v.Set(c)
+		}
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if v.Type().Field(i).IsExported() {
+				walk(v.Field(i), f)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			walk(v.Index(i), f)
+		}
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			c := settable(v.MapIndex(k))
+			walk(c, f)
+			v.SetMapIndex(k, c)
+		}
+	}
+}
+
+// settable returns a settable copy of v.
+func settable(v reflect.Value) reflect.Value {
+	c := reflect.New(v.Type()).Elem()
+	c.Set(v)
+	return c
+}
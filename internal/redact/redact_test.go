@@ -0,0 +1,223 @@
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/larsenclose/oci-tf-bootstrap/internal/discovery"
+	"github.com/larsenclose/oci-tf-bootstrap/internal/renderer"
+)
+
+const (
+	key       = "test-key"
+	tenancyID = "ocid1.tenancy.oc1..aaaaaaaatenancy"
+	vcnID     = "ocid1.vcn.oc1.phx.aaaaaaaavcn"
+	igwID     = "ocid1.internetgateway.oc1.phx.aaaaaaaaigw"
+)
+
+func sample() *discovery.Result {
+	return &discovery.Result{
+		CompartmentID: tenancyID,
+		Tenancy:       discovery.TenancyInfo{ID: tenancyID, Name: "acme-corp", HomeRegion: "us-phoenix-1", Description: "Acme Corp production"},
+		Compartments: []discovery.Compartment{
+			{ID: "ocid1.compartment.oc1..aaaaaaaaprod", Name: "Prod", Description: "Acme production", ParentID: tenancyID},
+		},
+		AvailabilityDomains: []discovery.AvailabilityDomain{{Name: "Uocm:PHX-AD-1"}},
+		VCNs: []discovery.VCN{{
+			ID:            vcnID,
+			CompartmentID: tenancyID,
+			CIDRBlock:     "10.0.0.0/16",
+			Subnets:       []discovery.Subnet{{ID: "ocid1.subnet.oc1.phx.aaaaaaaasubnet", CIDRBlock: "10.0.1.0/24", AvailabilityDomain: "Uocm:PHX-AD-1"}},
+			SecurityLists: []discovery.SecurityList{{
+				ID: "ocid1.securitylist.oc1.phx.aaaaaaaasl",
+				IngressRules: []discovery.SecurityRule{
+					{Protocol: "6", Source: "203.0.113.7/32", PortMin: 22, PortMax: 22, Description: "SSH from the office"},
+					{Protocol: "6", Source: "0.0.0.0/0", PortMin: 443, PortMax: 443},
+				},
+			}},
+			RouteTables:     []discovery.RouteTable{{ID: "ocid1.routetable.oc1.phx.aaaaaaaart", Routes: []discovery.RouteRule{{Destination: "0.0.0.0/0", NetworkEntityID: igwID}}}},
+			InternetGateway: &discovery.InternetGateway{ID: igwID, IsEnabled: true},
+			NATGateway:      &discovery.NATGateway{ID: "ocid1.natgateway.oc1.phx.aaaaaaaanat", PublicIP: "203.0.113.7"},
+			Tags:            discovery.Tags{DefinedTags: map[string]map[string]string{"Oracle-Tags": {"CreatedBy": "oracleidentitycloudservice/jane@acme.example"}}},
+		}},
+		Policies: []discovery.Policy{{
+			ID:              "ocid1.policy.oc1..aaaaaaaapolicy",
+			CompartmentID:   tenancyID,
+			CompartmentName: "root",
+			Statements:      []string{"Allow group Admins to manage virtual-network-family in compartment Prod"},
+		}},
+		Registry: &discovery.Registry{Namespace: "axaxnpcrorw5", Endpoint: "phx.ocir.io", Repositories: []discovery.ContainerRepository{
+			{ID: "ocid1.containerrepo.oc1.phx.aaaaaaaarepo", Name: "app"},
+		}},
+		Budgets:    []discovery.Budget{{ID: "ocid1.budget.oc1.phx.aaaaaaaabudget", Targets: []string{tenancyID}}},
+		Extensions: map[string]any{"buckets": []any{"https://objectstorage.us-phoenix-1.oraclecloud.com/n/axaxnpcrorw5/b/logs"}},
+	}
+}
+
+// sensitive are the values a redacted result must not contain.
+var sensitive = []string{"aaaaaaaa", "acme", "Acme", "Prod", "axaxnpcrorw5", "203.0.113.7", "jane", "Uocm"}
+
+func TestResult(t *testing.T) {
+	result := sample()
+	New(key).Result(result)
+
+	var buf bytes.Buffer
+	if err := renderer.OutputJSON(result, &buf); err != nil {
+		t.Fatalf("OutputJSON failed: %v", err)
+	}
+	for _, s := range sensitive {
+		if strings.Contains(buf.String(), s) {
+			t.Errorf("redacted JSON still contains %q", s)
+		}
+	}
+
+	vcn := result.VCNs[0]
+	if result.CompartmentID != result.Tenancy.ID || vcn.CompartmentID != result.Tenancy.ID {
+		t.Error("expected the tenancy OCID to get the same pseudonym everywhere")
+	}
+	if vcn.RouteTables[0].Routes[0].NetworkEntityID != vcn.InternetGateway.ID {
+		t.Error("expected a route target to keep pointing at the internet gateway")
+	}
+	if !strings.HasPrefix(vcn.ID, "ocid1.vcn.oc1.phx.redacted") {
+		t.Errorf("expected an OCID of the same type and region, got %s", vcn.ID)
+	}
+	if vcn.CIDRBlock != "10.0.0.0/16" || vcn.SecurityLists[0].IngressRules[1].Source != "0.0.0.0/0" {
+		t.Error("expected private and catch-all CIDR blocks to be kept")
+	}
+	source := vcn.SecurityLists[0].IngressRules[0].Source
+	if !strings.HasPrefix(source, "198.1") || !strings.HasSuffix(source, "/32") {
+		t.Errorf("expected a public /32 to become a benchmarking /32, got %s", source)
+	}
+	if strings.TrimSuffix(source, "/32") != vcn.NATGateway.PublicIP {
+		t.Errorf("expected the same public IP to get the same pseudonym, got %s and %s", source, vcn.NATGateway.PublicIP)
+	}
+	if vcn.Subnets[0].AvailabilityDomain != "XXXX:PHX-AD-1" || result.AvailabilityDomains[0].Name != "XXXX:PHX-AD-1" {
+		t.Errorf("expected the AD prefix to be redacted, got %s", result.AvailabilityDomains[0].Name)
+	}
+	compartment := result.Compartments[0].Name
+	if want := "Allow group Admins to manage virtual-network-family in compartment " + compartment; result.Policies[0].Statements[0] != want {
+		t.Errorf("expected the compartment name to be replaced in statements:\ngot  %s\nwant %s", result.Policies[0].Statements[0], want)
+	}
+	if result.Policies[0].CompartmentName != "root" {
+		t.Errorf("expected the root compartment name to be kept, got %s", result.Policies[0].CompartmentName)
+	}
+	if !strings.Contains(result.Extensions["buckets"].([]any)[0].(string), "/n/"+result.Registry.Namespace+"/b/") {
+		t.Errorf("expected the namespace to be replaced in paths, got %v", result.Extensions["buckets"])
+	}
+
+	t.Run("stable", func(t *testing.T) {
+		again := sample()
+		New(key).Result(again)
+		if again.VCNs[0].ID != vcn.ID || again.Compartments[0].Name != compartment || again.Tenancy.Name != result.Tenancy.Name {
+			t.Error("expected the same pseudonyms from the same key")
+		}
+
+		other := sample()
+		New("other-key").Result(other)
+		if other.VCNs[0].ID == vcn.ID {
+			t.Error("expected other pseudonyms from another key")
+		}
+
+		twice := sample()
+		New(key).Result(twice)
+		New(key).Result(twice)
+		if twice.VCNs[0].ID != vcn.ID || twice.VCNs[0].NATGateway.PublicIP != vcn.NATGateway.PublicIP {
+			t.Error("expected redacting twice to change nothing")
+		}
+	})
+
+	t.Run("nil redactor", func(t *testing.T) {
+		unchanged := sample()
+		var r *Redactor
+		r.Result(unchanged)
+		if unchanged.VCNs[0].ID != vcnID || r.String(tenancyID) != tenancyID {
+			t.Error("expected a nil redactor to change nothing")
+		}
+	})
+}
+
+func TestResultRenders(t *testing.T) {
+	result := sample()
+	New(key).Result(result)
+
+	dir := t.TempDir()
+	if err := renderer.OutputTerraform(result, dir, renderer.Options{Instances: 1}); err != nil {
+		t.Fatalf("OutputTerraform failed on a redacted result: %v", err)
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("expected generated files, got %v, %v", files, err)
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, s := range sensitive {
+			if strings.Contains(string(data), s) {
+				t.Errorf("%s still contains %q", filepath.Base(file), s)
+			}
+		}
+	}
+}
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := New(key).Writer(&buf)
+	line := "  Tenancy:    " + tenancyID + "\n"
+	n, err := w.Write([]byte(line))
+	if err != nil || n != len(line) {
+		t.Fatalf("Write returned %d, %v", n, err)
+	}
+	if strings.Contains(buf.String(), "aaaaaaaa") || !strings.HasPrefix(buf.String(), "  Tenancy:    ocid1.tenancy.oc1..redacted") {
+		t.Errorf("unexpected redacted output: %q", buf.String())
+	}
+
+	var raw bytes.Buffer
+	var r *Redactor
+	if r.Writer(&raw) != &raw {
+		t.Error("expected a nil redactor to return the writer unchanged")
+	}
+
+	var out bytes.Buffer
+	_ = json.NewEncoder(New(key).Writer(&out)).Encode(map[string]string{"email": "jane@acme.example"})
+	if strings.Contains(out.String(), "jane") {
+		t.Errorf("expected emails to be redacted, got %s", out.String())
+	}
+}
+
+func TestLoadKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "oci-tf-bootstrap", "redact.key")
+	created, err := LoadKey(path)
+	if err != nil {
+		t.Fatalf("LoadKey failed: %v", err)
+	}
+	if len(created) != 64 {
+		t.Errorf("expected a 256-bit hex key, got %q", created)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("expected the key file to be readable only by the user, got %v", info.Mode().Perm())
+	}
+	if loaded, err := LoadKey(path); err != nil || loaded != created {
+		t.Errorf("expected the stored key %q on the next run, got %q, %v", created, loaded, err)
+	}
+	if other, _ := LoadKey(filepath.Join(t.TempDir(), "redact.key")); other == created {
+		t.Error("expected a new random key for another key file")
+	}
+
+	empty := filepath.Join(t.TempDir(), "empty.key")
+	if err := os.WriteFile(empty, []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadKey(empty); err == nil {
+		t.Error("expected an error for an empty key file")
+	}
+}
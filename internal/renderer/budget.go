@@ -0,0 +1,253 @@
+package renderer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/larsenclose/oci-tf-bootstrap/internal/discovery"
+)
+
+// budgetAlert is an alert rule written for the generated budget.
+type budgetAlert struct {
+	name      string // Resource name suffix
+	kind      string // ACTUAL or FORECAST
+	threshold int    // Percent of the budget amount
+}
+
+var budgetAlerts = []budgetAlert{
+	{"actual_80", "ACTUAL", 80},
+	{"actual_100", "ACTUAL", 100},
+	{"forecast_100", "FORECAST", 100},
+}
+
+// shapeQuota is a quota statement allowing one of the selected shapes.
+type shapeQuota struct {
+	family string // Quota family, e.g. "compute-core"
+	name   string // Quota name, e.g. "standard-a1-core-count"
+	value  int
+}
+
+// shapeQuotas maps the shapes the bootstrap can select to the quotas that
+// allow them. Values are starting points; always-free values match the free
+// tier allowance.
+var shapeQuotas = map[string][]shapeQuota{
+	"VM.Standard.A1.Flex": {
+		{"compute-core", "standard-a1-core-count", 8},
+		{"compute-memory", "standard-a1-memory-count", 48},
+	},
+	"VM.Standard.E2.1.Micro": {
+		{"compute", "vm-standard-e2-1-micro-count", 2},
+	},
+	"VM.Standard.E3.Flex": {
+		{"compute-core", "standard-e3-core-ad-count", 8},
+		{"compute-memory", "standard-e3-memory-count", 128},
+	},
+	"VM.Standard.E4.Flex": {
+		{"compute-core", "standard-e4-core-count", 8},
+		{"compute-memory", "standard-e4-memory-count", 128},
+	},
+	"VM.Standard.E5.Flex": {
+		{"compute-core", "standard-e5-core-count", 8},
+		{"compute-memory", "standard-e5-memory-count", 128},
+	},
+	"VM.Standard3.Flex": {
+		{"compute-core", "standard3-core-count", 8},
+		{"compute-memory", "standard3-memory-count", 128},
+	},
+}
+
+// alwaysFreeQuotas caps the free shapes at the always-free allowance.
+var alwaysFreeQuotas = map[string]int{
+	"standard-a1-core-count":       4,
+	"standard-a1-memory-count":     24,
+	"vm-standard-e2-1-micro-count": 2,
+}
+
+// selectedShapes returns the compute shapes used by the generated examples,
+// in the order they appear.
+func selectedShapes(result *discovery.Result, opts Options) []string {
+	var shapes []string
+	add := func(name string) {
+		if name != "" && !slices.Contains(shapes, name) {
+			shapes = append(shapes, name)
+		}
+	}
+
+	if opts.AlwaysFree {
+		shape := "VM.Standard.E2.1.Micro"
+		for _, s := range result.Shapes {
+			if s.Name == "VM.Standard.A1.Flex" {
+				shape = s.Name
+				break
+			}
+		}
+		add(shape)
+	} else {
+		add("VM.Standard.E4.Flex")
+	}
+	if len(result.OKEImages) > 0 {
+		chosen := chooseOKEShapes(result.Shapes)
+		add(chosen.arm)
+		add(chosen.x86)
+	}
+	return shapes
+}
+
+// quotaCompartment returns the "in compartment ..." clause for the target
+// compartment, using the colon-separated path quota statements expect. It
+// returns "" when the compartment was not discovered.
+func quotaCompartment(result *discovery.Result) string {
+	target := result.CompartmentID
+	if target == "" || target == result.Tenancy.ID {
+		return "tenancy"
+	}
+	_, paths := compartmentOrder(result)
+	path, ok := paths[target]
+	if !ok {
+		return ""
+	}
+	return "compartment " + strings.ReplaceAll(strings.TrimPrefix(path, "root/"), "/", ":")
+}
+
+// quotaStatements zeroes the compute quota families in the target compartment
+// and then allows only the selected shapes.
+func quotaStatements(shapes []string, scope string, alwaysFree bool) []string {
+	var families []string
+	var sets []string
+	for _, shape := range shapes {
+		for _, q := range shapeQuotas[shape] {
+			if !slices.Contains(families, q.family) {
+				families = append(families, q.family)
+			}
+			value := q.value
+			if v, ok := alwaysFreeQuotas[q.name]; ok && alwaysFree {
+				value = v
+			}
+			sets = append(sets, fmt.Sprintf("set %s quota %s to %d in %s", q.family, q.name, value, scope))
+		}
+	}
+
+	statements := make([]string, 0, len(families)+len(sets))
+	for _, family := range families {
+		statements = append(statements, fmt.Sprintf("zero %s quotas in %s", family, scope))
+	}
+	return append(statements, sets...)
+}
+
+// writeBudgetLocals writes the budget and quota sections of locals.tf.
+func writeBudgetLocals(f *os.File, result *discovery.Result) {
+	if len(result.Budgets) > 0 {
+		fmt.Fprintln(f, "  # ── Budgets ──────────────────────────────────────────────────────────────")
+		tracker := newNameTracker()
+		for _, b := range result.Budgets {
+			fmt.Fprintf(f, "  budget_%s = %q  # %g/%s, %s target, %d alert rules, spent %g\n",
+				tracker.unique(b.Name), b.ID, b.Amount, b.ResetPeriod, b.TargetType, len(b.AlertRules), b.ActualSpend)
+		}
+		fmt.Fprintln(f, "")
+	}
+
+	if len(result.Quotas) > 0 {
+		fmt.Fprintln(f, "  # ── Quotas ───────────────────────────────────────────────────────────────")
+		tracker := newNameTracker()
+		for _, q := range result.Quotas {
+			fmt.Fprintf(f, "  quota_%s = %q  # %d statements\n", tracker.unique(q.Name), q.ID, len(q.Statements))
+			for _, s := range q.Statements {
+				fmt.Fprintf(f, "  #   %s\n", s)
+			}
+		}
+		fmt.Fprintln(f, "")
+	}
+}
+
+// writeBudget generates budget.tf: a monthly budget on the target compartment
+// with actual and forecast alert rules, and a quota policy limiting the
+// compartment to the shapes the generated examples use.
+func writeBudget(result *discovery.Result, outputDir string, opts Options) (err error) {
+	f, err := os.Create(filepath.Join(outputDir, "budget.tf")) // #nosec G304 -- outputDir is user-specified CLI flag
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	fmt.Fprintln(f, "# Generated by oci-tf-bootstrap")
+	fmt.Fprintln(f, "# Cost guardrails: a compartment budget with alert rules and a shape quota")
+	fmt.Fprintln(f, "#")
+	fmt.Fprintln(f, "# Budgets and quotas live in the tenancy root and need tenancy-level")
+	fmt.Fprintln(f, "# permissions to manage (usage-budgets and quotas in the cost family).")
+	if len(result.Budgets) > 0 || len(result.Quotas) > 0 {
+		fmt.Fprintln(f, "#")
+		fmt.Fprintln(f, "# Existing budgets and quotas are listed in locals.tf.")
+	}
+	fmt.Fprintln(f, "")
+
+	fmt.Fprintln(f, `variable "budget_alert_email" {`)
+	fmt.Fprintln(f, `  description = "Comma-separated email addresses notified by budget alert rules"`)
+	fmt.Fprintln(f, "  type        = string")
+	fmt.Fprintln(f, "}")
+	fmt.Fprintln(f, "")
+
+	fmt.Fprintln(f, `resource "oci_budget_budget" "bootstrap" {`)
+	fmt.Fprintln(f, "  compartment_id = local.tenancy_ocid")
+	fmt.Fprintln(f, `  display_name   = "bootstrap-budget"`)
+	fmt.Fprintf(f, "  amount         = %g\n", opts.Budget)
+	fmt.Fprintln(f, `  reset_period   = "MONTHLY"`)
+	fmt.Fprintln(f, `  target_type    = "COMPARTMENT"`)
+	fmt.Fprintln(f, "  targets        = [local.compartment_ocid]")
+	writeDefinedTags(f, opts, false)
+	fmt.Fprintln(f, "}")
+
+	for _, a := range budgetAlerts {
+		fmt.Fprintln(f, "")
+		fmt.Fprintf(f, "resource \"oci_budget_alert_rule\" %q {\n", a.name)
+		fmt.Fprintln(f, "  budget_id      = oci_budget_budget.bootstrap.id")
+		fmt.Fprintf(f, "  display_name   = %q\n", strings.ReplaceAll(a.name, "_", "-"))
+		fmt.Fprintf(f, "  type           = %q\n", a.kind)
+		fmt.Fprintf(f, "  threshold      = %d\n", a.threshold)
+		fmt.Fprintln(f, `  threshold_type = "PERCENTAGE"`)
+		fmt.Fprintln(f, "  recipients     = var.budget_alert_email")
+		fmt.Fprintf(f, "  message        = \"%s spend has reached %d%% of the bootstrap budget\"\n", a.kind[:1]+strings.ToLower(a.kind[1:]), a.threshold)
+		writeDefinedTags(f, opts, false)
+		fmt.Fprintln(f, "}")
+	}
+	fmt.Fprintln(f, "")
+
+	shapes := selectedShapes(result, opts)
+	scope := quotaCompartment(result)
+	commented := scope == ""
+	p := lineWriter(commented)
+
+	fmt.Fprintln(f, "# ── Shape Quota ────────────────────────────────────────────────────────────")
+	fmt.Fprintf(f, "# Allows only the shapes used by the generated examples: %s\n", strings.Join(shapes, ", "))
+	fmt.Fprintln(f, "# Quota values are starting points; adjust them to the capacity you need.")
+	switch scope {
+	case "":
+		fmt.Fprintln(f, "# The target compartment was not discovered. Replace <compartment> with its")
+		fmt.Fprintln(f, "# colon-separated path (e.g. parent:child), then uncomment below.")
+		scope = "compartment <compartment>"
+	case "tenancy":
+		fmt.Fprintln(f, "# WARNING: the target is the tenancy root, so this quota applies to every")
+		fmt.Fprintln(f, "# compartment. Consider running with --compartment.")
+	}
+	fmt.Fprintln(f, "")
+
+	p(f, `resource "oci_limits_quota" "bootstrap" {`)
+	p(f, "  compartment_id = local.tenancy_ocid")
+	p(f, `  name           = "bootstrap-shapes"`)
+	p(f, `  description    = "Limit the bootstrap compartment to the generated example shapes"`)
+	p(f, "  statements = [")
+	for _, s := range quotaStatements(shapes, scope, opts.AlwaysFree) {
+		p(f, fmt.Sprintf("    %q,", s))
+	}
+	p(f, "  ]")
+	writeDefinedTags(f, opts, commented)
+	p(f, "}")
+
+	return nil
+}
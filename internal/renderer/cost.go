@@ -0,0 +1,226 @@
+package renderer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/larsenclose/oci-tf-bootstrap/internal/discovery"
+	"github.com/larsenclose/oci-tf-bootstrap/internal/pricing"
+)
+
+// Sizing of the generated examples, as written by instance.go and oke.go.
+const (
+	exampleBootVolumeGBs = 47 // OCI default for platform Linux images; always-free sets 50
+	freeBootVolumeGBs    = 50
+	exampleVolumeGBs     = 50
+	exampleVolumeVPUs    = 10
+	nodeBootVolumeGBs    = 50 // OKE default node boot volume
+)
+
+// computeSizing is an instance shape with the OCPUs and memory it is priced at.
+type computeSizing struct {
+	shape        string
+	ocpus, memGB float64
+}
+
+func (c computeSizing) String() string {
+	if c.ocpus == 0 {
+		return c.shape
+	}
+	return fmt.Sprintf("%s, %g OCPU / %g GB", c.shape, c.ocpus, c.memGB)
+}
+
+// exampleSizing returns the shape and size of the example instance: A1.Flex at
+// half the free allowance (or E2.1.Micro without A1) in always-free mode, and a
+// 1 OCPU E4.Flex otherwise.
+func exampleSizing(result *discovery.Result, opts Options) computeSizing {
+	if !opts.AlwaysFree {
+		return computeSizing{"VM.Standard.E4.Flex", 1, 6}
+	}
+	for _, s := range result.Shapes {
+		if s.Name == "VM.Standard.A1.Flex" {
+			return computeSizing{s.Name, 2, 12}
+		}
+	}
+	return computeSizing{shape: "VM.Standard.E2.1.Micro"}
+}
+
+// costEstimator prices resources against a catalog, charging nothing for
+// resources that fit in the always-free allowance when it applies.
+type costEstimator struct {
+	prices *pricing.Catalog
+	free   *pricing.FreeTier // nil outside always-free mode
+	est    pricing.Estimate
+}
+
+func (e *costEstimator) compute(resource string, c computeSizing) {
+	item := pricing.Item{Resource: resource, Description: c.String()}
+	monthly, ok := e.prices.Compute(c.shape, c.ocpus, c.memGB)
+	if !ok {
+		item.Unpriced = true
+		item.Description += " (not in price catalog)"
+		e.est.Add(item)
+		return
+	}
+	item.Monthly = monthly
+	if e.free != nil {
+		item.AlwaysFree = e.free.Compute(c.shape, c.ocpus, c.memGB)
+	}
+	e.est.Add(item)
+}
+
+func (e *costEstimator) volume(resource, description string, sizeGBs, vpus float64) {
+	item := pricing.Item{
+		Resource:    resource,
+		Description: fmt.Sprintf("%s, %g GB at %g VPUs/GB", description, sizeGBs, vpus),
+		Monthly:     e.prices.Volume(sizeGBs, vpus),
+	}
+	if e.free != nil {
+		item.AlwaysFree = e.free.Volume(sizeGBs, vpus)
+	}
+	e.est.Add(item)
+}
+
+// EstimateCost prices the resources OutputTerraform generates uncommented,
+// from the files it writes with opts. Always-free resources within the
+// tenancy allowance are zero in always-free mode; outside it every resource is
+// charged at list price.
+func EstimateCost(result *discovery.Result, opts Options) pricing.Estimate {
+	prices := opts.Prices
+	if prices == nil {
+		prices = pricing.Default()
+	}
+	e := &costEstimator{
+		prices: prices,
+		est:    pricing.Estimate{CatalogVersion: prices.Version, Currency: prices.Currency},
+	}
+	if opts.AlwaysFree {
+		e.free = prices.NewFreeTier()
+	}
+
+	instance, bootGBs := "example", float64(exampleBootVolumeGBs)
+	if opts.AlwaysFree {
+		instance, bootGBs = "always_free", freeBootVolumeGBs
+	}
+	sizing := exampleSizing(result, opts)
+	if opts.Renders("instance_example.tf") {
+		e.compute("oci_core_instance."+instance, sizing)
+		e.volume("oci_core_instance."+instance, "Boot volume", bootGBs, exampleVolumeVPUs)
+		if opts.BackupPolicy != "" {
+			e.volume("oci_core_volume."+instance+"_data", "Block volume", exampleVolumeGBs, exampleVolumeVPUs)
+		}
+	}
+
+	if (opts.Instances > 1 || opts.InstancePool) && opts.Renders("instances.tf") {
+		placements, _ := topologyPlacements(result, max(opts.Instances, 1))
+		for i := range placements {
+			resource := fmt.Sprintf("oci_core_instance.spread[%d]", i)
+			if opts.InstancePool {
+				resource = fmt.Sprintf("oci_core_instance_pool.spread (instance %d)", i+1)
+			}
+			e.compute(resource, sizing)
+			e.volume(resource, "Boot volume", exampleBootVolumeGBs, exampleVolumeVPUs)
+		}
+	}
+
+	if len(result.OKEImages) > 0 && opts.Renders("oke_example.tf") {
+		estimateOKE(e, result, opts)
+	}
+	return e.est
+}
+
+// estimateOKE prices the cluster and the active node pools in oke_example.tf.
+func estimateOKE(e *costEstimator, result *discovery.Result, opts Options) {
+	target := okeTargetFor(result, opts)
+	shapes := chooseOKEShapes(result.Shapes)
+
+	if len(result.OKEClusters) == 0 && !target.noCluster {
+		item := pricing.Item{Resource: "oci_containerengine_cluster.oke", Description: "BASIC_CLUSTER control plane (free)"}
+		if opts.VirtualNodes {
+			item.Description = "ENHANCED_CLUSTER control plane"
+			item.Monthly = e.prices.EnhancedCluster()
+		}
+		e.est.Add(item)
+	}
+
+	groups := groupOKEImagesByVersion(result.OKEImages)
+	if active := activeOKEGroup(groups, target); active >= 0 {
+		g := groups[active]
+		suffix := toTFName(okeKubernetesVersion(g.version))
+		if g.arm != nil && shapes.arm != "" {
+			pool := "oci_containerengine_node_pool.arm_pool_" + suffix
+			e.compute(pool, computeSizing{shapes.arm, 2, 12})
+			e.volume(pool, "Node boot volume", nodeBootVolumeGBs, exampleVolumeVPUs)
+		}
+		if g.x86 != nil && shapes.x86 != "" {
+			pool := "oci_containerengine_node_pool.x86_pool_" + suffix
+			e.compute(pool, computeSizing{shapes.x86, 2, 16})
+			e.volume(pool, "Node boot volume", nodeBootVolumeGBs, exampleVolumeVPUs)
+		}
+	}
+
+	if virtualNodePoolActive(target, shapes.pod) {
+		e.est.Add(pricing.Item{
+			Resource:    "oci_containerengine_virtual_node_pool.virtual",
+			Description: "1 virtual node; pod OCPU and memory are billed per use",
+			Monthly:     e.prices.VirtualNodes(1),
+		})
+	}
+}
+
+// writeCostEstimate writes cost_estimate.md: the monthly cost of each generated
+// resource and the total, from the price catalog in opts.
+func writeCostEstimate(result *discovery.Result, outputDir string, opts Options) (err error) {
+	f, err := os.Create(filepath.Join(outputDir, "cost_estimate.md")) // #nosec G304 -- outputDir is user-specified CLI flag
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	est := EstimateCost(result, opts)
+
+	fmt.Fprintln(f, "# Cost Estimate")
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "Generated by oci-tf-bootstrap.")
+	fmt.Fprintln(f, "")
+	fmt.Fprintf(f, "Monthly list prices in %s from price catalog `%s`, excluding taxes,\n", est.Currency, est.CatalogVersion)
+	fmt.Fprintln(f, "network egress and commented-out resources.")
+	if opts.AlwaysFree {
+		fmt.Fprintln(f, "Resources within the always-free allowance are shown as free; the")
+		fmt.Fprintln(f, "allowance is shared with anything already running in the tenancy.")
+	}
+	fmt.Fprintln(f, "")
+
+	fmt.Fprintln(f, "| Resource | Sizing | Monthly |")
+	fmt.Fprintln(f, "|----------|--------|---------|")
+	for _, item := range est.Items {
+		cost := fmt.Sprintf("%.2f", item.Monthly)
+		switch {
+		case item.AlwaysFree:
+			cost = "always free"
+		case item.Unpriced:
+			cost = "unknown"
+		}
+		fmt.Fprintf(f, "| `%s` | %s | %s |\n", item.Resource, mdCell(item.Description), cost)
+	}
+	fmt.Fprintf(f, "| **Total** | | **%.2f %s** |\n", est.Total, est.Currency)
+
+	var unpriced []string
+	for _, item := range est.Items {
+		if item.Unpriced {
+			unpriced = append(unpriced, "`"+item.Resource+"`")
+		}
+	}
+	if len(unpriced) > 0 {
+		fmt.Fprintln(f, "")
+		fmt.Fprintf(f, "Not included in the total: %s. Add their shapes to a price catalog and\n", strings.Join(unpriced, ", "))
+		fmt.Fprintln(f, "re-run with `--price-catalog`.")
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package renderer
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/larsenclose/oci-tf-bootstrap/internal/discovery"
+)
+
+// internalFQDN returns the hostname an instance resolves to inside its VCN,
+// e.g. "web.app.main.oraclevcn.com", or "" when the subnet or VCN has no DNS label.
+func internalFQDN(host, subnetLabel, vcnLabel string) string {
+	if subnetLabel == "" || vcnLabel == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s.%s.%s.oraclevcn.com", host, subnetLabel, vcnLabel)
+}
+
+// writeDNSLocals writes the DNS zone, view and resolver sections of locals.tf.
+func writeDNSLocals(f *os.File, result *discovery.Result) {
+	viewNames := make(map[string]string)
+
+	if len(result.DNSViews) > 0 {
+		fmt.Fprintln(f, "  # ── DNS Views ────────────────────────────────────────────────────────────")
+		tracker := newNameTracker()
+		for _, v := range result.DNSViews {
+			name := "dns_view_" + tracker.unique(v.Name)
+			viewNames[v.ID] = name
+			if v.IsProtected {
+				fmt.Fprintf(f, "  %s = %q  # Oracle-managed VCN default view\n", name, v.ID)
+			} else {
+				fmt.Fprintf(f, "  %s = %q\n", name, v.ID)
+			}
+		}
+		fmt.Fprintln(f, "")
+	}
+
+	if len(result.DNSZones) > 0 {
+		fmt.Fprintln(f, "  # ── DNS Zones ────────────────────────────────────────────────────────────")
+		tracker := newNameTracker()
+		for _, z := range result.DNSZones {
+			name := "dns_zone_" + tracker.unique(z.Name)
+			detail := fmt.Sprintf("%s %s", z.Name, z.Scope)
+			if view, ok := viewNames[z.ViewID]; ok {
+				detail += ", view local." + view
+			}
+			if z.ZoneType == "SECONDARY" {
+				detail += ", secondary"
+			}
+			fmt.Fprintf(f, "  %s = %q  # %s\n", name, z.ID, detail)
+		}
+		fmt.Fprintln(f, "")
+	}
+
+	if len(result.DNSResolvers) > 0 {
+		fmt.Fprintln(f, "  # ── DNS Resolvers ────────────────────────────────────────────────────────")
+		vcnNames := make(map[string]string)
+		for _, v := range result.VCNs {
+			vcnNames[v.ID] = v.DisplayName
+		}
+		subnetNames := subnetLocalNames(result)
+		tracker := newNameTracker()
+		for _, r := range result.DNSResolvers {
+			name := "dns_resolver_" + tracker.unique(r.Name)
+			vcn := vcnNames[r.VCNID]
+			if vcn == "" {
+				vcn = r.VCNID
+			}
+			fmt.Fprintf(f, "  %s = %q  # VCN %s, %d endpoints\n", name, r.ID, vcn, len(r.Endpoints))
+			for _, ep := range r.Endpoints {
+				var role string
+				switch {
+				case ep.IsListening && ep.IsForwarding:
+					role = fmt.Sprintf("listening %s, forwarding %s", ep.ListeningAddress, ep.ForwardingAddress)
+				case ep.IsListening:
+					role = "listening " + ep.ListeningAddress
+				default:
+					role = "forwarding " + ep.ForwardingAddress
+				}
+				if subnet, ok := subnetNames[ep.SubnetID]; ok {
+					role += " in local." + subnet
+				}
+				fmt.Fprintf(f, "  #   %s: %s\n", ep.Name, role)
+			}
+		}
+		fmt.Fprintln(f, "")
+	}
+}
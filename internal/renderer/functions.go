@@ -0,0 +1,117 @@
+package renderer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/larsenclose/oci-tf-bootstrap/internal/discovery"
+)
+
+// ocirRepoLocalNames returns the locals.tf name for each repository, in order.
+func ocirRepoLocalNames(repos []discovery.ContainerRepository) []string {
+	tracker := newNameTracker()
+	names := make([]string, len(repos))
+	for i, r := range repos {
+		names[i] = "ocir_repo_" + tracker.unique(r.Name)
+	}
+	return names
+}
+
+// functionsAppLocalNames returns the locals.tf name for each application, in order.
+func functionsAppLocalNames(apps []discovery.FunctionsApplication) []string {
+	tracker := newNameTracker()
+	names := make([]string, len(apps))
+	for i, a := range apps {
+		names[i] = "fn_app_" + tracker.unique(a.Name)
+	}
+	return names
+}
+
+// firstPrivateSubnet returns the locals.tf reference for the first discovered
+// private subnet, or "" when there is none.
+func firstPrivateSubnet(result *discovery.Result) string {
+	names := subnetLocalNames(result)
+	for _, v := range result.VCNs {
+		for _, s := range v.Subnets {
+			if !s.IsPublic {
+				return "local." + names[s.ID]
+			}
+		}
+	}
+	return ""
+}
+
+// writeFunctionsExample generates functions_example.tf with an application on
+// a discovered private subnet and a function pulling its image from OCIR.
+func writeFunctionsExample(result *discovery.Result, outputDir string, opts Options) (err error) {
+	f, err := os.Create(filepath.Join(outputDir, "functions_example.tf")) // #nosec G304 -- outputDir is user-specified CLI flag
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	subnet := firstPrivateSubnet(result)
+	commented := subnet == ""
+	p := lineWriter(commented)
+
+	fmt.Fprintln(f, "# Generated by oci-tf-bootstrap")
+	fmt.Fprintln(f, "# OCI Functions Application")
+	fmt.Fprintln(f, "#")
+	fmt.Fprintln(f, "# Functions run in a private subnet and pull their images from OCIR.")
+	if result.Registry != nil {
+		fmt.Fprintln(f, "# Push an image before creating the function:")
+		fmt.Fprintf(f, "#   docker login %s -u '%s/<username>'  # password is an auth token\n", result.Registry.Endpoint, result.Registry.Namespace)
+		fmt.Fprintf(f, "#   docker push %s/<repo>:<tag>\n", result.Registry.URL())
+	}
+	if len(result.FunctionsApplications) > 0 {
+		fmt.Fprintln(f, "#")
+		fmt.Fprintln(f, "# Existing applications (see locals.tf):")
+		for i, name := range functionsAppLocalNames(result.FunctionsApplications) {
+			fmt.Fprintf(f, "#   local.%s  # %s\n", name, result.FunctionsApplications[i].Shape)
+		}
+	}
+	fmt.Fprintln(f, "")
+
+	if commented {
+		fmt.Fprintln(f, "# No private subnet was discovered. Set subnet_ids to a private subnet")
+		fmt.Fprintln(f, "# with access to OCIR (service gateway or NAT), then uncomment below.")
+		fmt.Fprintln(f, "")
+		subnet = `""`
+	}
+
+	p(f, `resource "oci_functions_application" "app" {`)
+	p(f, "  compartment_id = local.compartment_ocid")
+	p(f, `  display_name   = "fn-app"`)
+	p(f, fmt.Sprintf("  subnet_ids     = [%s]", subnet))
+	p(f, `  shape          = "GENERIC_X86"  # GENERIC_ARM or GENERIC_X86_ARM for multi-arch images`)
+	p(f, "")
+	p(f, "  config = {")
+	p(f, `    # LOG_LEVEL = "info"`)
+	p(f, "  }")
+	writeDefinedTags(f, opts, commented)
+	p(f, "}")
+	fmt.Fprintln(f, "")
+
+	image := `""  # Set to your image in OCIR, e.g. <region>.ocir.io/<namespace>/<repo>:<tag>`
+	if result.Registry != nil {
+		image = `"${local.ocir_registry}/my-function:latest"`
+		if len(result.Registry.Repositories) > 0 {
+			image = fmt.Sprintf(`"${local.%s}:latest"`, ocirRepoLocalNames(result.Registry.Repositories)[0])
+		}
+	}
+
+	fmt.Fprintln(f, "# Uncomment once the image has been pushed:")
+	fmt.Fprintln(f, `# resource "oci_functions_function" "hello" {`)
+	fmt.Fprintln(f, "#   application_id = oci_functions_application.app.id")
+	fmt.Fprintln(f, `#   display_name   = "hello"`)
+	fmt.Fprintf(f, "#   image          = %s\n", image)
+	fmt.Fprintln(f, "#   memory_in_mbs  = 256")
+	fmt.Fprintln(f, "# }")
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package renderer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/larsenclose/oci-tf-bootstrap/internal/discovery"
+)
+
+// mdCell escapes a value for use inside a markdown table cell.
+func mdCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+// compartmentOrder returns compartment IDs in report order: the tenancy root
+// first, then the compartment tree depth-first, each with its display path.
+func compartmentOrder(result *discovery.Result) (ids []string, paths map[string]string) {
+	paths = map[string]string{result.Tenancy.ID: "root"}
+	ids = []string{result.Tenancy.ID}
+
+	var walk func(nodes []*compartmentNode, prefix string)
+	walk = func(nodes []*compartmentNode, prefix string) {
+		for _, n := range nodes {
+			path := prefix + "/" + n.comp.Name
+			paths[n.comp.ID] = path
+			ids = append(ids, n.comp.ID)
+			walk(n.children, path)
+		}
+	}
+	walk(buildCompartmentTree(result.Compartments, result.Tenancy.ID), "root")
+	return ids, paths
+}
+
+// writeIAMReport writes iam_report.md: groups, dynamic groups and the policy
+// statements attached to each compartment, for review before writing Terraform
+// that depends on them.
+func writeIAMReport(result *discovery.Result, outputDir string) (err error) {
+	f, err := os.Create(filepath.Join(outputDir, "iam_report.md")) // #nosec G304 -- outputDir is user-specified CLI flag
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	fmt.Fprintln(f, "# IAM Report")
+	fmt.Fprintln(f, "")
+	tenancy := result.Tenancy.Name
+	if tenancy == "" {
+		tenancy = result.Tenancy.ID
+	}
+	fmt.Fprintf(f, "Generated by oci-tf-bootstrap for tenancy `%s`.\n", tenancy)
+	fmt.Fprintln(f, "")
+
+	if len(result.Groups) > 0 {
+		fmt.Fprintln(f, "## Groups")
+		fmt.Fprintln(f, "")
+		fmt.Fprintln(f, "| Name | Description | OCID |")
+		fmt.Fprintln(f, "|------|-------------|------|")
+		for _, g := range result.Groups {
+			fmt.Fprintf(f, "| %s | %s | `%s` |\n", mdCell(g.Name), mdCell(g.Description), g.ID)
+		}
+		fmt.Fprintln(f, "")
+	}
+
+	if len(result.DynamicGroups) > 0 {
+		fmt.Fprintln(f, "## Dynamic Groups")
+		fmt.Fprintln(f, "")
+		fmt.Fprintln(f, "| Name | Matching Rule | OCID |")
+		fmt.Fprintln(f, "|------|---------------|------|")
+		for _, g := range result.DynamicGroups {
+			fmt.Fprintf(f, "| %s | `%s` | `%s` |\n", mdCell(g.Name), mdCell(g.MatchingRule), g.ID)
+		}
+		fmt.Fprintln(f, "")
+	}
+
+	fmt.Fprintln(f, "## Policies by Compartment")
+	fmt.Fprintln(f, "")
+	if len(result.Policies) == 0 {
+		fmt.Fprintln(f, "No policies were discovered.")
+		return nil
+	}
+
+	byCompartment := make(map[string][]discovery.Policy)
+	for _, p := range result.Policies {
+		byCompartment[p.CompartmentID] = append(byCompartment[p.CompartmentID], p)
+	}
+
+	ids, paths := compartmentOrder(result)
+	// Policies attached to compartments outside the discovered tree go last.
+	known := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		known[id] = true
+	}
+	for _, p := range result.Policies {
+		if !known[p.CompartmentID] {
+			known[p.CompartmentID] = true
+			ids = append(ids, p.CompartmentID)
+			paths[p.CompartmentID] = p.CompartmentName
+		}
+	}
+
+	for _, id := range ids {
+		policies := byCompartment[id]
+		if len(policies) == 0 {
+			continue
+		}
+		fmt.Fprintf(f, "### %s\n", paths[id])
+		fmt.Fprintln(f, "")
+		for _, p := range policies {
+			fmt.Fprintf(f, "#### %s\n", p.Name)
+			fmt.Fprintln(f, "")
+			if p.Description != "" {
+				fmt.Fprintf(f, "%s\n", p.Description)
+				fmt.Fprintln(f, "")
+			}
+			fmt.Fprintln(f, "```")
+			for _, stmt := range p.Statements {
+				fmt.Fprintln(f, stmt)
+			}
+			fmt.Fprintln(f, "```")
+			fmt.Fprintln(f, "")
+		}
+	}
+
+	return nil
+}
@@ -53,15 +53,195 @@ func writeInstanceExample(result *discovery.Result, outputDir string, opts Optio
 	fmt.Fprintln(f, "# Generated by oci-tf-bootstrap")
 
 	if opts.AlwaysFree {
-		writeAlwaysFreeInstance(f, result)
+		fqdn := writeAlwaysFreeInstance(f, result, opts)
+		writeInstanceFQDN(f, "always_free", fqdn)
+		writeVolumeExample(f, result, opts, "always_free", "always-free-arm")
 	} else {
-		writeStandardInstance(f, result)
+		fqdn := writeStandardInstance(f, result, opts)
+		writeInstanceFQDN(f, "example", fqdn)
+		writeVolumeExample(f, result, opts, "example", "example-instance")
 	}
 
 	return nil
 }
 
-func writeAlwaysFreeInstance(f *os.File, result *discovery.Result) {
+// writeInstanceFQDN outputs the internal hostname of the example instance,
+// resolvable from the VCN and any network peered through its resolver.
+func writeInstanceFQDN(f *os.File, instance, fqdn string) {
+	if fqdn == "" {
+		return
+	}
+	fmt.Fprintln(f, "")
+	fmt.Fprintf(f, "output %q {\n", instance+"_fqdn")
+	fmt.Fprintf(f, "  value = %q\n", fqdn)
+	fmt.Fprintln(f, "}")
+}
+
+// backupPolicyRef returns the Terraform expression for the named backup policy.
+// Discovered policies resolve to their locals.tf entry; anything else falls back
+// to a data source lookup by display name, emitted once by the caller.
+func backupPolicyRef(result *discovery.Result, policyName string) (ref string, discovered bool) {
+	tracker := newNameTracker()
+	for _, bp := range result.BackupPolicies {
+		name := tracker.unique(bp.DisplayName)
+		if strings.EqualFold(bp.DisplayName, policyName) {
+			return "local.backup_policy_" + name, true
+		}
+	}
+	return "data.oci_core_volume_backup_policies.selected.volume_backup_policies[0].id", false
+}
+
+// writeVolumeExample writes a block volume attached to the example instance and
+// backup policy assignments for both the boot volume and the block volume, all
+// commented out unless a backup policy is selected.
+func writeVolumeExample(f *os.File, result *discovery.Result, opts Options, instance, displayName string) {
+	commented := opts.BackupPolicy == ""
+	p := lineWriter(commented)
+
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "# ── Block Volume Example ────────────────────────────────────────────────────")
+	if commented {
+		fmt.Fprintln(f, "# Re-run with --backup-policy <name> to create a backed-up data volume, or uncomment below.")
+	}
+	if opts.AlwaysFree {
+		fmt.Fprintln(f, "# 50GB boot + 50GB data = 100GB of the 200GB always-free block storage")
+	}
+	fmt.Fprintln(f, "")
+	p(f, fmt.Sprintf(`resource "oci_core_volume" "%s_data" {`, instance))
+	p(f, "  compartment_id      = local.compartment_ocid")
+	p(f, fmt.Sprintf("  availability_domain = oci_core_instance.%s.availability_domain", instance))
+	p(f, fmt.Sprintf(`  display_name        = "%s-data"`, displayName))
+	p(f, fmt.Sprintf("  size_in_gbs         = %d", exampleVolumeGBs))
+	p(f, fmt.Sprintf("  vpus_per_gb         = %d  # Balanced; 0 = Lower Cost, 20 = Higher Performance", exampleVolumeVPUs))
+	writeDefinedTags(f, opts, commented)
+	p(f, "}")
+	p(f, "")
+	p(f, fmt.Sprintf(`resource "oci_core_volume_attachment" "%s_data" {`, instance))
+	p(f, `  attachment_type = "paravirtualized"`)
+	p(f, fmt.Sprintf("  instance_id     = oci_core_instance.%s.id", instance))
+	p(f, fmt.Sprintf("  volume_id       = oci_core_volume.%s_data.id", instance))
+	p(f, "}")
+	fmt.Fprintln(f, "")
+
+	policyName := opts.BackupPolicy
+	if commented {
+		policyName = "bronze"
+		fmt.Fprintln(f, "# ── Backup Policy ───────────────────────────────────────────────────────────")
+		fmt.Fprintln(f, "# Re-run with --backup-policy <name> to assign a backup policy, or uncomment below.")
+		if len(result.BackupPolicies) > 0 {
+			var names []string
+			for _, bp := range result.BackupPolicies {
+				names = append(names, bp.DisplayName)
+			}
+			fmt.Fprintf(f, "# Discovered policies: %s\n", strings.Join(names, ", "))
+		}
+	} else {
+		fmt.Fprintf(f, "# ── Backup Policy: %s ──────────────────────────────────────────────────────\n", policyName)
+	}
+	if opts.AlwaysFree {
+		fmt.Fprintln(f, "# Always-free tier includes 5 volume backups in total")
+	}
+	fmt.Fprintln(f, "")
+
+	ref, discovered := backupPolicyRef(result, policyName)
+	if !discovered {
+		p(f, `data "oci_core_volume_backup_policies" "selected" {`)
+		p(f, "  filter {")
+		p(f, `    name   = "display_name"`)
+		p(f, fmt.Sprintf("    values = [%q]", policyName))
+		p(f, "  }")
+		p(f, "}")
+		p(f, "")
+	}
+	p(f, fmt.Sprintf(`resource "oci_core_volume_backup_policy_assignment" "%s_boot" {`, instance))
+	p(f, fmt.Sprintf("  asset_id  = oci_core_instance.%s.boot_volume_id", instance))
+	p(f, fmt.Sprintf("  policy_id = %s", ref))
+	p(f, "}")
+	p(f, "")
+	p(f, fmt.Sprintf(`resource "oci_core_volume_backup_policy_assignment" "%s_data" {`, instance))
+	p(f, fmt.Sprintf("  asset_id  = oci_core_volume.%s_data.id", instance))
+	p(f, fmt.Sprintf("  policy_id = %s", ref))
+	p(f, "}")
+}
+
+// exampleImageKey returns the data.tf image data source used by the example
+// instance: Ubuntu (aarch64 in always-free mode for A1.Flex), then any aarch64
+// image in always-free mode, then the first image. It returns "" without images.
+func exampleImageKey(result *discovery.Result, alwaysFree bool) string {
+	for _, img := range result.Images {
+		if img.OS == "Canonical Ubuntu" && (!alwaysFree || strings.Contains(strings.ToLower(img.OSVersion), "aarch64")) {
+			return toTFName(img.OS + "_" + img.OSVersion)
+		}
+	}
+	if alwaysFree {
+		for _, img := range result.Images {
+			if strings.Contains(strings.ToLower(img.OSVersion), "aarch64") {
+				return toTFName(img.OS + "_" + img.OSVersion)
+			}
+		}
+	}
+	if len(result.Images) > 0 {
+		img := result.Images[0]
+		return toTFName(img.OS + "_" + img.OSVersion)
+	}
+	return ""
+}
+
+// exampleSubnet returns the subnet the example instance launches in: the first
+// public subnet of the first VCN, else its first subnet. It returns nil when no
+// subnet was discovered and the bootstrap subnet from network.tf is used.
+func exampleSubnet(result *discovery.Result) *discovery.Subnet {
+	if len(result.VCNs) == 0 || len(result.VCNs[0].Subnets) == 0 {
+		return nil
+	}
+	subnets := result.VCNs[0].Subnets
+	for i := range subnets {
+		if subnets[i].IsPublic {
+			return &subnets[i]
+		}
+	}
+	return &subnets[0]
+}
+
+// writeCapacityReport writes the A1.Flex capacity report as comments and returns
+// the availability domain expression and fault domain to place the instance in.
+// Without a selection it falls back to local.ad_1.
+func writeCapacityReport(f *os.File, result *discovery.Result, report *discovery.CapacityReport) (ad, faultDomain string) {
+	fmt.Fprintf(f, "# A1.Flex capacity for %g OCPU / %g GB at discovery time:\n", report.OCPUs, report.MemoryGB)
+	for _, d := range report.Domains {
+		domain := d.AvailabilityDomain
+		if d.FaultDomain != "" {
+			domain += " " + d.FaultDomain
+		}
+		status := d.Status
+		if d.Status == "AVAILABLE" {
+			status = fmt.Sprintf("%s (%d)", d.Status, d.AvailableCount)
+		}
+		fmt.Fprintf(f, "#   %-40s %s\n", domain, status)
+	}
+
+	ad = "local.ad_1"
+	for i, a := range result.AvailabilityDomains {
+		if report.SelectedAD != "" && a.Name == report.SelectedAD {
+			ad = fmt.Sprintf("local.ad_%d", i+1)
+			break
+		}
+	}
+	if report.SelectedAD == "" {
+		fmt.Fprintln(f, "#")
+		fmt.Fprintln(f, "# WARNING: no availability domain reported capacity. Applying will likely")
+		fmt.Fprintln(f, "#          fail with 'Out of host capacity'; re-run later or reduce the size.")
+		return ad, ""
+	}
+	fmt.Fprintln(f, "#")
+	fmt.Fprintln(f, "# Placed in the domain with the most capacity. Capacity changes constantly;")
+	fmt.Fprintln(f, "# re-run oci-tf-bootstrap to refresh the selection if apply fails.")
+	return ad, report.SelectedFaultDomain
+}
+
+// writeAlwaysFreeInstance writes the always-free instance and returns its
+// internal FQDN, or "" when its subnet has DNS disabled.
+func writeAlwaysFreeInstance(f *os.File, result *discovery.Result, opts Options) string {
 	fmt.Fprintln(f, "# Always-Free Tier Instance Example")
 	fmt.Fprintln(f, "#")
 	fmt.Fprintln(f, "# Free tier limits for VM.Standard.A1.Flex (ARM):")
@@ -72,38 +252,33 @@ func writeAlwaysFreeInstance(f *os.File, result *discovery.Result) {
 	fmt.Fprintln(f, "#")
 	fmt.Fprintln(f, "# Boot volume is included in the 200GB free block storage limit")
 	fmt.Fprintln(f, "#")
-	fmt.Fprintln(f, "# WARNING: A1.Flex capacity varies by AD. If you get 'Out of Capacity' errors,")
-	fmt.Fprintln(f, "#          try changing availability_domain to ad_2 or ad_3 below.")
-	fmt.Fprintln(f, "")
 
-	// Find ARM64 Ubuntu image first, then fall back to any ARM64 image
-	var imageKey string
-	for _, img := range result.Images {
-		version := strings.ToLower(img.OSVersion)
-		if img.OS == "Canonical Ubuntu" && strings.Contains(version, "aarch64") {
-			imageKey = toTFName(img.OS + "_" + img.OSVersion)
+	// Check if A1.Flex is available
+	hasA1Flex := false
+	for _, s := range result.Shapes {
+		if s.Name == "VM.Standard.A1.Flex" {
+			hasA1Flex = true
 			break
 		}
 	}
-	// Fallback to any aarch64 image
-	if imageKey == "" {
-		for _, img := range result.Images {
-			version := strings.ToLower(img.OSVersion)
-			if strings.Contains(version, "aarch64") {
-				imageKey = toTFName(img.OS + "_" + img.OSVersion)
-				break
-			}
-		}
-	}
-	// Final fallback to first image
-	if imageKey == "" && len(result.Images) > 0 {
-		img := result.Images[0]
-		imageKey = toTFName(img.OS + "_" + img.OSVersion)
+
+	ad, faultDomain := "local.ad_1", ""
+	if report := result.A1Capacity; hasA1Flex && report != nil {
+		ad, faultDomain = writeCapacityReport(f, result, report)
+	} else {
+		fmt.Fprintln(f, "# WARNING: A1.Flex capacity varies by AD. If you get 'Out of Capacity' errors,")
+		fmt.Fprintln(f, "#          try changing availability_domain to ad_2 or ad_3 below.")
 	}
+	fmt.Fprintln(f, "")
+
+	imageKey := exampleImageKey(result, true)
 
 	fmt.Fprintln(f, `resource "oci_core_instance" "always_free" {`)
 	fmt.Fprintln(f, "  compartment_id      = local.compartment_ocid")
-	fmt.Fprintln(f, "  availability_domain = local.ad_1")
+	fmt.Fprintf(f, "  availability_domain = %s\n", ad)
+	if faultDomain != "" {
+		fmt.Fprintf(f, "  fault_domain        = %q\n", faultDomain)
+	}
 	fmt.Fprintln(f, `  display_name        = "always-free-arm"`)
 	fmt.Fprintln(f, "")
 
@@ -116,15 +291,6 @@ func writeAlwaysFreeInstance(f *os.File, result *discovery.Result) {
 		fmt.Fprintln(f, "")
 	}
 
-	// Check if A1.Flex is available
-	hasA1Flex := false
-	for _, s := range result.Shapes {
-		if s.Name == "VM.Standard.A1.Flex" {
-			hasA1Flex = true
-			break
-		}
-	}
-
 	if hasA1Flex {
 		fmt.Fprintln(f, `  shape = "VM.Standard.A1.Flex"  # ARM-based, always-free eligible`)
 		fmt.Fprintln(f, "")
@@ -142,23 +308,20 @@ func writeAlwaysFreeInstance(f *os.File, result *discovery.Result) {
 	fmt.Fprintln(f, "  create_vnic_details {")
 	fmt.Fprintln(f, "    assign_public_ip = true  # Free for always-free instances")
 
-	if len(result.VCNs) > 0 && len(result.VCNs[0].Subnets) > 0 {
-		// Prefer public subnet for always-free (no NAT gateway needed)
-		var subnetName string
-		for _, s := range result.VCNs[0].Subnets {
-			if s.IsPublic {
-				subnetName = toTFName(s.DisplayName)
-				break
-			}
-		}
-		if subnetName == "" {
-			subnetName = toTFName(result.VCNs[0].Subnets[0].DisplayName)
+	var fqdn string
+	// Prefer public subnet for always-free (no NAT gateway needed)
+	if selectedSubnet := exampleSubnet(result); selectedSubnet != nil {
+		fmt.Fprintf(f, "    subnet_id        = local.subnet_%s\n", toTFName(selectedSubnet.DisplayName))
+		fqdn = internalFQDN("always-free-arm", selectedSubnet.DNSLabel, result.VCNs[0].DNSLabel)
+		if fqdn != "" {
+			fmt.Fprintln(f, `    hostname_label   = "always-free-arm"`)
 		}
-		fmt.Fprintf(f, "    subnet_id        = local.subnet_%s\n", subnetName)
 	} else {
+		fmt.Fprintln(f, `    hostname_label   = "always-free-arm"`)
 		fmt.Fprintln(f, "    # Use the bootstrap subnet from network.tf, or uncomment after creating your own:")
 		fmt.Fprintln(f, "    subnet_id = oci_core_subnet.public.id")
 		fmt.Fprintln(f, "    # subnet_id = local.subnet_<your_subnet_name>")
+		fqdn = internalFQDN("always-free-arm", "public", "bootstrap")
 	}
 
 	fmt.Fprintln(f, "  }")
@@ -176,25 +339,18 @@ func writeAlwaysFreeInstance(f *os.File, result *discovery.Result) {
 		fmt.Fprintln(f, `  #   ssh_authorized_keys = file("~/.ssh/id_rsa.pub")`)
 		fmt.Fprintln(f, "  # }")
 	}
+	writeDefinedTags(f, opts, false)
 	fmt.Fprintln(f, "}")
+	return fqdn
 }
 
-func writeStandardInstance(f *os.File, result *discovery.Result) {
+// writeStandardInstance writes the example instance and returns its internal
+// FQDN, or "" when its subnet has DNS disabled.
+func writeStandardInstance(f *os.File, result *discovery.Result, opts Options) string {
 	fmt.Fprintln(f, "# Example instance using discovered locals and data sources")
 	fmt.Fprintln(f, "")
 
-	var imageKey string
-	for _, img := range result.Images {
-		if img.OS == "Canonical Ubuntu" {
-			imageKey = toTFName(img.OS + "_" + img.OSVersion)
-			break
-		}
-	}
-
-	if imageKey == "" && len(result.Images) > 0 {
-		img := result.Images[0]
-		imageKey = toTFName(img.OS + "_" + img.OSVersion)
-	}
+	imageKey := exampleImageKey(result, false)
 
 	fmt.Fprintln(f, `resource "oci_core_instance" "example" {`)
 	fmt.Fprintln(f, "  compartment_id      = local.compartment_ocid")
@@ -219,19 +375,9 @@ func writeStandardInstance(f *os.File, result *discovery.Result) {
 	fmt.Fprintln(f, "")
 	fmt.Fprintln(f, "  create_vnic_details {")
 
-	if len(result.VCNs) > 0 && len(result.VCNs[0].Subnets) > 0 {
-		// Prefer public subnet for instances that need direct internet access
-		var selectedSubnet *discovery.Subnet
-		for i := range result.VCNs[0].Subnets {
-			if result.VCNs[0].Subnets[i].IsPublic {
-				selectedSubnet = &result.VCNs[0].Subnets[i]
-				break
-			}
-		}
-		if selectedSubnet == nil {
-			selectedSubnet = &result.VCNs[0].Subnets[0]
-		}
-
+	var fqdn string
+	// Prefer public subnet for instances that need direct internet access
+	if selectedSubnet := exampleSubnet(result); selectedSubnet != nil {
 		subnetName := toTFName(selectedSubnet.DisplayName)
 		if selectedSubnet.IsPublic {
 			fmt.Fprintln(f, "    assign_public_ip = true")
@@ -239,11 +385,17 @@ func writeStandardInstance(f *os.File, result *discovery.Result) {
 			fmt.Fprintln(f, "    assign_public_ip = false  # Private subnet; set to true only with a public subnet")
 		}
 		fmt.Fprintf(f, "    subnet_id        = local.subnet_%s  # %s\n", subnetName, subnetType(selectedSubnet.IsPublic))
+		fqdn = internalFQDN("example-instance", selectedSubnet.DNSLabel, result.VCNs[0].DNSLabel)
+		if fqdn != "" {
+			fmt.Fprintln(f, `    hostname_label   = "example-instance"`)
+		}
 	} else {
 		fmt.Fprintln(f, "    assign_public_ip = true")
+		fmt.Fprintln(f, `    hostname_label   = "example-instance"`)
 		fmt.Fprintln(f, "    # Use the bootstrap subnet from network.tf, or uncomment after creating your own:")
 		fmt.Fprintln(f, "    subnet_id = oci_core_subnet.public.id")
 		fmt.Fprintln(f, "    # subnet_id = local.subnet_<your_subnet_name>")
+		fqdn = internalFQDN("example-instance", "public", "bootstrap")
 	}
 
 	fmt.Fprintln(f, "  }")
@@ -260,5 +412,7 @@ func writeStandardInstance(f *os.File, result *discovery.Result) {
 		fmt.Fprintln(f, `  #   ssh_authorized_keys = file("~/.ssh/id_rsa.pub")`)
 		fmt.Fprintln(f, "  # }")
 	}
+	writeDefinedTags(f, opts, false)
 	fmt.Fprintln(f, "}")
+	return fqdn
 }
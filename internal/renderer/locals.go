@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/larsenclose/oci-tf-bootstrap/internal/discovery"
@@ -29,6 +30,20 @@ func (t *nameTracker) unique(base string) string {
 	return name
 }
 
+// volumePerformance describes a volume's performance tier from its VPUs/GB setting.
+func volumePerformance(vpusPerGB int64) string {
+	switch {
+	case vpusPerGB <= 0:
+		return "Lower Cost (0 VPUs/GB)"
+	case vpusPerGB < 20:
+		return fmt.Sprintf("Balanced (%d VPUs/GB)", vpusPerGB)
+	case vpusPerGB < 30:
+		return fmt.Sprintf("Higher Performance (%d VPUs/GB)", vpusPerGB)
+	default:
+		return fmt.Sprintf("Ultra High Performance (%d VPUs/GB)", vpusPerGB)
+	}
+}
+
 // compartmentNode represents a node in the compartment hierarchy
 type compartmentNode struct {
 	comp     discovery.Compartment
@@ -128,6 +143,23 @@ func writeLocals(result *discovery.Result, outputDir string, opts Options) (err
 	}
 	fmt.Fprintln(f, "")
 
+	if len(opts.Tags) > 0 {
+		fmt.Fprintln(f, "  # Defined tags (--tags) applied to every generated resource")
+		keys := make([]string, 0, len(opts.Tags))
+		width := 0
+		for k := range opts.Tags {
+			keys = append(keys, k)
+			width = max(width, len(strconv.Quote(k)))
+		}
+		sort.Strings(keys)
+		fmt.Fprintln(f, "  common_tags = {")
+		for _, k := range keys {
+			fmt.Fprintf(f, "    %-*s = %q\n", width, strconv.Quote(k), opts.Tags[k])
+		}
+		fmt.Fprintln(f, "  }")
+		fmt.Fprintln(f, "")
+	}
+
 	fmt.Fprintln(f, "  # Compartments (hierarchical)")
 	compTracker := newNameTracker()
 	tree := buildCompartmentTree(result.Compartments, result.Tenancy.ID)
@@ -165,15 +197,14 @@ func writeLocals(result *discovery.Result, outputDir string, opts Options) (err
 		fmt.Fprintln(f, "")
 
 		fmt.Fprintln(f, "  # Existing Subnets")
-		subnetTracker := newNameTracker()
+		subnetNames := subnetLocalNames(result)
 		for _, v := range result.VCNs {
 			for _, s := range v.Subnets {
-				name := subnetTracker.unique(s.DisplayName)
 				pubStr := "private"
 				if s.IsPublic {
 					pubStr = "public"
 				}
-				fmt.Fprintf(f, "  subnet_%s = %q  # %s, %s\n", name, s.ID, s.CIDRBlock, pubStr)
+				fmt.Fprintf(f, "  %s = %q  # %s, %s\n", subnetNames[s.ID], s.ID, s.CIDRBlock, pubStr)
 			}
 		}
 		fmt.Fprintln(f, "")
@@ -265,18 +296,146 @@ func writeLocals(result *discovery.Result, outputDir string, opts Options) (err
 	}
 
 	// Block Volumes
+	var totalGB int64
 	if len(result.BlockVolumes) > 0 {
 		fmt.Fprintln(f, "  # Existing Block Volumes")
-		var totalGB int64
 		bvTracker := newNameTracker()
 		for _, bv := range result.BlockVolumes {
 			name := bvTracker.unique(bv.DisplayName)
-			fmt.Fprintf(f, "  blockvol_%s = %q  # %dGB, %s\n", name, bv.ID, bv.SizeGB, bv.AvailabilityDomain)
+			fmt.Fprintf(f, "  blockvol_%s = %q  # %dGB, %s, %s\n", name, bv.ID, bv.SizeGB, bv.AvailabilityDomain, volumePerformance(bv.VPUsPerGB))
 			totalGB += bv.SizeGB
 		}
-		fmt.Fprintf(f, "  # Total block storage: %dGB\n", totalGB)
+		fmt.Fprintln(f, "")
+	}
+
+	// Boot Volumes
+	if len(result.BootVolumes) > 0 {
+		fmt.Fprintln(f, "  # Existing Boot Volumes")
+		bootTracker := newNameTracker()
+		for _, bv := range result.BootVolumes {
+			name := bootTracker.unique(bv.DisplayName)
+			fmt.Fprintf(f, "  bootvol_%s = %q  # %dGB, %s, %s\n", name, bv.ID, bv.SizeGB, bv.AvailabilityDomain, volumePerformance(bv.VPUsPerGB))
+			totalGB += bv.SizeGB
+		}
+		fmt.Fprintln(f, "")
+	}
+
+	if len(result.BlockVolumes) > 0 || len(result.BootVolumes) > 0 {
+		fmt.Fprintf(f, "  # Total block storage (boot + block volumes): %dGB\n", totalGB)
 		if opts.AlwaysFree {
 			fmt.Fprintln(f, "  # WARNING: Always-free tier limit is 200GB total (boot + block volumes)")
+			if limit := int64(discovery.DefaultAlwaysFreeResources().BlockStorageGB); totalGB > limit {
+				fmt.Fprintf(f, "  # WARNING: Existing volumes already exceed the free limit by %dGB\n", totalGB-limit)
+			}
+		}
+		fmt.Fprintln(f, "")
+	}
+
+	// Volume Groups
+	if len(result.VolumeGroups) > 0 {
+		fmt.Fprintln(f, "  # Existing Volume Groups")
+		vgTracker := newNameTracker()
+		for _, vg := range result.VolumeGroups {
+			name := vgTracker.unique(vg.DisplayName)
+			fmt.Fprintf(f, "  volgroup_%s = %q  # %d volumes, %dGB, %s\n", name, vg.ID, len(vg.VolumeIDs), vg.SizeGB, vg.AvailabilityDomain)
+		}
+		fmt.Fprintln(f, "")
+	}
+
+	// Backup Policies
+	if len(result.BackupPolicies) > 0 {
+		fmt.Fprintln(f, "  # Volume Backup Policies")
+		bpTracker := newNameTracker()
+		for _, bp := range result.BackupPolicies {
+			name := bpTracker.unique(bp.DisplayName)
+			origin := "custom"
+			if bp.IsOracleDefined {
+				origin = "Oracle-defined"
+			}
+			fmt.Fprintf(f, "  backup_policy_%s = %q  # %s, %d schedules\n", name, bp.ID, origin, len(bp.Schedules))
+		}
+		fmt.Fprintln(f, "")
+	}
+
+	// IAM
+	if len(result.Groups) > 0 {
+		fmt.Fprintln(f, "  # IAM Groups")
+		groupTracker := newNameTracker()
+		for _, g := range result.Groups {
+			name := groupTracker.unique(g.Name)
+			fmt.Fprintf(f, "  group_%s = %q\n", name, g.ID)
+		}
+		fmt.Fprintln(f, "")
+	}
+
+	if len(result.DynamicGroups) > 0 {
+		fmt.Fprintln(f, "  # Dynamic Groups")
+		dgTracker := newNameTracker()
+		for _, g := range result.DynamicGroups {
+			name := dgTracker.unique(g.Name)
+			fmt.Fprintf(f, "  dynamic_group_%s = %q\n", name, g.ID)
+		}
+		fmt.Fprintln(f, "")
+	}
+
+	if len(result.Policies) > 0 {
+		fmt.Fprintln(f, "  # IAM Policies (statements listed in iam_report.md)")
+		policyTracker := newNameTracker()
+		for _, p := range result.Policies {
+			name := policyTracker.unique(p.Name)
+			fmt.Fprintf(f, "  policy_%s = %q  # %s, %d statements\n", name, p.ID, p.CompartmentName, len(p.Statements))
+		}
+		fmt.Fprintln(f, "")
+	}
+
+	// Tags
+	if len(result.TagNamespaces) > 0 {
+		fmt.Fprintln(f, "  # Tag Namespaces")
+		nsTracker := newNameTracker()
+		for _, ns := range result.TagNamespaces {
+			name := nsTracker.unique(ns.Name)
+			var defs []string
+			for _, d := range ns.Definitions {
+				if d.IsRetired {
+					continue
+				}
+				if len(d.AllowedValues) > 0 {
+					defs = append(defs, fmt.Sprintf("%s (%s)", d.Name, strings.Join(d.AllowedValues, "|")))
+				} else {
+					defs = append(defs, d.Name)
+				}
+			}
+			comment := fmt.Sprintf("%d tags", len(defs))
+			if len(defs) > 0 {
+				comment += ": " + strings.Join(defs, ", ")
+			}
+			if ns.IsRetired {
+				comment = "retired"
+			}
+			fmt.Fprintf(f, "  tag_namespace_%s = %q  # %s\n", name, ns.ID, comment)
+		}
+		var required []string
+		for _, d := range result.TagDefaults {
+			if d.IsRequired {
+				required = append(required, d.Namespace+"."+d.Key)
+			}
+		}
+		if len(required) > 0 {
+			fmt.Fprintf(f, "  # Required tags (supply with --tags): %s\n", strings.Join(required, ", "))
+		}
+		fmt.Fprintln(f, "")
+	}
+
+	// OKE Clusters
+	if len(result.OKEClusters) > 0 {
+		fmt.Fprintln(f, "  # ── OKE Clusters ─────────────────────────────────────────────────────────")
+		for i, name := range okeClusterLocalNames(result.OKEClusters) {
+			c := result.OKEClusters[i]
+			endpoint := "private endpoint"
+			if c.IsPublicEndpoint {
+				endpoint = "public endpoint"
+			}
+			fmt.Fprintf(f, "  %s = %q  # %s, %s, %d node pools\n", name, c.ID, okeKubernetesVersion(c.KubernetesVersion), endpoint, len(c.NodePools))
 		}
 		fmt.Fprintln(f, "")
 	}
@@ -295,6 +454,37 @@ func writeLocals(result *discovery.Result, outputDir string, opts Options) (err
 		fmt.Fprintln(f, "")
 	}
 
+	writeDNSLocals(f, result)
+	writeObservabilityLocals(f, result)
+	writeBudgetLocals(f, result)
+
+	// Container Registry
+	if reg := result.Registry; reg != nil {
+		fmt.Fprintln(f, "  # ── Container Registry (OCIR) ────────────────────────────────────────────")
+		fmt.Fprintf(f, "  # docker login %s -u '%s/<username>'\n", reg.Endpoint, reg.Namespace)
+		fmt.Fprintf(f, "  tenancy_namespace = %q\n", reg.Namespace)
+		fmt.Fprintf(f, "  ocir_registry     = %q\n", reg.URL())
+		for i, name := range ocirRepoLocalNames(reg.Repositories) {
+			r := reg.Repositories[i]
+			visibility := "private"
+			if r.IsPublic {
+				visibility = "public"
+			}
+			fmt.Fprintf(f, "  %s = %q  # %d images, %s\n", name, reg.URL()+"/"+r.Name, r.ImageCount, visibility)
+		}
+		fmt.Fprintln(f, "")
+	}
+
+	// Functions Applications
+	if len(result.FunctionsApplications) > 0 {
+		fmt.Fprintln(f, "  # ── Functions Applications ───────────────────────────────────────────────")
+		for i, name := range functionsAppLocalNames(result.FunctionsApplications) {
+			a := result.FunctionsApplications[i]
+			fmt.Fprintf(f, "  %s = %q  # %s, %d subnets\n", name, a.ID, a.Shape, len(a.SubnetIDs))
+		}
+		fmt.Fprintln(f, "")
+	}
+
 	fmt.Fprintln(f, "}")
 	return nil
 }
@@ -40,6 +40,7 @@ func writeNetwork(result *discovery.Result, outputDir string, opts Options) (err
 	fmt.Fprintln(f, `  cidr_blocks    = ["10.0.0.0/16"]`)
 	fmt.Fprintln(f, `  display_name   = "bootstrap-vcn"`)
 	fmt.Fprintln(f, `  dns_label      = "bootstrap"`)
+	writeDefinedTags(f, opts, false)
 	fmt.Fprintln(f, "}")
 	fmt.Fprintln(f, "")
 
@@ -49,6 +50,7 @@ func writeNetwork(result *discovery.Result, outputDir string, opts Options) (err
 	fmt.Fprintln(f, "  vcn_id         = oci_core_vcn.main.id")
 	fmt.Fprintln(f, `  display_name   = "bootstrap-igw"`)
 	fmt.Fprintln(f, "  enabled        = true")
+	writeDefinedTags(f, opts, false)
 	fmt.Fprintln(f, "}")
 	fmt.Fprintln(f, "")
 
@@ -63,6 +65,7 @@ func writeNetwork(result *discovery.Result, outputDir string, opts Options) (err
 	fmt.Fprintln(f, `    destination_type  = "CIDR_BLOCK"`)
 	fmt.Fprintln(f, "    network_entity_id = oci_core_internet_gateway.main.id")
 	fmt.Fprintln(f, "  }")
+	writeDefinedTags(f, opts, false)
 	fmt.Fprintln(f, "}")
 	fmt.Fprintln(f, "")
 
@@ -104,6 +107,7 @@ func writeNetwork(result *discovery.Result, outputDir string, opts Options) (err
 	fmt.Fprintln(f, "      type = 8")
 	fmt.Fprintln(f, "    }")
 	fmt.Fprintln(f, "  }")
+	writeDefinedTags(f, opts, false)
 	fmt.Fprintln(f, "}")
 	fmt.Fprintln(f, "")
 
@@ -117,6 +121,7 @@ func writeNetwork(result *discovery.Result, outputDir string, opts Options) (err
 	fmt.Fprintln(f, "  route_table_id             = oci_core_route_table.public.id")
 	fmt.Fprintln(f, "  security_list_ids          = [oci_core_security_list.public.id]")
 	fmt.Fprintln(f, "  prohibit_public_ip_on_vnic = false")
+	writeDefinedTags(f, opts, false)
 	fmt.Fprintln(f, "}")
 	fmt.Fprintln(f, "")
 
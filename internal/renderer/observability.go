@@ -0,0 +1,199 @@
+package renderer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/larsenclose/oci-tf-bootstrap/internal/discovery"
+)
+
+// instanceAlarm is a metric alarm written for the example instance.
+type instanceAlarm struct {
+	name      string // Resource name suffix, e.g. "cpu"
+	metric    string // oci_computeagent metric
+	threshold int    // Percent
+	label     string // Human-readable metric name for the alarm body
+}
+
+var instanceAlarms = []instanceAlarm{
+	{"cpu", "CpuUtilization", 80, "CPU"},
+	{"memory", "MemoryUtilization", 90, "Memory"},
+}
+
+// writeObservabilityLocals writes the log group, topic and alarm sections of locals.tf.
+func writeObservabilityLocals(f *os.File, result *discovery.Result) {
+	if len(result.LogGroups) > 0 {
+		fmt.Fprintln(f, "  # ── Log Groups ───────────────────────────────────────────────────────────")
+		tracker := newNameTracker()
+		for _, lg := range result.LogGroups {
+			fmt.Fprintf(f, "  log_group_%s = %q\n", tracker.unique(lg.Name), lg.ID)
+		}
+		fmt.Fprintln(f, "")
+	}
+
+	if len(result.NotificationTopics) > 0 {
+		fmt.Fprintln(f, "  # ── Notification Topics ──────────────────────────────────────────────────")
+		tracker := newNameTracker()
+		for _, t := range result.NotificationTopics {
+			fmt.Fprintf(f, "  ons_topic_%s = %q\n", tracker.unique(t.Name), t.ID)
+		}
+		fmt.Fprintln(f, "")
+	}
+
+	if len(result.Alarms) > 0 {
+		fmt.Fprintln(f, "  # ── Alarms ───────────────────────────────────────────────────────────────")
+		tracker := newNameTracker()
+		for _, a := range result.Alarms {
+			state := "enabled"
+			if !a.IsEnabled {
+				state = "disabled"
+			}
+			fmt.Fprintf(f, "  alarm_%s = %q  # %s, %s, %s\n", tracker.unique(a.Name), a.ID, a.Severity, a.Namespace, state)
+		}
+		fmt.Fprintln(f, "")
+	}
+}
+
+// writeObservability generates observability.tf: a log group with flow logs for
+// every discovered subnet, an alert topic with an email subscription, and CPU
+// and memory alarms on the example instance.
+func writeObservability(result *discovery.Result, outputDir string, opts Options) (err error) {
+	f, err := os.Create(filepath.Join(outputDir, "observability.tf")) // #nosec G304 -- outputDir is user-specified CLI flag
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	instance, host := "example", "example-instance"
+	if opts.AlwaysFree {
+		instance, host = "always_free", "always-free-arm"
+	}
+
+	fmt.Fprintln(f, "# Generated by oci-tf-bootstrap")
+	fmt.Fprintln(f, "# Observability: VCN flow logs, an alert topic and instance alarms")
+	fmt.Fprintln(f, "#")
+	fmt.Fprintln(f, "# ONS emails a confirmation link to var.alert_email; alarms are not")
+	fmt.Fprintln(f, "# delivered until it is confirmed. Alarms need the Compute Instance")
+	fmt.Fprintln(f, "# Monitoring plugin of the Oracle Cloud Agent (enabled on platform images).")
+	if opts.AlwaysFree {
+		fmt.Fprintln(f, "#")
+		fmt.Fprintln(f, "# Always-Free Tier Notes:")
+		fmt.Fprintln(f, "#   - Logging: 10GB of log storage per month is free")
+		fmt.Fprintln(f, "#   - Notifications: 1,000 emails per month are free")
+		fmt.Fprintln(f, "#   - Monitoring: 500 million ingested datapoints per month are free")
+	}
+	if len(result.LogGroups) > 0 || len(result.NotificationTopics) > 0 {
+		fmt.Fprintln(f, "#")
+		fmt.Fprintln(f, "# Existing log groups and topics are listed in locals.tf and can replace")
+		fmt.Fprintln(f, "# the ones created below.")
+	}
+	fmt.Fprintln(f, "")
+
+	fmt.Fprintln(f, `variable "alert_email" {`)
+	fmt.Fprintln(f, `  description = "Email address subscribed to the alert topic"`)
+	fmt.Fprintln(f, "  type        = string")
+	fmt.Fprintln(f, "}")
+	fmt.Fprintln(f, "")
+
+	fmt.Fprintln(f, "# ── Flow Logs ──────────────────────────────────────────────────────────────")
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, `resource "oci_logging_log_group" "bootstrap" {`)
+	fmt.Fprintln(f, "  compartment_id = local.compartment_ocid")
+	fmt.Fprintln(f, `  display_name   = "bootstrap-logs"`)
+	fmt.Fprintln(f, `  description    = "VCN flow logs generated by oci-tf-bootstrap"`)
+	writeDefinedTags(f, opts, false)
+	fmt.Fprintln(f, "}")
+
+	subnets := flowLogSubnets(result)
+	for _, s := range subnets {
+		fmt.Fprintln(f, "")
+		fmt.Fprintf(f, "resource \"oci_logging_log\" %q {\n", "flow_"+s.name)
+		fmt.Fprintf(f, "  display_name       = %q\n", strings.ReplaceAll(s.name, "_", "-")+"-flow")
+		fmt.Fprintln(f, "  log_group_id       = oci_logging_log_group.bootstrap.id")
+		fmt.Fprintln(f, `  log_type           = "SERVICE"`)
+		fmt.Fprintln(f, "  is_enabled         = true")
+		fmt.Fprintln(f, "  retention_duration = 30  # Days")
+		fmt.Fprintln(f, "")
+		fmt.Fprintln(f, "  configuration {")
+		fmt.Fprintln(f, "    source {")
+		fmt.Fprintln(f, `      category    = "all"`)
+		fmt.Fprintf(f, "      resource    = %s\n", s.ref)
+		fmt.Fprintln(f, `      service     = "flowlogs"`)
+		fmt.Fprintln(f, `      source_type = "OCISERVICE"`)
+		fmt.Fprintln(f, "    }")
+		fmt.Fprintln(f, "  }")
+		writeDefinedTags(f, opts, false)
+		fmt.Fprintln(f, "}")
+	}
+	fmt.Fprintln(f, "")
+
+	fmt.Fprintln(f, "# ── Notifications ──────────────────────────────────────────────────────────")
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, `resource "oci_ons_notification_topic" "alerts" {`)
+	fmt.Fprintln(f, "  compartment_id = local.compartment_ocid")
+	fmt.Fprintln(f, `  name           = "bootstrap-alerts"  # Topic names are unique per tenancy`)
+	fmt.Fprintln(f, `  description    = "Alarm notifications for the example instance"`)
+	writeDefinedTags(f, opts, false)
+	fmt.Fprintln(f, "}")
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, `resource "oci_ons_subscription" "alerts_email" {`)
+	fmt.Fprintln(f, "  compartment_id = local.compartment_ocid")
+	fmt.Fprintln(f, "  topic_id       = oci_ons_notification_topic.alerts.id")
+	fmt.Fprintln(f, `  protocol       = "EMAIL"`)
+	fmt.Fprintln(f, "  endpoint       = var.alert_email")
+	writeDefinedTags(f, opts, false)
+	fmt.Fprintln(f, "}")
+	fmt.Fprintln(f, "")
+
+	fmt.Fprintln(f, "# ── Instance Alarms ────────────────────────────────────────────────────────")
+	for _, a := range instanceAlarms {
+		fmt.Fprintln(f, "")
+		fmt.Fprintf(f, "resource \"oci_monitoring_alarm\" \"%s_%s\" {\n", instance, a.name)
+		fmt.Fprintln(f, "  compartment_id        = local.compartment_ocid")
+		fmt.Fprintln(f, "  metric_compartment_id = local.compartment_ocid")
+		fmt.Fprintf(f, "  display_name          = \"%s-%s-high\"\n", host, a.name)
+		fmt.Fprintln(f, `  namespace             = "oci_computeagent"`)
+		fmt.Fprintf(f, "  query                 = \"%s[1m]{resourceId = \\\"${oci_core_instance.%s.id}\\\"}.mean() > %d\"\n", a.metric, instance, a.threshold)
+		fmt.Fprintln(f, `  severity              = "CRITICAL"`)
+		fmt.Fprintln(f, `  pending_duration      = "PT5M"`)
+		fmt.Fprintf(f, "  body                  = \"%s above %d%% for 5 minutes on %s\"\n", a.label, a.threshold, host)
+		fmt.Fprintln(f, "  destinations          = [oci_ons_notification_topic.alerts.id]")
+		fmt.Fprintln(f, "  is_enabled            = true")
+		writeDefinedTags(f, opts, false)
+		fmt.Fprintln(f, "}")
+	}
+
+	return nil
+}
+
+// flowLogSubnet is a subnet that gets a flow log.
+type flowLogSubnet struct {
+	name string // Terraform name, e.g. "public_subnet"
+	ref  string // Terraform expression for the subnet OCID
+}
+
+// flowLogSubnets returns every discovered subnet, or the bootstrap subnet from
+// network.tf when no VCN exists.
+func flowLogSubnets(result *discovery.Result) []flowLogSubnet {
+	names := subnetLocalNames(result)
+	var subnets []flowLogSubnet
+	for _, v := range result.VCNs {
+		for _, s := range v.Subnets {
+			local := names[s.ID]
+			subnets = append(subnets, flowLogSubnet{
+				name: strings.TrimPrefix(local, "subnet_"),
+				ref:  "local." + local,
+			})
+		}
+	}
+	if len(result.VCNs) == 0 {
+		subnets = append(subnets, flowLogSubnet{name: "public", ref: "oci_core_subnet.public.id"})
+	}
+	return subnets
+}
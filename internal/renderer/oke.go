@@ -20,32 +20,7 @@ type okeVersionGroup struct {
 // compareVersions compares two semver strings (e.g. "v1.32.10" vs "v1.31.10").
 // Returns negative if a < b, zero if equal, positive if a > b.
 func compareVersions(a, b string) int {
-	partsA := strings.Split(strings.TrimPrefix(a, "v"), ".")
-	partsB := strings.Split(strings.TrimPrefix(b, "v"), ".")
-
-	for i := 0; i < len(partsA) && i < len(partsB); i++ {
-		// Compare numerically by padding with zeros
-		pa := partsA[i]
-		pb := partsB[i]
-		// Simple numeric comparison via string padding
-		maxLen := len(pa)
-		if len(pb) > maxLen {
-			maxLen = len(pb)
-		}
-		for len(pa) < maxLen {
-			pa = "0" + pa
-		}
-		for len(pb) < maxLen {
-			pb = "0" + pb
-		}
-		if pa < pb {
-			return -1
-		}
-		if pa > pb {
-			return 1
-		}
-	}
-	return len(partsA) - len(partsB)
+	return discovery.CompareKubernetesVersions(a, b)
 }
 
 // groupOKEImagesByVersion groups OKE images by Kubernetes version and architecture,
@@ -114,14 +89,22 @@ func writeOKEExample(result *discovery.Result, outputDir string, opts Options) (
 		}
 	}()
 
+	groups := groupOKEImagesByVersion(result.OKEImages)
+
+	target := okeTargetFor(result, opts)
+	shapes := chooseOKEShapes(result.Shapes)
+
 	fmt.Fprintln(f, "# Generated by oci-tf-bootstrap")
-	fmt.Fprintln(f, "# OKE (Oracle Kubernetes Engine) Node Pool Examples")
+	fmt.Fprintln(f, "# OKE (Oracle Kubernetes Engine) Cluster and Node Pools")
 	fmt.Fprintln(f, "#")
-	fmt.Fprintln(f, "# These are REFERENCE configurations. Before using, you must:")
-	fmt.Fprintln(f, "#   1. Create an OKE cluster (or reference an existing one)")
-	fmt.Fprintln(f, "#   2. Replace PLACEHOLDER_CLUSTER_OCID with your cluster's OCID")
-	fmt.Fprintln(f, "#   3. Update subnet references to match your network configuration")
-	fmt.Fprintln(f, "#   4. Adjust node counts and shape configurations as needed")
+	if len(result.OKEClusters) > 0 {
+		fmt.Fprintln(f, "# Node pools attach to the existing cluster and worker subnet discovered in")
+		fmt.Fprintln(f, "# this compartment. Node pool versions never exceed the cluster version.")
+	} else {
+		fmt.Fprintln(f, "# Creates a cluster with its own VCN, subnets and security rules, then")
+		fmt.Fprintln(f, "# node pools on the worker subnet. Applies cleanly on a blank tenancy.")
+	}
+	fmt.Fprintln(f, "# Adjust node counts and shape configurations as needed.")
 
 	if opts.AlwaysFree {
 		fmt.Fprintln(f, "#")
@@ -135,23 +118,29 @@ func writeOKEExample(result *discovery.Result, outputDir string, opts Options) (
 
 	fmt.Fprintln(f, "")
 
-	groups := groupOKEImagesByVersion(result.OKEImages)
+	if len(result.OKEClusters) > 0 {
+		writeExistingClusterHeader(f, result, target)
+	} else {
+		writeOKEStack(f, opts, okeClusterVersion(result), groups[0].version, result.OKESupportedVersions)
+	}
 
+	active := activeOKEGroup(groups, target)
 	for i, g := range groups {
-		isLatest := i == 0
-		commented := !isLatest
+		commented := i != active
 
-		writeVersionHeader(f, g, isLatest, commented)
+		writeVersionHeader(f, g, i == 0, commented)
 
-		if g.arm != nil {
-			writeARMNodePool(f, g, opts, commented)
+		if g.arm != nil && shapes.arm != "" {
+			writeARMNodePool(f, g, target, shapes.arm, opts, commented)
 		}
 
-		if g.x86 != nil {
-			writeX86NodePool(f, g, opts, commented)
+		if g.x86 != nil && shapes.x86 != "" {
+			writeX86NodePool(f, g, target, shapes.x86, opts, commented)
 		}
 	}
 
+	writeVirtualNodePool(f, target, shapes.pod, opts)
+
 	fmt.Fprintln(f, "# ── Adding More Node Pools ──────────────────────────────────────────────────")
 	fmt.Fprintln(f, "#")
 	fmt.Fprintln(f, "# To add more node pools, copy one of the resource blocks above and change:")
@@ -163,11 +152,54 @@ func writeOKEExample(result *discovery.Result, outputDir string, opts Options) (
 	return nil
 }
 
+// okeTargetFor returns the existing cluster node pools attach to, or the
+// cluster generated by writeOKEStack when none was discovered.
+func okeTargetFor(result *discovery.Result, opts Options) okeTarget {
+	if len(result.OKEClusters) > 0 {
+		return existingOKETarget(result)
+	}
+	version := okeClusterVersion(result)
+	return okeTarget{
+		clusterID:    "oci_containerengine_cluster.oke.id",
+		subnetID:     "oci_core_subnet.oke_workers.id",
+		maxVersion:   version,
+		vcnNative:    opts.VirtualNodes,
+		virtualNodes: opts.VirtualNodes,
+		enhanced:     opts.VirtualNodes,
+		noCluster:    version == "",
+	}
+}
+
+// activeOKEGroup returns the index of the newest version group the cluster can
+// run, or -1 when none can. Every other version is written commented out for
+// reference.
+func activeOKEGroup(groups []okeVersionGroup, target okeTarget) int {
+	if target.subnetID == "" || target.noCluster {
+		return -1
+	}
+	for i, g := range groups {
+		if target.maxVersion == "" || compareVersions(g.version, target.maxVersion) <= 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// virtualNodePoolActive reports whether writeVirtualNodePool writes an
+// uncommented pool.
+func virtualNodePoolActive(target okeTarget, podShape string) bool {
+	return target.virtualNodes && target.subnetID != "" && !target.noCluster && podShape != ""
+}
+
 func writeVersionHeader(f *os.File, g okeVersionGroup, isLatest bool, commented bool) {
 	p := lineWriter(commented)
 
-	if isLatest {
-		fmt.Fprintf(f, "\n# ── Kubernetes %s (latest) ─────────────────────────────────────────────\n", g.version)
+	if !commented {
+		label := ""
+		if isLatest {
+			label = " (latest)"
+		}
+		fmt.Fprintf(f, "\n# ── Kubernetes %s%s ─────────────────────────────────────────────\n", g.version, label)
 	} else {
 		fmt.Fprintln(f, "")
 		p(f, fmt.Sprintf("# ── Kubernetes %s ─────────────────────────────────────────────────────", g.version))
@@ -176,7 +208,7 @@ func writeVersionHeader(f *os.File, g okeVersionGroup, isLatest bool, commented
 	fmt.Fprintln(f, "")
 }
 
-func writeARMNodePool(f *os.File, g okeVersionGroup, opts Options, commented bool) {
+func writeARMNodePool(f *os.File, g okeVersionGroup, target okeTarget, shape string, opts Options, commented bool) {
 	p := lineWriter(commented)
 	localName := okeLocalName(g.arm)
 
@@ -189,14 +221,14 @@ func writeARMNodePool(f *os.File, g okeVersionGroup, opts Options, commented boo
 		memGB = "12"
 	}
 
-	p(f, fmt.Sprintf(`resource "oci_containerengine_node_pool" "arm_pool_%s" {`, toTFName(g.version)))
+	p(f, fmt.Sprintf(`resource "oci_containerengine_node_pool" "arm_pool_%s" {`, toTFName(okeKubernetesVersion(g.version))))
 	p(f, "  compartment_id     = local.compartment_ocid")
-	p(f, `  cluster_id         = "PLACEHOLDER_CLUSTER_OCID"  # Replace with your OKE cluster OCID`)
-	p(f, fmt.Sprintf("  kubernetes_version = %q", g.version))
+	p(f, fmt.Sprintf("  cluster_id         = %s", target.clusterID))
+	p(f, fmt.Sprintf("  kubernetes_version = %q", okeKubernetesVersion(g.version)))
 	p(f, fmt.Sprintf(`  name               = "arm-pool-%s"`, strings.ReplaceAll(strings.TrimPrefix(g.version, "v"), ".", "-")))
 	p(f, "")
 	p(f, `  # ARM-based shape: best price-performance ratio`)
-	p(f, `  node_shape = "VM.Standard.A1.Flex"`)
+	p(f, fmt.Sprintf("  node_shape = %q", shape))
 	p(f, "  node_shape_config {")
 	p(f, fmt.Sprintf("    ocpus         = %s", ocpus))
 	p(f, fmt.Sprintf("    memory_in_gbs = %s", memGB))
@@ -216,25 +248,26 @@ func writeARMNodePool(f *os.File, g okeVersionGroup, opts Options, commented boo
 	}
 	p(f, "    placement_configs {")
 	p(f, "      availability_domain = local.ad_1")
-	p(f, `      subnet_id           = "PLACEHOLDER_SUBNET_OCID"  # Replace with your worker subnet OCID`)
+	p(f, fmt.Sprintf("      subnet_id           = %s", target.workerSubnet()))
 	p(f, "    }")
-	p(f, "  }")
+	writeNodePoolLifecycle(p, f, target, opts)
+	writeDefinedTags(f, opts, commented)
 	p(f, "}")
 	fmt.Fprintln(f, "")
 }
 
-func writeX86NodePool(f *os.File, g okeVersionGroup, opts Options, commented bool) {
+func writeX86NodePool(f *os.File, g okeVersionGroup, target okeTarget, shape string, opts Options, commented bool) {
 	p := lineWriter(commented)
 	localName := okeLocalName(g.x86)
 
-	p(f, fmt.Sprintf(`resource "oci_containerengine_node_pool" "x86_pool_%s" {`, toTFName(g.version)))
+	p(f, fmt.Sprintf(`resource "oci_containerengine_node_pool" "x86_pool_%s" {`, toTFName(okeKubernetesVersion(g.version))))
 	p(f, "  compartment_id     = local.compartment_ocid")
-	p(f, `  cluster_id         = "PLACEHOLDER_CLUSTER_OCID"  # Replace with your OKE cluster OCID`)
-	p(f, fmt.Sprintf("  kubernetes_version = %q", g.version))
+	p(f, fmt.Sprintf("  cluster_id         = %s", target.clusterID))
+	p(f, fmt.Sprintf("  kubernetes_version = %q", okeKubernetesVersion(g.version)))
 	p(f, fmt.Sprintf(`  name               = "x86-pool-%s"`, strings.ReplaceAll(strings.TrimPrefix(g.version, "v"), ".", "-")))
 	p(f, "")
 	p(f, `  # x86-based shape: broad compatibility`)
-	p(f, `  node_shape = "VM.Standard.E4.Flex"`)
+	p(f, fmt.Sprintf("  node_shape = %q", shape))
 	p(f, "  node_shape_config {")
 	p(f, "    ocpus         = 2")
 	p(f, "    memory_in_gbs = 16")
@@ -249,13 +282,143 @@ func writeX86NodePool(f *os.File, g okeVersionGroup, opts Options, commented boo
 	p(f, "    size = 1  # Number of worker nodes")
 	p(f, "    placement_configs {")
 	p(f, "      availability_domain = local.ad_1")
-	p(f, `      subnet_id           = "PLACEHOLDER_SUBNET_OCID"  # Replace with your worker subnet OCID`)
+	p(f, fmt.Sprintf("      subnet_id           = %s", target.workerSubnet()))
 	p(f, "    }")
+	writeNodePoolLifecycle(p, f, target, opts)
+	writeDefinedTags(f, opts, commented)
+	p(f, "}")
+	fmt.Fprintln(f, "")
+}
+
+// writeNodePoolLifecycle closes node_config_details, adding VCN-native pod
+// networking when the cluster uses it, and writes the eviction settings. On
+// an ENHANCED_CLUSTER it adds node cycling, so image, shape and version
+// changes roll nodes one at a time: with a surge node, or in always-free mode
+// by taking one node down instead, since a surge node would exceed the free
+// A1 allowance.
+func writeNodePoolLifecycle(p func(f *os.File, line string), f *os.File, target okeTarget, opts Options) {
+	if target.vcnNative {
+		p(f, "")
+		p(f, "    node_pool_pod_network_option_details {")
+		p(f, `      cni_type       = "OCI_VCN_IP_NATIVE"`)
+		p(f, fmt.Sprintf("      pod_subnet_ids = [%s]", target.workerSubnet()))
+		p(f, "    }")
+	}
+	p(f, "  }")
+	p(f, "")
+	p(f, "  node_eviction_node_pool_settings {")
+	p(f, `    eviction_grace_duration              = "PT60M"  # Time allowed to drain pods before a node is removed`)
+	p(f, "    is_force_delete_after_grace_duration = true")
+	p(f, "  }")
+	if !target.enhanced {
+		return
+	}
+	surge, unavailable := "1", "0"
+	if opts.AlwaysFree {
+		surge, unavailable = "0", "1"
+	}
+	p(f, "")
+	p(f, "  node_pool_cycling_details {")
+	p(f, "    is_node_cycling_enabled = true  # Replace nodes when the image, shape or version changes")
+	p(f, fmt.Sprintf("    maximum_surge           = %q", surge))
+	p(f, fmt.Sprintf("    maximum_unavailable     = %q", unavailable))
+	p(f, "  }")
+}
+
+// writeVirtualNodePool writes a serverless virtual node pool. It is active only
+// when the cluster supports virtual nodes and a pod shape was discovered.
+func writeVirtualNodePool(f *os.File, target okeTarget, podShape string, opts Options) {
+	commented := !virtualNodePoolActive(target, podShape)
+	p := lineWriter(commented)
+
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "# ── Virtual Node Pool ──────────────────────────────────────────────────────")
+	switch {
+	case podShape == "":
+		fmt.Fprintln(f, "# No compute shape with a virtual node pod shape (E3/E4/A1 Flex) was discovered.")
+	case target.noCluster:
+		fmt.Fprintln(f, "# The cluster above is commented out; uncomment it first.")
+	case !target.virtualNodes:
+		fmt.Fprintln(f, "# Virtual nodes need an ENHANCED_CLUSTER with OCI_VCN_IP_NATIVE pod networking.")
+		fmt.Fprintln(f, "# Re-run with --oke-virtual-nodes to generate one, then uncomment below.")
+	default:
+		fmt.Fprintln(f, "# Serverless nodes: pods are billed per OCPU and GB, with no worker VMs to manage.")
+	}
+	fmt.Fprintln(f, "")
+	if podShape == "" {
+		podShape = "Pod.Standard.E4.Flex"
+	}
+
+	p(f, `resource "oci_containerengine_virtual_node_pool" "virtual" {`)
+	p(f, "  compartment_id = local.compartment_ocid")
+	p(f, fmt.Sprintf("  cluster_id     = %s", target.clusterID))
+	p(f, `  display_name   = "virtual-pool"`)
+	p(f, "  size           = 1  # Number of virtual nodes")
+	p(f, "")
+	p(f, "  placement_configurations {")
+	p(f, "    availability_domain = local.ad_1")
+	p(f, `    fault_domain        = ["FAULT-DOMAIN-1"]`)
+	p(f, fmt.Sprintf("    subnet_id           = %s", target.workerSubnet()))
 	p(f, "  }")
+	p(f, "")
+	p(f, "  pod_configuration {")
+	p(f, fmt.Sprintf("    shape     = %q", podShape))
+	p(f, fmt.Sprintf("    subnet_id = %s", target.workerSubnet()))
+	p(f, "  }")
+	writeDefinedTags(f, opts, commented)
 	p(f, "}")
 	fmt.Fprintln(f, "")
 }
 
+// okeShapes holds the node and pod shapes used by the OKE examples.
+type okeShapes struct {
+	arm string // ARM node shape; empty when not available
+	x86 string // x86 node shape; empty when not available
+	pod string // Virtual node pod shape; empty when not available
+}
+
+// okeX86Shapes lists x86 flexible node shapes in order of preference.
+var okeX86Shapes = []string{"VM.Standard.E5.Flex", "VM.Standard.E4.Flex", "VM.Standard3.Flex", "VM.Standard.E3.Flex"}
+
+// okePodShapes lists virtual node pod shapes in order of preference with the
+// compute shape each runs on.
+var okePodShapes = [][2]string{
+	{"Pod.Standard.E4.Flex", "VM.Standard.E4.Flex"},
+	{"Pod.Standard.E3.Flex", "VM.Standard.E3.Flex"},
+	{"Pod.Standard.A1.Flex", "VM.Standard.A1.Flex"},
+}
+
+// chooseOKEShapes picks node and pod shapes from the discovered compute shapes.
+// When shape discovery returned nothing, the defaults are used unchecked.
+func chooseOKEShapes(shapes []discovery.Shape) okeShapes {
+	if len(shapes) == 0 {
+		return okeShapes{arm: "VM.Standard.A1.Flex", x86: "VM.Standard.E4.Flex", pod: "Pod.Standard.E4.Flex"}
+	}
+
+	available := make(map[string]bool, len(shapes))
+	for _, s := range shapes {
+		available[s.Name] = true
+	}
+
+	var chosen okeShapes
+	if available["VM.Standard.A1.Flex"] {
+		chosen.arm = "VM.Standard.A1.Flex"
+	}
+	for _, name := range okeX86Shapes {
+		if available[name] {
+			chosen.x86 = name
+			break
+		}
+	}
+	for _, pair := range okePodShapes {
+		if available[pair[1]] {
+			chosen.pod = pair[0]
+			break
+		}
+	}
+	return chosen
+}
+
 // lineWriter returns a function that writes a line, optionally prefixed with "# " for commenting out.
 func lineWriter(commented bool) func(f *os.File, line string) {
 	if commented {
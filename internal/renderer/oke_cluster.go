@@ -0,0 +1,426 @@
+package renderer
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/larsenclose/oci-tf-bootstrap/internal/discovery"
+)
+
+// okeTarget is the cluster and worker subnet generated node pools attach to.
+type okeTarget struct {
+	clusterID    string // Terraform expression for cluster_id
+	subnetID     string // Terraform expression for the worker subnet; empty when unknown
+	maxVersion   string // Newest Kubernetes version node pools may run; empty for no limit
+	vcnNative    bool   // Cluster uses OCI_VCN_IP_NATIVE pod networking
+	virtualNodes bool   // Cluster can run virtual node pools (ENHANCED_CLUSTER, VCN-native)
+	enhanced     bool   // Cluster is an ENHANCED_CLUSTER, which node cycling needs
+	noCluster    bool   // The generated cluster is commented out, so node pools are too
+}
+
+func (t okeTarget) workerSubnet() string {
+	if t.subnetID == "" {
+		return `""  # No worker subnet discovered; set your worker subnet OCID`
+	}
+	return t.subnetID
+}
+
+// okeKubernetesVersion normalizes a version to the "v1.31.10" form OKE expects.
+func okeKubernetesVersion(v string) string {
+	return "v" + strings.TrimPrefix(v, "v")
+}
+
+// okeClusterVersion returns the newest Kubernetes version OKE supports that
+// has a node image, for the generated cluster, or "" when there is none.
+func okeClusterVersion(result *discovery.Result) string {
+	for _, g := range groupOKEImagesByVersion(result.OKEImages) {
+		if slices.ContainsFunc(result.OKESupportedVersions, func(v string) bool { return compareVersions(v, g.version) == 0 }) {
+			return g.version
+		}
+	}
+	return ""
+}
+
+// okeClusterLocalNames returns the locals.tf name for each discovered cluster, in order.
+func okeClusterLocalNames(clusters []discovery.OKECluster) []string {
+	tracker := newNameTracker()
+	names := make([]string, len(clusters))
+	for i, c := range clusters {
+		names[i] = "oke_cluster_" + tracker.unique(c.Name)
+	}
+	return names
+}
+
+// subnetLocalNames maps discovered subnet OCIDs to their locals.tf name.
+// Iteration order must match the subnet section of writeLocals.
+func subnetLocalNames(result *discovery.Result) map[string]string {
+	tracker := newNameTracker()
+	names := make(map[string]string)
+	for _, v := range result.VCNs {
+		for _, s := range v.Subnets {
+			names[s.ID] = "subnet_" + tracker.unique(s.DisplayName)
+		}
+	}
+	return names
+}
+
+// existingOKETarget attaches node pools to the first discovered cluster. The
+// worker subnet comes from the cluster's node pools, falling back to a private
+// subnet in the cluster's VCN.
+func existingOKETarget(result *discovery.Result) okeTarget {
+	cluster := result.OKEClusters[0]
+	target := okeTarget{
+		clusterID:  "local." + okeClusterLocalNames(result.OKEClusters)[0],
+		maxVersion: cluster.KubernetesVersion,
+		vcnNative:  cluster.CNIType == "OCI_VCN_IP_NATIVE",
+	}
+	target.enhanced = cluster.Type == "ENHANCED_CLUSTER"
+	target.virtualNodes = target.vcnNative && target.enhanced
+
+	var subnetID string
+	for _, np := range cluster.NodePools {
+		if len(np.SubnetIDs) > 0 {
+			subnetID = np.SubnetIDs[0]
+			break
+		}
+	}
+	if subnetID == "" {
+		for _, v := range result.VCNs {
+			if v.ID != cluster.VCNID {
+				continue
+			}
+			for _, s := range v.Subnets {
+				if !s.IsPublic && s.ID != cluster.EndpointSubnetID {
+					subnetID = s.ID
+					break
+				}
+			}
+		}
+	}
+
+	if name, ok := subnetLocalNames(result)[subnetID]; ok {
+		target.subnetID = "local." + name
+	} else if subnetID != "" {
+		target.subnetID = fmt.Sprintf("%q", subnetID)
+	}
+	return target
+}
+
+// writeExistingClusterHeader describes the discovered cluster node pools attach to.
+func writeExistingClusterHeader(f *os.File, result *discovery.Result, target okeTarget) {
+	cluster := result.OKEClusters[0]
+	endpoint := "private endpoint"
+	if cluster.IsPublicEndpoint {
+		endpoint = "public endpoint"
+	}
+
+	fmt.Fprintf(f, "# ── Existing Cluster: %s ────────────────────────────────────────────────\n", cluster.Name)
+	fmt.Fprintf(f, "# %s, %s, %d node pools\n", okeKubernetesVersion(cluster.KubernetesVersion), endpoint, len(cluster.NodePools))
+	for _, np := range cluster.NodePools {
+		fmt.Fprintf(f, "#   %s: %d x %s (%s)\n", np.Name, np.Size, np.NodeShape, okeKubernetesVersion(np.KubernetesVersion))
+	}
+	if len(result.OKEClusters) > 1 {
+		fmt.Fprintf(f, "# %d clusters discovered; node pools below attach to the first. See locals.tf for the rest.\n", len(result.OKEClusters))
+	}
+	if target.subnetID == "" {
+		fmt.Fprintln(f, "# No worker subnet found in the cluster's VCN; the node pools below are commented out.")
+	}
+	fmt.Fprintln(f, "")
+}
+
+// okeRule is a security list rule from the OKE flannel overlay network documentation.
+type okeRule struct {
+	cidr     string // HCL expression for the source or destination
+	service  bool   // cidr is the Oracle Services Network CIDR block
+	protocol string // "all", "6" (TCP) or "1" (ICMP)
+	min, max int    // TCP destination port range; zero for all ports
+	desc     string
+}
+
+var (
+	okeAPIIngress = []okeRule{
+		{cidr: `"0.0.0.0/0"`, protocol: "6", min: 6443, max: 6443, desc: "External access to Kubernetes API endpoint"},
+		{cidr: "local.oke_workers_cidr", protocol: "6", min: 6443, max: 6443, desc: "Kubernetes worker to Kubernetes API endpoint communication"},
+		{cidr: "local.oke_workers_cidr", protocol: "6", min: 12250, max: 12250, desc: "Kubernetes worker to control plane communication"},
+		{cidr: "local.oke_workers_cidr", protocol: "1", desc: "Path discovery"},
+	}
+	okeAPIEgress = []okeRule{
+		{cidr: "data.oci_core_services.oke.services[0].cidr_block", service: true, protocol: "6", min: 443, max: 443, desc: "Allow Kubernetes control plane to communicate with OKE"},
+		{cidr: "local.oke_workers_cidr", protocol: "6", desc: "All traffic to worker nodes"},
+		{cidr: "local.oke_workers_cidr", protocol: "1", desc: "Path discovery"},
+	}
+	okeWorkerIngress = []okeRule{
+		{cidr: "local.oke_workers_cidr", protocol: "all", desc: "Pod to pod communication across worker nodes"},
+		{cidr: "local.oke_api_cidr", protocol: "6", desc: "TCP access from Kubernetes control plane"},
+		{cidr: `"0.0.0.0/0"`, protocol: "1", desc: "Path discovery"},
+		{cidr: "local.oke_lb_cidr", protocol: "6", min: 30000, max: 32767, desc: "Load balancer to worker node ports"},
+		{cidr: "local.oke_lb_cidr", protocol: "6", min: 10256, max: 10256, desc: "Load balancer to kube-proxy health check"},
+	}
+	okeWorkerEgress = []okeRule{
+		{cidr: "local.oke_workers_cidr", protocol: "all", desc: "Pod to pod communication across worker nodes"},
+		{cidr: `"0.0.0.0/0"`, protocol: "1", desc: "Path discovery"},
+		{cidr: "data.oci_core_services.oke.services[0].cidr_block", service: true, protocol: "6", desc: "Allow nodes to communicate with OKE"},
+		{cidr: "local.oke_api_cidr", protocol: "6", min: 6443, max: 6443, desc: "Access to Kubernetes API endpoint"},
+		{cidr: "local.oke_api_cidr", protocol: "6", min: 12250, max: 12250, desc: "Kubernetes worker to control plane communication"},
+		{cidr: `"0.0.0.0/0"`, protocol: "all", desc: "Worker node access to the internet through the NAT gateway"},
+	}
+	okeLBIngress = []okeRule{
+		{cidr: `"0.0.0.0/0"`, protocol: "6", min: 80, max: 80, desc: "HTTP load balancer listener"},
+		{cidr: `"0.0.0.0/0"`, protocol: "6", min: 443, max: 443, desc: "HTTPS load balancer listener"},
+	}
+	okeLBEgress = []okeRule{
+		{cidr: "local.oke_workers_cidr", protocol: "6", min: 30000, max: 32767, desc: "Load balancer to worker node ports"},
+		{cidr: "local.oke_workers_cidr", protocol: "6", min: 10256, max: 10256, desc: "Load balancer to kube-proxy health check"},
+	}
+)
+
+// writeAttrs writes HCL attributes with their "=" aligned, as terraform fmt does.
+func writeAttrs(f *os.File, indent string, attrs [][2]string) {
+	width := 0
+	for _, a := range attrs {
+		width = max(width, len(a[0]))
+	}
+	for _, a := range attrs {
+		fmt.Fprintf(f, "%s%-*s = %s\n", indent, width, a[0], a[1])
+	}
+}
+
+func writeOKERules(f *os.File, ingress bool, rules []okeRule) {
+	block, target := "egress_security_rules", "destination"
+	if ingress {
+		block, target = "ingress_security_rules", "source"
+	}
+	for _, r := range rules {
+		fmt.Fprintf(f, "  %s {\n", block)
+		attrs := [][2]string{
+			{"description", fmt.Sprintf("%q", r.desc)},
+			{"protocol", fmt.Sprintf("%q", r.protocol)},
+			{target, r.cidr},
+		}
+		if r.service {
+			attrs = append(attrs, [2]string{target + "_type", `"SERVICE_CIDR_BLOCK"`})
+		}
+		writeAttrs(f, "    ", attrs)
+		switch {
+		case r.protocol == "1":
+			fmt.Fprintln(f, "    icmp_options {")
+			fmt.Fprintln(f, "      type = 3")
+			fmt.Fprintln(f, "      code = 4")
+			fmt.Fprintln(f, "    }")
+		case r.min != 0:
+			fmt.Fprintln(f, "    tcp_options {")
+			fmt.Fprintf(f, "      min = %d\n", r.min)
+			fmt.Fprintf(f, "      max = %d\n", r.max)
+			fmt.Fprintln(f, "    }")
+		}
+		fmt.Fprintln(f, "  }")
+	}
+}
+
+func writeOKESecurityList(f *os.File, opts Options, name, displayName string, ingress, egress []okeRule) {
+	fmt.Fprintf(f, "resource \"oci_core_security_list\" \"%s\" {\n", name)
+	fmt.Fprintln(f, "  compartment_id = local.compartment_ocid")
+	fmt.Fprintln(f, "  vcn_id         = oci_core_vcn.oke.id")
+	fmt.Fprintf(f, "  display_name   = %q\n", displayName)
+	fmt.Fprintln(f, "")
+	writeOKERules(f, true, ingress)
+	writeOKERules(f, false, egress)
+	writeDefinedTags(f, opts, false)
+	fmt.Fprintln(f, "}")
+	fmt.Fprintln(f, "")
+}
+
+func writeOKESubnet(f *os.File, opts Options, name, cidr, dnsLabel, routeTable string, public bool) {
+	fmt.Fprintf(f, "resource \"oci_core_subnet\" \"%s\" {\n", name)
+	writeAttrs(f, "  ", [][2]string{
+		{"compartment_id", "local.compartment_ocid"},
+		{"vcn_id", "oci_core_vcn.oke.id"},
+		{"cidr_block", cidr},
+		{"display_name", fmt.Sprintf("%q", strings.ReplaceAll(name, "_", "-"))},
+		{"dns_label", fmt.Sprintf("%q", dnsLabel)},
+		{"route_table_id", "oci_core_route_table." + routeTable + ".id"},
+		{"security_list_ids", "[oci_core_security_list." + name + ".id]"},
+		{"prohibit_public_ip_on_vnic", fmt.Sprintf("%t", !public)},
+	})
+	writeDefinedTags(f, opts, false)
+	fmt.Fprintln(f, "}")
+	fmt.Fprintln(f, "")
+}
+
+// writeOKEStack generates a dedicated VCN, the API endpoint, worker and load
+// balancer subnets with the security rules OKE documents, and a cluster running
+// the given Kubernetes version: a free BASIC_CLUSTER with flannel overlay
+// networking, or with opts.VirtualNodes an ENHANCED_CLUSTER with VCN-native
+// pod networking whose pods share the worker subnet. Without a supported
+// version that has node images (version is ""), the cluster is commented out
+// with the newest image version, fallback, for the user to adjust.
+func writeOKEStack(f *os.File, opts Options, version, fallback string, supported []string) {
+	commented := version == ""
+	if commented {
+		version = fallback
+	}
+	p := lineWriter(commented)
+
+	fmt.Fprintln(f, "# ── OKE Network ────────────────────────────────────────────────────────────")
+	fmt.Fprintln(f, "# No existing OKE cluster was discovered, so a complete stack is generated:")
+	fmt.Fprintln(f, "# a dedicated VCN with a public API endpoint subnet, a private worker subnet")
+	fmt.Fprintln(f, "# (internet through NAT, OCI services through a service gateway) and a public")
+	fmt.Fprintln(f, "# load balancer subnet.")
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "locals {")
+	fmt.Fprintln(f, `  oke_vcn_cidr     = "10.1.0.0/16"`)
+	fmt.Fprintln(f, `  oke_api_cidr     = "10.1.0.0/28"`)
+	fmt.Fprintln(f, `  oke_workers_cidr = "10.1.10.0/24"`)
+	fmt.Fprintln(f, `  oke_lb_cidr      = "10.1.20.0/24"`)
+	fmt.Fprintln(f, "}")
+	fmt.Fprintln(f, "")
+
+	fmt.Fprintln(f, `data "oci_core_services" "oke" {`)
+	fmt.Fprintln(f, "  filter {")
+	fmt.Fprintln(f, `    name   = "name"`)
+	fmt.Fprintln(f, `    values = ["All .* Services In Oracle Services Network"]`)
+	fmt.Fprintln(f, "    regex  = true")
+	fmt.Fprintln(f, "  }")
+	fmt.Fprintln(f, "}")
+	fmt.Fprintln(f, "")
+
+	fmt.Fprintln(f, `resource "oci_core_vcn" "oke" {`)
+	fmt.Fprintln(f, "  compartment_id = local.compartment_ocid")
+	fmt.Fprintln(f, "  cidr_blocks    = [local.oke_vcn_cidr]")
+	fmt.Fprintln(f, `  display_name   = "oke-vcn"`)
+	fmt.Fprintln(f, `  dns_label      = "oke"`)
+	writeDefinedTags(f, opts, false)
+	fmt.Fprintln(f, "}")
+	fmt.Fprintln(f, "")
+
+	fmt.Fprintln(f, `resource "oci_core_internet_gateway" "oke" {`)
+	fmt.Fprintln(f, "  compartment_id = local.compartment_ocid")
+	fmt.Fprintln(f, "  vcn_id         = oci_core_vcn.oke.id")
+	fmt.Fprintln(f, `  display_name   = "oke-igw"`)
+	fmt.Fprintln(f, "  enabled        = true")
+	writeDefinedTags(f, opts, false)
+	fmt.Fprintln(f, "}")
+	fmt.Fprintln(f, "")
+
+	fmt.Fprintln(f, `resource "oci_core_nat_gateway" "oke" {`)
+	fmt.Fprintln(f, "  compartment_id = local.compartment_ocid")
+	fmt.Fprintln(f, "  vcn_id         = oci_core_vcn.oke.id")
+	fmt.Fprintln(f, `  display_name   = "oke-nat"`)
+	writeDefinedTags(f, opts, false)
+	fmt.Fprintln(f, "}")
+	fmt.Fprintln(f, "")
+
+	fmt.Fprintln(f, `resource "oci_core_service_gateway" "oke" {`)
+	fmt.Fprintln(f, "  compartment_id = local.compartment_ocid")
+	fmt.Fprintln(f, "  vcn_id         = oci_core_vcn.oke.id")
+	fmt.Fprintln(f, `  display_name   = "oke-sgw"`)
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "  services {")
+	fmt.Fprintln(f, "    service_id = data.oci_core_services.oke.services[0].id")
+	fmt.Fprintln(f, "  }")
+	writeDefinedTags(f, opts, false)
+	fmt.Fprintln(f, "}")
+	fmt.Fprintln(f, "")
+
+	fmt.Fprintln(f, `resource "oci_core_route_table" "oke_public" {`)
+	fmt.Fprintln(f, "  compartment_id = local.compartment_ocid")
+	fmt.Fprintln(f, "  vcn_id         = oci_core_vcn.oke.id")
+	fmt.Fprintln(f, `  display_name   = "oke-public-rt"`)
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "  route_rules {")
+	fmt.Fprintln(f, `    destination       = "0.0.0.0/0"`)
+	fmt.Fprintln(f, `    destination_type  = "CIDR_BLOCK"`)
+	fmt.Fprintln(f, "    network_entity_id = oci_core_internet_gateway.oke.id")
+	fmt.Fprintln(f, "  }")
+	writeDefinedTags(f, opts, false)
+	fmt.Fprintln(f, "}")
+	fmt.Fprintln(f, "")
+
+	fmt.Fprintln(f, `resource "oci_core_route_table" "oke_private" {`)
+	fmt.Fprintln(f, "  compartment_id = local.compartment_ocid")
+	fmt.Fprintln(f, "  vcn_id         = oci_core_vcn.oke.id")
+	fmt.Fprintln(f, `  display_name   = "oke-private-rt"`)
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "  route_rules {")
+	fmt.Fprintln(f, `    destination       = "0.0.0.0/0"`)
+	fmt.Fprintln(f, `    destination_type  = "CIDR_BLOCK"`)
+	fmt.Fprintln(f, "    network_entity_id = oci_core_nat_gateway.oke.id")
+	fmt.Fprintln(f, "  }")
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "  route_rules {")
+	fmt.Fprintln(f, "    destination       = data.oci_core_services.oke.services[0].cidr_block")
+	fmt.Fprintln(f, `    destination_type  = "SERVICE_CIDR_BLOCK"`)
+	fmt.Fprintln(f, "    network_entity_id = oci_core_service_gateway.oke.id")
+	fmt.Fprintln(f, "  }")
+	writeDefinedTags(f, opts, false)
+	fmt.Fprintln(f, "}")
+	fmt.Fprintln(f, "")
+
+	writeOKESecurityList(f, opts, "oke_api", "oke-api-endpoint-sl", okeAPIIngress, okeAPIEgress)
+	writeOKESecurityList(f, opts, "oke_workers", "oke-workers-sl", okeWorkerIngress, okeWorkerEgress)
+	writeOKESecurityList(f, opts, "oke_lb", "oke-lb-sl", okeLBIngress, okeLBEgress)
+
+	writeOKESubnet(f, opts, "oke_api", "local.oke_api_cidr", "api", "oke_public", true)
+	writeOKESubnet(f, opts, "oke_workers", "local.oke_workers_cidr", "workers", "oke_private", false)
+	writeOKESubnet(f, opts, "oke_lb", "local.oke_lb_cidr", "lb", "oke_public", true)
+
+	clusterType, cniType := "BASIC_CLUSTER", "FLANNEL_OVERLAY"
+	if opts.VirtualNodes {
+		clusterType, cniType = "ENHANCED_CLUSTER", "OCI_VCN_IP_NATIVE"
+	}
+
+	fmt.Fprintln(f, "# ── OKE Cluster ────────────────────────────────────────────────────────────")
+	if opts.VirtualNodes {
+		fmt.Fprintln(f, "# ENHANCED_CLUSTER is required for virtual nodes and is billed per cluster-hour")
+	} else if opts.AlwaysFree {
+		fmt.Fprintln(f, "# BASIC_CLUSTER control planes are free; ENHANCED_CLUSTER is billed per hour")
+	}
+	if commented {
+		listed := "none were discovered"
+		if len(supported) > 0 {
+			listed = "supported: " + strings.Join(supported, ", ")
+		}
+		fmt.Fprintln(f, "# No node image matches a Kubernetes version OKE supports for new clusters")
+		fmt.Fprintf(f, "# (%s), so the cluster and its node pools are commented out.\n", listed)
+		fmt.Fprintln(f, "# Set kubernetes_version to a supported version with node images, then uncomment.")
+	}
+	fmt.Fprintln(f, "")
+	p(f, `resource "oci_containerengine_cluster" "oke" {`)
+	p(f, "  compartment_id     = local.compartment_ocid")
+	p(f, fmt.Sprintf("  kubernetes_version = %q", okeKubernetesVersion(version)))
+	p(f, `  name               = "bootstrap-oke"`)
+	p(f, fmt.Sprintf("  type               = %q", clusterType))
+	p(f, "  vcn_id             = oci_core_vcn.oke.id")
+	p(f, "")
+	p(f, "  cluster_pod_network_options {")
+	p(f, fmt.Sprintf("    cni_type = %q", cniType))
+	p(f, "  }")
+	p(f, "")
+	p(f, "  endpoint_config {")
+	p(f, "    is_public_ip_enabled = true")
+	p(f, "    subnet_id            = oci_core_subnet.oke_api.id")
+	p(f, "  }")
+	p(f, "")
+	p(f, "  options {")
+	p(f, "    service_lb_subnet_ids = [oci_core_subnet.oke_lb.id]")
+	p(f, "")
+	p(f, "    kubernetes_network_config {")
+	if opts.VirtualNodes {
+		// VCN-native pods take addresses from the pod subnet, not a pods CIDR
+		p(f, `      services_cidr = "10.96.0.0/16"`)
+	} else {
+		p(f, `      pods_cidr     = "10.244.0.0/16"`)
+		p(f, `      services_cidr = "10.96.0.0/16"`)
+	}
+	p(f, "    }")
+	p(f, "  }")
+	writeDefinedTags(f, opts, commented)
+	p(f, "}")
+	fmt.Fprintln(f, "")
+	p(f, `output "oke_cluster_id" {`)
+	p(f, `  description = "OKE cluster OCID; fetch a kubeconfig with: oci ce cluster create-kubeconfig --cluster-id <id>"`)
+	p(f, "  value       = oci_containerengine_cluster.oke.id")
+	p(f, "}")
+}
@@ -3,10 +3,12 @@ package renderer
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
 	"github.com/larsenclose/oci-tf-bootstrap/internal/discovery"
+	"github.com/larsenclose/oci-tf-bootstrap/internal/pricing"
 )
 
 func TestWriteOKEExampleMixedArchMultipleVersions(t *testing.T) {
@@ -20,6 +22,7 @@ func TestWriteOKEExampleMixedArchMultipleVersions(t *testing.T) {
 		AvailabilityDomains: []discovery.AvailabilityDomain{
 			{Name: "TEST:AD-1"},
 		},
+		OKESupportedVersions: []string{"v1.31.10", "v1.32.10"},
 		OKEImages: []discovery.OKEImage{
 			{ID: "ocid1.image.oc1..v131-arm", SourceName: "Oracle-Linux-8.10-aarch64-2025.11.20-0-OKE-1.31.10-1345", KubernetesVersion: "v1.31.10", Architecture: "aarch64"},
 			{ID: "ocid1.image.oc1..v131-x86", SourceName: "Oracle-Linux-8.10-2025.11.20-0-OKE-1.31.10-1345", KubernetesVersion: "v1.31.10", Architecture: "x86_64"},
@@ -544,7 +547,7 @@ func TestOKELocalName(t *testing.T) {
 	}
 }
 
-func TestWriteOKEExamplePlaceholders(t *testing.T) {
+func TestWriteOKEExampleFullStack(t *testing.T) {
 	tmpDir := t.TempDir()
 
 	result := &discovery.Result{
@@ -555,13 +558,13 @@ func TestWriteOKEExamplePlaceholders(t *testing.T) {
 		AvailabilityDomains: []discovery.AvailabilityDomain{
 			{Name: "TEST:AD-1"},
 		},
+		OKESupportedVersions: []string{"v1.32.10"},
 		OKEImages: []discovery.OKEImage{
-			{ID: "ocid1.image.oc1..v132-arm", SourceName: "OKE-ARM", KubernetesVersion: "v1.32.10", Architecture: "aarch64"},
+			{ID: "ocid1.image.oc1..v132-arm", SourceName: "OKE-ARM", KubernetesVersion: "1.32.10", Architecture: "aarch64"},
 		},
 	}
 
-	opts := Options{AlwaysFree: false}
-	if err := writeOKEExample(result, tmpDir, opts); err != nil {
+	if err := writeOKEExample(result, tmpDir, Options{}); err != nil {
 		t.Fatalf("writeOKEExample failed: %v", err)
 	}
 
@@ -571,22 +574,334 @@ func TestWriteOKEExamplePlaceholders(t *testing.T) {
 	}
 	s := string(content)
 
-	// Should contain placeholder values with clear instructions
-	if !strings.Contains(s, "PLACEHOLDER_CLUSTER_OCID") {
-		t.Error("should contain PLACEHOLDER_CLUSTER_OCID")
+	if strings.Contains(s, "PLACEHOLDER") {
+		t.Error("full stack should not contain placeholders")
+	}
+
+	for _, e := range []string{
+		`resource "oci_core_vcn" "oke"`,
+		`resource "oci_core_internet_gateway" "oke"`,
+		`resource "oci_core_nat_gateway" "oke"`,
+		`resource "oci_core_service_gateway" "oke"`,
+		`resource "oci_core_subnet" "oke_api"`,
+		`resource "oci_core_subnet" "oke_workers"`,
+		`resource "oci_core_subnet" "oke_lb"`,
+		`resource "oci_containerengine_cluster" "oke"`,
+		`  kubernetes_version = "v1.32.10"`,
+		"    service_lb_subnet_ids = [oci_core_subnet.oke_lb.id]",
+		"    subnet_id            = oci_core_subnet.oke_api.id",
+		"  cluster_id         = oci_containerengine_cluster.oke.id",
+		"      subnet_id           = oci_core_subnet.oke_workers.id",
+		// Worker subnet is private, API endpoint public
+		"  prohibit_public_ip_on_vnic = true",
+		"  prohibit_public_ip_on_vnic = false",
+		// Documented security rules
+		`description = "External access to Kubernetes API endpoint"`,
+		`description = "Kubernetes worker to control plane communication"`,
+		`destination_type = "SERVICE_CIDR_BLOCK"`,
+		"      min = 30000",
+		"      max = 32767",
+		"      min = 10256",
+	} {
+		if !strings.Contains(s, e) {
+			t.Errorf("oke_example.tf should contain %q", e)
+		}
+	}
+
+	// The stack's node pool is active, not commented
+	if !strings.Contains(s, "\nresource \"oci_containerengine_node_pool\" \"arm_pool_v1_32_10\"") {
+		t.Error("node pool should be uncommented")
+	}
+}
+
+func TestWriteOKEExampleClusterVersion(t *testing.T) {
+	images := []discovery.OKEImage{
+		{ID: "arm-133", KubernetesVersion: "v1.33.1", Architecture: "aarch64"},
+		{ID: "arm-132", KubernetesVersion: "v1.32.10", Architecture: "aarch64"},
+	}
+
+	t.Run("newest supported version with images", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		result := &discovery.Result{OKEImages: images, OKESupportedVersions: []string{"v1.31.10", "v1.32.10"}}
+		if err := writeOKEExample(result, tmpDir, Options{}); err != nil {
+			t.Fatalf("writeOKEExample failed: %v", err)
+		}
+		content, _ := os.ReadFile(filepath.Join(tmpDir, "oke_example.tf"))
+		s := string(content)
+		for _, e := range []string{
+			"\nresource \"oci_containerengine_cluster\" \"oke\" {\n  compartment_id     = local.compartment_ocid\n  kubernetes_version = \"v1.32.10\"",
+			"\nresource \"oci_containerengine_node_pool\" \"arm_pool_v1_32_10\"",
+			"# resource \"oci_containerengine_node_pool\" \"arm_pool_v1_33_1\"",
+		} {
+			if !strings.Contains(s, e) {
+				t.Errorf("oke_example.tf should contain %q", e)
+			}
+		}
+	})
+
+	t.Run("no supported version", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		result := &discovery.Result{OKEImages: images, OKESupportedVersions: []string{"v1.30.1"}}
+		if err := writeOKEExample(result, tmpDir, Options{}); err != nil {
+			t.Fatalf("writeOKEExample failed: %v", err)
+		}
+		content, _ := os.ReadFile(filepath.Join(tmpDir, "oke_example.tf"))
+		s := string(content)
+		for _, e := range []string{
+			"# (supported: v1.30.1), so the cluster and its node pools are commented out.",
+			"# resource \"oci_containerengine_cluster\" \"oke\" {",
+			"#   kubernetes_version = \"v1.33.1\"",
+			"# output \"oke_cluster_id\" {",
+		} {
+			if !strings.Contains(s, e) {
+				t.Errorf("oke_example.tf should contain %q", e)
+			}
+		}
+		if strings.Contains(s, "\nresource \"oci_containerengine_node_pool\"") {
+			t.Error("node pools should be commented out with the cluster")
+		}
+		if est := EstimateCost(result, Options{}); slices.ContainsFunc(est.Items, func(i pricing.Item) bool { return strings.HasPrefix(i.Resource, "oci_containerengine") }) {
+			t.Errorf("expected no OKE items in the estimate, got %+v", est.Items)
+		}
+	})
+}
+
+func TestWriteOKEExampleExistingCluster(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	result := &discovery.Result{
+		Tenancy: discovery.TenancyInfo{
+			ID:         "ocid1.tenancy.oc1..test",
+			HomeRegion: "us-phoenix-1",
+		},
+		AvailabilityDomains: []discovery.AvailabilityDomain{
+			{Name: "TEST:AD-1"},
+		},
+		VCNs: []discovery.VCN{
+			{
+				ID:          "ocid1.vcn.oc1..oke",
+				DisplayName: "oke-vcn",
+				Subnets: []discovery.Subnet{
+					{ID: "ocid1.subnet.oc1..api", DisplayName: "api", IsPublic: true},
+					{ID: "ocid1.subnet.oc1..workers", DisplayName: "workers"},
+				},
+			},
+		},
+		OKEClusters: []discovery.OKECluster{
+			{
+				ID:                "ocid1.cluster.oc1..prod",
+				Name:              "prod",
+				VCNID:             "ocid1.vcn.oc1..oke",
+				KubernetesVersion: "v1.31.10",
+				EndpointSubnetID:  "ocid1.subnet.oc1..api",
+				IsPublicEndpoint:  true,
+			},
+		},
+		OKEImages: []discovery.OKEImage{
+			{ID: "ocid1.image.oc1..v132-arm", SourceName: "OKE-ARM-132", KubernetesVersion: "v1.32.10", Architecture: "aarch64"},
+			{ID: "ocid1.image.oc1..v131-arm", SourceName: "OKE-ARM-131", KubernetesVersion: "v1.31.10", Architecture: "aarch64"},
+		},
+	}
+
+	if err := writeOKEExample(result, tmpDir, Options{}); err != nil {
+		t.Fatalf("writeOKEExample failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "oke_example.tf"))
+	if err != nil {
+		t.Fatalf("failed to read oke_example.tf: %v", err)
+	}
+	s := string(content)
+
+	if strings.Contains(s, "oci_containerengine_cluster") || strings.Contains(s, "oci_core_vcn") {
+		t.Error("should not generate a cluster stack when one exists")
+	}
+	if !strings.Contains(s, "# ── Existing Cluster: prod") {
+		t.Error("should describe the existing cluster")
+	}
+
+	// The pool matching the cluster version is active; the newer one is commented
+	if !strings.Contains(s, "\nresource \"oci_containerengine_node_pool\" \"arm_pool_v1_31_10\"") {
+		t.Error("v1.31.10 node pool should be uncommented")
+	}
+	if !strings.Contains(s, "# resource \"oci_containerengine_node_pool\" \"arm_pool_v1_32_10\"") {
+		t.Error("v1.32.10 node pool should be commented out (newer than the cluster)")
+	}
+	for _, e := range []string{
+		"  cluster_id         = local.oke_cluster_prod",
+		"      subnet_id           = local.subnet_workers",
+	} {
+		if !strings.Contains(s, e) {
+			t.Errorf("oke_example.tf should contain %q", e)
+		}
+	}
+}
+
+func TestWriteOKEVersionReport(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	result := &discovery.Result{
+		OKESupportedVersions: []string{"v1.31.1", "v1.32.1"},
+		OKEImages: []discovery.OKEImage{
+			{ID: "img-132-arm", SourceName: "OKE-ARM-1.32.1", KubernetesVersion: "1.32.1", Architecture: "aarch64", IsSupported: true},
+		},
+		OKEVersions: []discovery.OKEVersionStatus{
+			{ClusterID: "c1", Cluster: "prod", Version: "v1.32.1", TargetVersion: "v1.32.1"},
+			{ClusterID: "c1", Cluster: "prod", NodePool: "arm", Version: "v1.31.1", TargetVersion: "v1.32.1", IsBehind: true, UpgradeImages: []string{"img-132-arm"}},
+		},
+	}
+
+	if err := OutputTerraform(result, tmpDir, Options{}); err != nil {
+		t.Fatalf("OutputTerraform failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "oke_versions.md"))
+	if err != nil {
+		t.Fatalf("failed to read oke_versions.md: %v", err)
+	}
+	s := string(content)
+
+	for _, e := range []string{
+		"Supported Kubernetes versions: `v1.31.1`, `v1.32.1`",
+		"1 of 2 clusters and node pools are behind",
+		"| prod | (control plane) | v1.32.1 | v1.32.1 |  | current |  |",
+		"| prod | arm | v1.31.1 | v1.32.1 |  | **behind** | OKE-ARM-1.32.1 (`img-132-arm`) |",
+	} {
+		if !strings.Contains(s, e) {
+			t.Errorf("oke_versions.md should contain %q", e)
+		}
 	}
-	if !strings.Contains(s, "Replace with your OKE cluster OCID") {
-		t.Error("should contain instruction to replace cluster OCID")
+}
+
+func TestOutputTerraformNoOKEVersionReport(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := OutputTerraform(&discovery.Result{}, tmpDir, Options{}); err != nil {
+		t.Fatalf("OutputTerraform failed: %v", err)
 	}
-	if !strings.Contains(s, "PLACEHOLDER_SUBNET_OCID") {
-		t.Error("should contain PLACEHOLDER_SUBNET_OCID")
+	if _, err := os.Stat(filepath.Join(tmpDir, "oke_versions.md")); !os.IsNotExist(err) {
+		t.Error("oke_versions.md should not be created without OKE clusters")
+	}
+}
+
+func TestChooseOKEShapes(t *testing.T) {
+	tests := []struct {
+		name   string
+		shapes []string
+		want   okeShapes
+	}{
+		{"no discovery falls back to defaults", nil, okeShapes{arm: "VM.Standard.A1.Flex", x86: "VM.Standard.E4.Flex", pod: "Pod.Standard.E4.Flex"}},
+		{"prefers newest x86 flex", []string{"VM.Standard.E4.Flex", "VM.Standard.E5.Flex"}, okeShapes{x86: "VM.Standard.E5.Flex", pod: "Pod.Standard.E4.Flex"}},
+		{"always-free shapes", []string{"VM.Standard.A1.Flex", "VM.Standard.E2.1.Micro"}, okeShapes{arm: "VM.Standard.A1.Flex", pod: "Pod.Standard.A1.Flex"}},
+		{"no eligible shapes", []string{"VM.Standard2.1"}, okeShapes{}},
 	}
-	if !strings.Contains(s, "Replace with your worker subnet OCID") {
-		t.Error("should contain instruction to replace subnet OCID")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var shapes []discovery.Shape
+			for _, name := range tt.shapes {
+				shapes = append(shapes, discovery.Shape{Name: name, IsFlexible: true})
+			}
+			if got := chooseOKEShapes(shapes); got != tt.want {
+				t.Errorf("chooseOKEShapes() = %+v, want %+v", got, tt.want)
+			}
+		})
 	}
+}
 
-	// Should contain header explaining this is a reference config
-	if !strings.Contains(s, "REFERENCE configurations") {
-		t.Error("should note these are reference configurations")
+func TestWriteOKEExampleDiscoveredShapes(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	result := &discovery.Result{
+		Shapes: []discovery.Shape{{Name: "VM.Standard.E5.Flex", IsFlexible: true}},
+		OKEImages: []discovery.OKEImage{
+			{ID: "arm", KubernetesVersion: "v1.32.10", Architecture: "aarch64"},
+			{ID: "x86", KubernetesVersion: "v1.32.10", Architecture: "x86_64"},
+		},
+	}
+	if err := writeOKEExample(result, tmpDir, Options{}); err != nil {
+		t.Fatalf("writeOKEExample failed: %v", err)
+	}
+	content, _ := os.ReadFile(filepath.Join(tmpDir, "oke_example.tf"))
+	s := string(content)
+
+	if strings.Contains(s, `"arm_pool_v1_32_10"`) {
+		t.Error("ARM pool should be skipped when VM.Standard.A1.Flex was not discovered")
+	}
+	if !strings.Contains(s, `node_shape = "VM.Standard.E5.Flex"`) {
+		t.Error("x86 pool should use the discovered E5.Flex shape")
+	}
+	if !strings.Contains(s, "  node_eviction_node_pool_settings {") {
+		t.Error("node pools should set eviction settings")
+	}
+	if strings.Contains(s, "node_pool_cycling_details") {
+		t.Error("node cycling needs an ENHANCED_CLUSTER and should not be set on a basic one")
+	}
+	// No E3/E4/A1 compute shape, so no pod shape: virtual pool stays commented
+	if !strings.Contains(s, `# resource "oci_containerengine_virtual_node_pool" "virtual"`) {
+		t.Error("virtual node pool should be commented out without a pod shape")
 	}
 }
+
+func TestWriteOKEExampleVirtualNodes(t *testing.T) {
+	images := []discovery.OKEImage{{ID: "arm", KubernetesVersion: "v1.32.10", Architecture: "aarch64"}}
+
+	t.Run("generated enhanced cluster", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		result := &discovery.Result{OKEImages: images, OKESupportedVersions: []string{"v1.32.10"}}
+		if err := writeOKEExample(result, tmpDir, Options{VirtualNodes: true}); err != nil {
+			t.Fatalf("writeOKEExample failed: %v", err)
+		}
+		content, _ := os.ReadFile(filepath.Join(tmpDir, "oke_example.tf"))
+		s := string(content)
+		for _, e := range []string{
+			`  type               = "ENHANCED_CLUSTER"`,
+			`    cni_type = "OCI_VCN_IP_NATIVE"`,
+			"\nresource \"oci_containerengine_virtual_node_pool\" \"virtual\" {",
+			`    shape     = "Pod.Standard.E4.Flex"`,
+			"      pod_subnet_ids = [oci_core_subnet.oke_workers.id]",
+			"    is_node_cycling_enabled = true",
+			`    maximum_surge           = "1"`,
+		} {
+			if !strings.Contains(s, e) {
+				t.Errorf("oke_example.tf should contain %q", e)
+			}
+		}
+		if strings.Contains(s, "pods_cidr") {
+			t.Error("VCN-native clusters should not set pods_cidr")
+		}
+	})
+
+	t.Run("always-free enhanced cluster cycles without a surge node", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		result := &discovery.Result{OKEImages: images, OKESupportedVersions: []string{"v1.32.10"}}
+		if err := writeOKEExample(result, tmpDir, Options{VirtualNodes: true, AlwaysFree: true}); err != nil {
+			t.Fatalf("writeOKEExample failed: %v", err)
+		}
+		content, _ := os.ReadFile(filepath.Join(tmpDir, "oke_example.tf"))
+		s := string(content)
+		if !strings.Contains(s, `    maximum_surge           = "0"`) || !strings.Contains(s, `    maximum_unavailable     = "1"`) {
+			t.Errorf("expected node cycling without a surge node in always-free mode:\n%s", s)
+		}
+	})
+
+	t.Run("existing basic cluster", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		result := &discovery.Result{
+			OKEImages: images,
+			OKEClusters: []discovery.OKECluster{{
+				ID: "c1", Name: "prod", KubernetesVersion: "v1.32.10", Type: "BASIC_CLUSTER", CNIType: "FLANNEL_OVERLAY",
+				NodePools: []discovery.OKENodePool{{Name: "p", SubnetIDs: []string{"ocid1.subnet.oc1..w"}}},
+			}},
+		}
+		if err := writeOKEExample(result, tmpDir, Options{VirtualNodes: true}); err != nil {
+			t.Fatalf("writeOKEExample failed: %v", err)
+		}
+		content, _ := os.ReadFile(filepath.Join(tmpDir, "oke_example.tf"))
+		s := string(content)
+		if !strings.Contains(s, `# resource "oci_containerengine_virtual_node_pool" "virtual"`) {
+			t.Error("virtual node pool should be commented out for a basic flannel cluster")
+		}
+		if strings.Contains(s, "pod_subnet_ids") {
+			t.Error("flannel clusters should not set pod subnets on node pools")
+		}
+	})
+}
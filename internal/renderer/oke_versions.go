@@ -0,0 +1,84 @@
+package renderer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/larsenclose/oci-tf-bootstrap/internal/discovery"
+)
+
+// writeOKEVersionReport writes oke_versions.md: each discovered cluster and
+// node pool with its Kubernetes version, the version it should move to and the
+// node images to use for the upgrade.
+func writeOKEVersionReport(result *discovery.Result, outputDir string) (err error) {
+	f, err := os.Create(filepath.Join(outputDir, "oke_versions.md")) // #nosec G304 -- outputDir is user-specified CLI flag
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	fmt.Fprintln(f, "# OKE Versions")
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "Generated by oci-tf-bootstrap.")
+	fmt.Fprintln(f, "")
+	if len(result.OKESupportedVersions) > 0 {
+		supported := make([]string, len(result.OKESupportedVersions))
+		for i, v := range result.OKESupportedVersions {
+			supported[i] = "`" + v + "`"
+		}
+		fmt.Fprintf(f, "Supported Kubernetes versions: %s\n", strings.Join(supported, ", "))
+		fmt.Fprintln(f, "")
+	}
+
+	var behind int
+	for _, s := range result.OKEVersions {
+		if s.IsBehind {
+			behind++
+		}
+	}
+	if behind == 0 {
+		fmt.Fprintln(f, "All clusters and node pools are on their target version.")
+		fmt.Fprintln(f, "")
+	} else {
+		fmt.Fprintf(f, "%d of %d clusters and node pools are behind. Upgrade each cluster one minor\n", behind, len(result.OKEVersions))
+		fmt.Fprintln(f, "version at a time (next upgrade column), then move its node pools to the")
+		fmt.Fprintln(f, "cluster version using the listed images.")
+		fmt.Fprintln(f, "")
+	}
+
+	imageNames := make(map[string]string, len(result.OKEImages))
+	for _, img := range result.OKEImages {
+		imageNames[img.ID] = img.SourceName
+	}
+
+	fmt.Fprintln(f, "| Cluster | Node Pool | Version | Target | Next Upgrade | Status | Upgrade Images |")
+	fmt.Fprintln(f, "|---------|-----------|---------|--------|--------------|--------|----------------|")
+	for _, s := range result.OKEVersions {
+		nodePool := s.NodePool
+		if nodePool == "" {
+			nodePool = "(control plane)"
+		}
+		status := "current"
+		if s.IsBehind {
+			status = "**behind**"
+		}
+		var images []string
+		for _, id := range s.UpgradeImages {
+			name := imageNames[id]
+			if name == "" {
+				name = id
+			}
+			images = append(images, fmt.Sprintf("%s (`%s`)", mdCell(name), id))
+		}
+		fmt.Fprintf(f, "| %s | %s | %s | %s | %s | %s | %s |\n",
+			mdCell(s.Cluster), mdCell(nodePool), s.Version, s.TargetVersion, s.NextUpgrade, status, strings.Join(images, "<br>"))
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package renderer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/larsenclose/oci-tf-bootstrap/internal/discovery"
+)
+
+// PolicyOptions configures least-privilege policy generation
+type PolicyOptions struct {
+	Group         string // IAM group the statements are granted to
+	TenancyID     string
+	CompartmentID string // Discovery target; empty or TenancyID for the tenancy root
+}
+
+// OutputPolicy writes the least-privilege policy for running discovery as
+// bootstrap_policy.tf (an oci_identity_policy resource) and bootstrap_policy.txt
+// (plain statements for pasting into the console).
+func OutputPolicy(stmts []discovery.PolicyStatement, outputDir string, opts PolicyOptions) error {
+	if err := os.MkdirAll(outputDir, 0750); err != nil {
+		return err
+	}
+	if err := writePolicyFile(filepath.Join(outputDir, "bootstrap_policy.tf"), func(w io.Writer) {
+		WritePolicyHCL(w, stmts, opts)
+	}); err != nil {
+		return fmt.Errorf("bootstrap_policy.tf: %w", err)
+	}
+	if err := writePolicyFile(filepath.Join(outputDir, "bootstrap_policy.txt"), func(w io.Writer) {
+		WritePolicyText(w, stmts, opts)
+	}); err != nil {
+		return fmt.Errorf("bootstrap_policy.txt: %w", err)
+	}
+	return nil
+}
+
+func writePolicyFile(path string, write func(io.Writer)) (err error) {
+	f, err := os.Create(path) // #nosec G304 -- outputDir is user-specified CLI flag
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+	write(f)
+	return nil
+}
+
+// WritePolicyText writes one statement per line, each preceded by a comment
+// naming the interface methods that need it.
+func WritePolicyText(w io.Writer, stmts []discovery.PolicyStatement, opts PolicyOptions) {
+	fmt.Fprintln(w, "# Least-privilege IAM policy for oci-tf-bootstrap")
+	fmt.Fprintf(w, "# Grant to group %q in the tenancy root.\n", opts.Group)
+	fmt.Fprintf(w, "# No policy required: %s\n", strings.Join(discovery.NoPolicyMethods, ", "))
+	for _, s := range stmts {
+		fmt.Fprintln(w, "")
+		fmt.Fprintf(w, "# [%s] %s\n", s.Scope, strings.Join(s.Methods, ", "))
+		fmt.Fprintln(w, s.Statement(opts.Group, opts.TenancyID, opts.CompartmentID))
+	}
+}
+
+// WritePolicyHCL writes an oci_identity_policy resource granting the statements.
+func WritePolicyHCL(w io.Writer, stmts []discovery.PolicyStatement, opts PolicyOptions) {
+	fmt.Fprintln(w, "# Generated by oci-tf-bootstrap")
+	fmt.Fprintln(w, "# Least-privilege IAM policy for running oci-tf-bootstrap discovery.")
+	fmt.Fprintln(w, "# Apply as a tenancy administrator; policies granting tenancy-wide access")
+	fmt.Fprintln(w, "# must be attached to the tenancy root compartment.")
+	fmt.Fprintf(w, "#\n# No policy required: %s\n", strings.Join(discovery.NoPolicyMethods, ", "))
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, `resource "oci_identity_policy" "oci_tf_bootstrap" {`)
+	fmt.Fprintf(w, "  compartment_id = %q\n", opts.TenancyID)
+	fmt.Fprintln(w, `  name           = "oci-tf-bootstrap-discovery"`)
+	fmt.Fprintf(w, "  description    = %q\n", "Read-only access required by oci-tf-bootstrap for group "+opts.Group)
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "  statements = [")
+	for _, s := range stmts {
+		fmt.Fprintf(w, "    # [%s] %s\n", s.Scope, strings.Join(s.Methods, ", "))
+		fmt.Fprintf(w, "    %q,\n", s.Statement(opts.Group, opts.TenancyID, opts.CompartmentID))
+	}
+	fmt.Fprintln(w, "  ]")
+	fmt.Fprintln(w, "}")
+}
@@ -0,0 +1,67 @@
+package renderer
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/larsenclose/oci-tf-bootstrap/internal/discovery"
+)
+
+func TestOutputPolicy(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	stmts := discovery.RequiredPolicy(&discovery.Context{})
+	opts := PolicyOptions{
+		Group:         "bootstrap-admins",
+		TenancyID:     "ocid1.tenancy.oc1..test",
+		CompartmentID: "ocid1.compartment.oc1..target",
+	}
+	if err := OutputPolicy(stmts, tmpDir, opts); err != nil {
+		t.Fatalf("OutputPolicy failed: %v", err)
+	}
+
+	hcl, err := os.ReadFile(filepath.Join(tmpDir, "bootstrap_policy.tf"))
+	if err != nil {
+		t.Fatalf("failed to read bootstrap_policy.tf: %v", err)
+	}
+	hclStr := string(hcl)
+	for _, e := range []string{
+		`resource "oci_identity_policy" "oci_tf_bootstrap"`,
+		`compartment_id = "ocid1.tenancy.oc1..test"`,
+		"# [virtual-network] VirtualNetworkAPI.ListVcns",
+		`"Allow group bootstrap-admins to inspect vcns in compartment id ocid1.compartment.oc1..target",`,
+		`"Allow group bootstrap-admins to inspect compartments in tenancy",`,
+	} {
+		if !strings.Contains(hclStr, e) {
+			t.Errorf("bootstrap_policy.tf should contain %q", e)
+		}
+	}
+	if strings.Contains(hclStr, "all-resources") {
+		t.Error("bootstrap_policy.tf should not grant all-resources")
+	}
+
+	text, err := os.ReadFile(filepath.Join(tmpDir, "bootstrap_policy.txt"))
+	if err != nil {
+		t.Fatalf("failed to read bootstrap_policy.txt: %v", err)
+	}
+	var lines int
+	for _, line := range strings.Split(string(text), "\n") {
+		if strings.HasPrefix(line, "Allow group ") {
+			lines++
+		}
+	}
+	if lines != len(stmts) {
+		t.Errorf("expected %d statements in bootstrap_policy.txt, got %d", len(stmts), lines)
+	}
+}
+
+func TestWritePolicyTextNoPolicyMethods(t *testing.T) {
+	var buf bytes.Buffer
+	WritePolicyText(&buf, nil, PolicyOptions{Group: "g", TenancyID: "t"})
+	if !strings.Contains(buf.String(), "IdentityAPI.ListAvailabilityDomains") {
+		t.Error("policy text should list methods that need no policy")
+	}
+}
@@ -5,22 +5,70 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
 
 	"github.com/larsenclose/oci-tf-bootstrap/internal/discovery"
+	"github.com/larsenclose/oci-tf-bootstrap/internal/pricing"
 )
 
-// FormatVersion tracks the output format for downstream consumers.
-const FormatVersion = "1.0.0"
+// FormatVersion tracks the output format for downstream consumers. It is
+// written to locals.tf and as format_version in the JSON output. The minor
+// version changes when fields are added to the JSON and the major version
+// when fields are removed, renamed or change type; Schema describes the
+// current one.
+const FormatVersion = "1.1.0"
 
 // Options configures terraform output generation
 type Options struct {
-	AlwaysFree bool
+	AlwaysFree    bool
+	BackupPolicy  string            // Volume backup policy display name (e.g. "bronze") assigned to example volumes
+	Tags          map[string]string // Defined tags ("Namespace.Key" -> value) stamped on every generated resource
+	VirtualNodes  bool              // Generate an ENHANCED_CLUSTER with VCN-native pod networking and an active virtual node pool
+	Observability bool              // Generate flow logs, an alert topic and instance alarms
+	Budget        float64           // Monthly budget amount for budget.tf; 0 disables budget and quota generation
+	Prices        *pricing.Catalog  // Price catalog for cost_estimate.md; nil uses the embedded catalog
+	Instances     int               // Number of instances in instances.tf, spread across ADs and fault domains
+	InstancePool  bool              // Generate instances.tf as an instance configuration and pool instead of counted instances
+	Kinds         []string          // Resource kinds discovered (discovery.Discoverer names); nil means all
+}
+
+// FileKinds lists the resource kinds each generated file is built from. With
+// Options.Kinds set, a file is only written when all of its kinds were
+// discovered, so that it never stands in for resources that were not looked
+// at (network.tf, for example, would create a VCN). Files not listed render
+// whatever was discovered.
+var FileKinds = map[string][]string{
+	"data.tf":              {"availability-domains", "images"},
+	"network.tf":           {"vcns"},
+	"instance_example.tf":  {"availability-domains", "shapes", "images", "vcns"},
+	"instances.tf":         {"availability-domains", "shapes", "images", "vcns"},
+	"cost_estimate.md":     {"shapes"},
+	"observability.tf":     {"availability-domains", "shapes", "images", "vcns"},
+	"budget.tf":            {"shapes"},
+	"functions_example.tf": {"vcns"},
+	"oke_example.tf":       {"availability-domains", "images", "shapes", "vcns"},
+}
+
+// Renders reports whether every resource kind file is built from was
+// discovered, and so whether OutputTerraform writes it.
+func (o Options) Renders(file string) bool {
+	if o.Kinds == nil {
+		return true
+	}
+	for _, kind := range FileKinds[file] {
+		if !slices.Contains(o.Kinds, kind) {
+			return false
+		}
+	}
+	return true
 }
 
 func OutputJSON(result *discovery.Result, w io.Writer) error {
+	out := *result
+	out.FormatVersion = FormatVersion
 	enc := json.NewEncoder(w)
 	enc.SetIndent("", "  ")
-	return enc.Encode(result)
+	return enc.Encode(&out)
 }
 
 func OutputTerraform(result *discovery.Result, outputDir string, opts Options) error {
@@ -34,16 +82,57 @@ func OutputTerraform(result *discovery.Result, outputDir string, opts Options) e
 	if err := writeLocals(result, outputDir, opts); err != nil {
 		return fmt.Errorf("locals.tf: %w", err)
 	}
-	if err := writeDataSources(result, outputDir); err != nil {
-		return fmt.Errorf("data.tf: %w", err)
+	if opts.Renders("data.tf") {
+		if err := writeDataSources(result, outputDir); err != nil {
+			return fmt.Errorf("data.tf: %w", err)
+		}
+	}
+	if opts.Renders("instance_example.tf") {
+		if err := writeInstanceExample(result, outputDir, opts); err != nil {
+			return fmt.Errorf("instance_example.tf: %w", err)
+		}
+	}
+	if opts.Renders("network.tf") {
+		if err := writeNetwork(result, outputDir, opts); err != nil {
+			return fmt.Errorf("network.tf: %w", err)
+		}
+	}
+	if (opts.Instances > 1 || opts.InstancePool) && opts.Renders("instances.tf") {
+		if err := writeTopology(result, outputDir, opts); err != nil {
+			return fmt.Errorf("instances.tf: %w", err)
+		}
+	}
+	if opts.Renders("cost_estimate.md") {
+		if err := writeCostEstimate(result, outputDir, opts); err != nil {
+			return fmt.Errorf("cost_estimate.md: %w", err)
+		}
+	}
+	if len(result.Policies) > 0 || len(result.Groups) > 0 || len(result.DynamicGroups) > 0 {
+		if err := writeIAMReport(result, outputDir); err != nil {
+			return fmt.Errorf("iam_report.md: %w", err)
+		}
+	}
+	if len(result.OKEVersions) > 0 {
+		if err := writeOKEVersionReport(result, outputDir); err != nil {
+			return fmt.Errorf("oke_versions.md: %w", err)
+		}
+	}
+	if opts.Observability && opts.Renders("observability.tf") {
+		if err := writeObservability(result, outputDir, opts); err != nil {
+			return fmt.Errorf("observability.tf: %w", err)
+		}
 	}
-	if err := writeInstanceExample(result, outputDir, opts); err != nil {
-		return fmt.Errorf("instance_example.tf: %w", err)
+	if opts.Budget > 0 && opts.Renders("budget.tf") {
+		if err := writeBudget(result, outputDir, opts); err != nil {
+			return fmt.Errorf("budget.tf: %w", err)
+		}
 	}
-	if err := writeNetwork(result, outputDir, opts); err != nil {
-		return fmt.Errorf("network.tf: %w", err)
+	if (result.Registry != nil || len(result.FunctionsApplications) > 0) && opts.Renders("functions_example.tf") {
+		if err := writeFunctionsExample(result, outputDir, opts); err != nil {
+			return fmt.Errorf("functions_example.tf: %w", err)
+		}
 	}
-	if len(result.OKEImages) > 0 {
+	if len(result.OKEImages) > 0 && opts.Renders("oke_example.tf") {
 		if err := writeOKEExample(result, outputDir, opts); err != nil {
 			return fmt.Errorf("oke_example.tf: %w", err)
 		}
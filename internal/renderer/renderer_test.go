@@ -3,13 +3,16 @@ package renderer
 import (
 	"bytes"
 	"encoding/json"
+	"math"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
 	"github.com/larsenclose/oci-tf-bootstrap/internal/discovery"
+	"github.com/larsenclose/oci-tf-bootstrap/internal/pricing"
 )
 
 func TestOutputJSON(t *testing.T) {
@@ -43,6 +46,12 @@ func TestOutputJSON(t *testing.T) {
 	if tenancy["id"] != "ocid1.tenancy.oc1..test" {
 		t.Errorf("expected tenancy ID to be ocid1.tenancy.oc1..test, got %v", tenancy["id"])
 	}
+	if parsed["format_version"] != FormatVersion {
+		t.Errorf("expected format_version %s, got %v", FormatVersion, parsed["format_version"])
+	}
+	if result.FormatVersion != "" {
+		t.Error("expected OutputJSON not to modify the result")
+	}
 }
 
 func TestOutputTerraform(t *testing.T) {
@@ -104,6 +113,59 @@ func TestOutputTerraform(t *testing.T) {
 	}
 }
 
+func TestOutputTerraformKinds(t *testing.T) {
+	tmpDir := t.TempDir()
+	result := &discovery.Result{
+		Tenancy:             discovery.TenancyInfo{ID: "ocid1.tenancy.oc1..test", HomeRegion: "us-phoenix-1"},
+		AvailabilityDomains: []discovery.AvailabilityDomain{{Name: "TEST:AD-1"}},
+		Images:              []discovery.Image{{OS: "Canonical Ubuntu", OSVersion: "24.04"}},
+	}
+
+	// A Packer-style run: only ADs and images were discovered
+	opts := Options{Kinds: []string{"availability-domains", "images"}, Budget: 50}
+	if err := OutputTerraform(result, tmpDir, opts); err != nil {
+		t.Fatalf("OutputTerraform failed: %v", err)
+	}
+	for _, fname := range []string{"provider.tf", "locals.tf", "data.tf"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, fname)); err != nil {
+			t.Errorf("expected %s: %v", fname, err)
+		}
+	}
+	// network.tf would create a VCN although VCNs were never looked for
+	for _, fname := range []string{"network.tf", "instance_example.tf", "cost_estimate.md", "budget.tf"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, fname)); err == nil {
+			t.Errorf("%s should not be generated without its resource kinds", fname)
+		}
+	}
+
+	// --include shapes: the estimate is written, but prices no instance file
+	shapesDir := t.TempDir()
+	result.Shapes = []discovery.Shape{{Name: "VM.Standard.E4.Flex"}}
+	if err := OutputTerraform(result, shapesDir, Options{Kinds: []string{"shapes"}, Instances: 3}); err != nil {
+		t.Fatalf("OutputTerraform failed: %v", err)
+	}
+	estimate, err := os.ReadFile(filepath.Join(shapesDir, "cost_estimate.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(estimate), "oci_core_instance") {
+		t.Errorf("cost_estimate.md lists instances that were not generated:\n%s", estimate)
+	}
+
+	// Every kind a file depends on is a discoverer
+	var names []string
+	for _, d := range discovery.Discoverers() {
+		names = append(names, d.Name())
+	}
+	for file, kinds := range FileKinds {
+		for _, kind := range kinds {
+			if !slices.Contains(names, kind) {
+				t.Errorf("%s depends on unknown kind %q", file, kind)
+			}
+		}
+	}
+}
+
 func TestOutputTerraformAlwaysFree(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "oci-tf-bootstrap-test-*")
 	if err != nil {
@@ -826,6 +888,1061 @@ func TestWriteLocalsWithCompartment(t *testing.T) {
 	}
 }
 
+func TestWriteLocalsWithStorage(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	result := &discovery.Result{
+		Tenancy: discovery.TenancyInfo{
+			ID:         "ocid1.tenancy.oc1..test",
+			HomeRegion: "us-phoenix-1",
+		},
+		BlockVolumes: []discovery.BlockVolume{
+			{ID: "ocid1.volume.oc1..data", DisplayName: "data", SizeGB: 100, AvailabilityDomain: "TEST:AD-1", VPUsPerGB: 20},
+		},
+		BootVolumes: []discovery.BootVolume{
+			{ID: "ocid1.bootvolume.oc1..boot", DisplayName: "web (Boot Volume)", SizeGB: 150, AvailabilityDomain: "TEST:AD-1", VPUsPerGB: 10},
+		},
+		VolumeGroups: []discovery.VolumeGroup{
+			{ID: "ocid1.volumegroup.oc1..vg", DisplayName: "app-group", SizeGB: 250, VolumeIDs: []string{"a", "b"}, AvailabilityDomain: "TEST:AD-1"},
+		},
+		BackupPolicies: []discovery.BackupPolicy{
+			{ID: "ocid1.volumebackuppolicy.oc1..gold", DisplayName: "gold", IsOracleDefined: true, Schedules: []discovery.BackupSchedule{{BackupType: "INCREMENTAL", Period: "ONE_DAY"}}},
+			{ID: "ocid1.volumebackuppolicy.oc1..custom", DisplayName: "nightly", CompartmentID: "ocid1.compartment.oc1..x"},
+		},
+	}
+
+	if err := writeLocals(result, tmpDir, Options{AlwaysFree: true}); err != nil {
+		t.Fatalf("writeLocals failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "locals.tf"))
+	if err != nil {
+		t.Fatalf("failed to read locals.tf: %v", err)
+	}
+	contentStr := string(content)
+
+	expected := []string{
+		`blockvol_data = "ocid1.volume.oc1..data"  # 100GB, TEST:AD-1, Higher Performance (20 VPUs/GB)`,
+		`bootvol_web_boot_volume = "ocid1.bootvolume.oc1..boot"  # 150GB, TEST:AD-1, Balanced (10 VPUs/GB)`,
+		"Total block storage (boot + block volumes): 250GB",
+		"exceed the free limit by 50GB",
+		`volgroup_app_group = "ocid1.volumegroup.oc1..vg"  # 2 volumes, 250GB`,
+		`backup_policy_gold = "ocid1.volumebackuppolicy.oc1..gold"  # Oracle-defined, 1 schedules`,
+		`backup_policy_nightly = "ocid1.volumebackuppolicy.oc1..custom"  # custom, 0 schedules`,
+	}
+	for _, e := range expected {
+		if !strings.Contains(contentStr, e) {
+			t.Errorf("locals.tf should contain %q", e)
+		}
+	}
+}
+
+func TestVolumePerformance(t *testing.T) {
+	tests := []struct {
+		vpus     int64
+		expected string
+	}{
+		{0, "Lower Cost (0 VPUs/GB)"},
+		{10, "Balanced (10 VPUs/GB)"},
+		{20, "Higher Performance (20 VPUs/GB)"},
+		{120, "Ultra High Performance (120 VPUs/GB)"},
+	}
+	for _, tt := range tests {
+		if got := volumePerformance(tt.vpus); got != tt.expected {
+			t.Errorf("volumePerformance(%d) = %q, want %q", tt.vpus, got, tt.expected)
+		}
+	}
+}
+
+func TestWriteInstanceExampleBackupPolicy(t *testing.T) {
+	baseResult := func() *discovery.Result {
+		return &discovery.Result{
+			Tenancy: discovery.TenancyInfo{ID: "ocid1.tenancy.oc1..test"},
+			Images: []discovery.Image{
+				{OS: "Canonical Ubuntu", OSVersion: "24.04"},
+			},
+			BackupPolicies: []discovery.BackupPolicy{
+				{ID: "ocid1.volumebackuppolicy.oc1..silver", DisplayName: "silver", IsOracleDefined: true},
+			},
+		}
+	}
+
+	render := func(t *testing.T, result *discovery.Result, opts Options) string {
+		t.Helper()
+		tmpDir := t.TempDir()
+		if err := writeInstanceExample(result, tmpDir, opts); err != nil {
+			t.Fatalf("writeInstanceExample failed: %v", err)
+		}
+		content, err := os.ReadFile(filepath.Join(tmpDir, "instance_example.tf"))
+		if err != nil {
+			t.Fatalf("failed to read instance_example.tf: %v", err)
+		}
+		return string(content)
+	}
+
+	t.Run("unset renders commented assignments", func(t *testing.T) {
+		content := render(t, baseResult(), Options{})
+		if !strings.Contains(content, `# resource "oci_core_volume" "example_data"`) ||
+			!strings.Contains(content, `# resource "oci_core_volume_attachment" "example_data"`) {
+			t.Error("block volume example should be commented out when no policy is selected")
+		}
+		if !strings.Contains(content, `# resource "oci_core_volume_backup_policy_assignment" "example_boot"`) {
+			t.Error("backup policy assignment should be commented out when no policy is selected")
+		}
+		if !strings.Contains(content, "Discovered policies: silver") {
+			t.Error("should list discovered policies")
+		}
+	})
+
+	t.Run("discovered policy uses local", func(t *testing.T) {
+		content := render(t, baseResult(), Options{BackupPolicy: "Silver"})
+		if !strings.Contains(content, "\nresource \"oci_core_volume\" \"example_data\"") ||
+			!strings.Contains(content, "\nresource \"oci_core_volume_attachment\" \"example_data\"") {
+			t.Error("block volume example should be active")
+		}
+		if !strings.Contains(content, "\nresource \"oci_core_volume_backup_policy_assignment\" \"example_boot\"") {
+			t.Error("boot volume assignment should be active")
+		}
+		if !strings.Contains(content, "asset_id  = oci_core_instance.example.boot_volume_id") {
+			t.Error("boot assignment should reference the instance boot volume")
+		}
+		if !strings.Contains(content, "policy_id = local.backup_policy_silver") {
+			t.Error("assignment should reference the discovered policy local")
+		}
+		if strings.Contains(content, "oci_core_volume_backup_policies") {
+			t.Error("data source lookup should not be emitted for a discovered policy")
+		}
+	})
+
+	t.Run("undiscovered policy falls back to data source", func(t *testing.T) {
+		result := baseResult()
+		result.BackupPolicies = nil
+		content := render(t, result, Options{BackupPolicy: "gold", AlwaysFree: true})
+		if !strings.Contains(content, `data "oci_core_volume_backup_policies" "selected"`) {
+			t.Error("should look up the policy by display name")
+		}
+		if !strings.Contains(content, `values = ["gold"]`) {
+			t.Error("data source should filter on the policy name")
+		}
+		if !strings.Contains(content, "asset_id  = oci_core_volume.always_free_data.id") {
+			t.Error("data volume assignment should reference the always-free data volume")
+		}
+	})
+}
+
+func TestWriteIAMReport(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	result := &discovery.Result{
+		Tenancy: discovery.TenancyInfo{
+			ID:         "ocid1.tenancy.oc1..test",
+			Name:       "test-tenancy",
+			HomeRegion: "us-phoenix-1",
+		},
+		Compartments: []discovery.Compartment{
+			{ID: "ocid1.compartment.oc1..net", Name: "network", ParentID: "ocid1.tenancy.oc1..test"},
+			{ID: "ocid1.compartment.oc1..prod", Name: "prod", ParentID: "ocid1.compartment.oc1..net"},
+		},
+		Groups: []discovery.Group{
+			{ID: "ocid1.group.oc1..admins", Name: "Administrators", Description: "Admins | ops"},
+		},
+		DynamicGroups: []discovery.DynamicGroup{
+			{ID: "ocid1.dynamicgroup.oc1..dg", Name: "instances", MatchingRule: "ALL {instance.compartment.id = 'x'}"},
+		},
+		Policies: []discovery.Policy{
+			{ID: "ocid1.policy.oc1..prod", Name: "prod-ops", CompartmentID: "ocid1.compartment.oc1..prod", CompartmentName: "prod", Statements: []string{"Allow group Ops to manage instance-family in compartment prod"}},
+			{ID: "ocid1.policy.oc1..root", Name: "Tenant Admin Policy", CompartmentID: "ocid1.tenancy.oc1..test", CompartmentName: "root", Statements: []string{"ALLOW GROUP Administrators to manage all-resources IN TENANCY"}},
+		},
+	}
+
+	if err := OutputTerraform(result, tmpDir, Options{}); err != nil {
+		t.Fatalf("OutputTerraform failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "iam_report.md"))
+	if err != nil {
+		t.Fatalf("failed to read iam_report.md: %v", err)
+	}
+	report := string(content)
+
+	for _, e := range []string{
+		"tenancy `test-tenancy`",
+		`| Administrators | Admins \| ops | ` + "`ocid1.group.oc1..admins`",
+		"## Dynamic Groups",
+		"### root\n",
+		"### root/network/prod\n",
+		"Allow group Ops to manage instance-family in compartment prod",
+	} {
+		if !strings.Contains(report, e) {
+			t.Errorf("iam_report.md should contain %q", e)
+		}
+	}
+	if strings.Index(report, "### root\n") > strings.Index(report, "### root/network/prod") {
+		t.Error("root policies should be listed before compartment policies")
+	}
+
+	locals, err := os.ReadFile(filepath.Join(tmpDir, "locals.tf"))
+	if err != nil {
+		t.Fatalf("failed to read locals.tf: %v", err)
+	}
+	for _, e := range []string{
+		`group_administrators = "ocid1.group.oc1..admins"`,
+		`dynamic_group_instances = "ocid1.dynamicgroup.oc1..dg"`,
+		`policy_prod_ops = "ocid1.policy.oc1..prod"  # prod, 1 statements`,
+	} {
+		if !strings.Contains(string(locals), e) {
+			t.Errorf("locals.tf should contain %q", e)
+		}
+	}
+}
+
+func TestOutputTerraformNoIAMReport(t *testing.T) {
+	tmpDir := t.TempDir()
+	result := &discovery.Result{
+		Tenancy: discovery.TenancyInfo{ID: "ocid1.tenancy.oc1..test", HomeRegion: "us-phoenix-1"},
+	}
+	if err := OutputTerraform(result, tmpDir, Options{}); err != nil {
+		t.Fatalf("OutputTerraform failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "iam_report.md")); !os.IsNotExist(err) {
+		t.Error("iam_report.md should not be created without IAM data")
+	}
+}
+
+func TestOutputTerraformDefinedTags(t *testing.T) {
+	result := &discovery.Result{
+		Tenancy: discovery.TenancyInfo{ID: "ocid1.tenancy.oc1..test", HomeRegion: "us-phoenix-1"},
+		Images: []discovery.Image{
+			{ID: "img-1", OS: "Canonical Ubuntu", OSVersion: "22.04"},
+		},
+		OKEImages: []discovery.OKEImage{
+			{ID: "oke-1", SourceName: "Oracle-Linux-8.10-aarch64-OKE-1.31.10", KubernetesVersion: "1.31.10", Architecture: "aarch64"},
+		},
+		TagNamespaces: []discovery.TagNamespace{
+			{ID: "ns-1", Name: "Operations", Definitions: []discovery.TagDefinition{
+				{Name: "CostCenter", AllowedValues: []string{"eng", "ops"}},
+				{Name: "Owner"},
+			}},
+		},
+		TagDefaults: []discovery.TagDefault{
+			{Namespace: "Operations", Key: "CostCenter", IsRequired: true},
+		},
+	}
+
+	t.Run("with tags", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		opts := Options{Tags: map[string]string{"Operations.CostCenter": "eng", "Operations.Owner": "alice"}}
+		if err := OutputTerraform(result, tmpDir, opts); err != nil {
+			t.Fatalf("OutputTerraform failed: %v", err)
+		}
+
+		locals, _ := os.ReadFile(filepath.Join(tmpDir, "locals.tf"))
+		for _, e := range []string{
+			"  common_tags = {",
+			`    "Operations.CostCenter" = "eng"`,
+			`    "Operations.Owner"      = "alice"`,
+			`tag_namespace_operations = "ns-1"  # 2 tags: CostCenter (eng|ops), Owner`,
+			"# Required tags (supply with --tags): Operations.CostCenter",
+		} {
+			if !strings.Contains(string(locals), e) {
+				t.Errorf("locals.tf should contain %q", e)
+			}
+		}
+
+		// Every taggable resource block gets the common tags
+		for file, want := range map[string]int{
+			"network.tf":          5,  // vcn, igw, route table, security list, subnet
+			"instance_example.tf": 2,  // instance, data volume
+			"oke_example.tf":      15, // vcn, 3 gateways, 2 route tables, 3 security lists, 3 subnets, cluster, node pool, virtual node pool (commented)
+		} {
+			content, err := os.ReadFile(filepath.Join(tmpDir, file))
+			if err != nil {
+				t.Fatalf("failed to read %s: %v", file, err)
+			}
+			if got := strings.Count(string(content), "defined_tags = local.common_tags"); got != want {
+				t.Errorf("%s: expected %d defined_tags lines, got %d", file, want, got)
+			}
+		}
+	})
+
+	t.Run("without tags", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		if err := OutputTerraform(result, tmpDir, Options{}); err != nil {
+			t.Fatalf("OutputTerraform failed: %v", err)
+		}
+		for _, file := range []string{"locals.tf", "network.tf", "instance_example.tf", "oke_example.tf"} {
+			content, _ := os.ReadFile(filepath.Join(tmpDir, file))
+			if strings.Contains(string(content), "common_tags") {
+				t.Errorf("%s should not reference common_tags without --tags", file)
+			}
+		}
+	})
+}
+
+func TestInternalFQDN(t *testing.T) {
+	tests := []struct {
+		host, subnet, vcn, want string
+	}{
+		{"example-instance", "pub", "main", "example-instance.pub.main.oraclevcn.com"},
+		{"example-instance", "", "main", ""},
+		{"example-instance", "pub", "", ""},
+	}
+	for _, tt := range tests {
+		if got := internalFQDN(tt.host, tt.subnet, tt.vcn); got != tt.want {
+			t.Errorf("internalFQDN(%q, %q, %q) = %q, want %q", tt.host, tt.subnet, tt.vcn, got, tt.want)
+		}
+	}
+}
+
+func TestWriteInstanceExampleFQDN(t *testing.T) {
+	vcn := discovery.VCN{
+		ID: "vcn-1", DisplayName: "main", DNSLabel: "main",
+		Subnets: []discovery.Subnet{
+			{ID: "sub-pub", DisplayName: "public", IsPublic: true, DNSLabel: "pub"},
+		},
+	}
+
+	t.Run("discovered subnet with DNS", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		result := &discovery.Result{
+			Tenancy: discovery.TenancyInfo{ID: "ocid1.tenancy.oc1..test", HomeRegion: "us-phoenix-1"},
+			VCNs:    []discovery.VCN{vcn},
+		}
+		if err := OutputTerraform(result, tmpDir, Options{}); err != nil {
+			t.Fatalf("OutputTerraform failed: %v", err)
+		}
+		content, _ := os.ReadFile(filepath.Join(tmpDir, "instance_example.tf"))
+		for _, e := range []string{
+			`    hostname_label   = "example-instance"`,
+			`output "example_fqdn" {`,
+			`  value = "example-instance.pub.main.oraclevcn.com"`,
+		} {
+			if !strings.Contains(string(content), e) {
+				t.Errorf("instance_example.tf should contain %q", e)
+			}
+		}
+	})
+
+	t.Run("subnet without DNS label", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		noDNS := vcn
+		noDNS.Subnets = []discovery.Subnet{{ID: "sub-pub", DisplayName: "public", IsPublic: true}}
+		result := &discovery.Result{
+			Tenancy: discovery.TenancyInfo{ID: "ocid1.tenancy.oc1..test", HomeRegion: "us-phoenix-1"},
+			VCNs:    []discovery.VCN{noDNS},
+		}
+		if err := OutputTerraform(result, tmpDir, Options{AlwaysFree: true}); err != nil {
+			t.Fatalf("OutputTerraform failed: %v", err)
+		}
+		content, _ := os.ReadFile(filepath.Join(tmpDir, "instance_example.tf"))
+		if strings.Contains(string(content), "hostname_label") || strings.Contains(string(content), "_fqdn") {
+			t.Error("instance_example.tf should not set a hostname when the subnet has DNS disabled")
+		}
+	})
+
+	t.Run("bootstrap network", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		result := &discovery.Result{
+			Tenancy: discovery.TenancyInfo{ID: "ocid1.tenancy.oc1..test", HomeRegion: "us-phoenix-1"},
+		}
+		if err := OutputTerraform(result, tmpDir, Options{AlwaysFree: true}); err != nil {
+			t.Fatalf("OutputTerraform failed: %v", err)
+		}
+		content, _ := os.ReadFile(filepath.Join(tmpDir, "instance_example.tf"))
+		if !strings.Contains(string(content), `  value = "always-free-arm.public.bootstrap.oraclevcn.com"`) {
+			t.Error("instance_example.tf should output the FQDN within the bootstrap VCN")
+		}
+	})
+}
+
+func TestWriteLocalsWithDNS(t *testing.T) {
+	tmpDir := t.TempDir()
+	result := &discovery.Result{
+		Tenancy: discovery.TenancyInfo{ID: "ocid1.tenancy.oc1..test", HomeRegion: "us-phoenix-1"},
+		VCNs: []discovery.VCN{
+			{ID: "vcn-1", DisplayName: "main", Subnets: []discovery.Subnet{{ID: "sub-priv", DisplayName: "app"}}},
+		},
+		DNSZones: []discovery.DNSZone{
+			{ID: "zone-pub", Name: "example.com", Scope: "GLOBAL", ZoneType: "PRIMARY"},
+			{ID: "zone-priv", Name: "corp.internal", Scope: "PRIVATE", ZoneType: "PRIMARY", ViewID: "view-1"},
+		},
+		DNSViews: []discovery.DNSView{
+			{ID: "view-1", Name: "corp"},
+			{ID: "view-2", Name: "main", IsProtected: true},
+		},
+		DNSResolvers: []discovery.DNSResolver{
+			{ID: "resolver-1", Name: "main", VCNID: "vcn-1", Endpoints: []discovery.ResolverEndpoint{
+				{Name: "inbound", SubnetID: "sub-priv", IsListening: true, ListeningAddress: "10.0.1.53"},
+			}},
+		},
+	}
+	if err := OutputTerraform(result, tmpDir, Options{}); err != nil {
+		t.Fatalf("OutputTerraform failed: %v", err)
+	}
+
+	locals, _ := os.ReadFile(filepath.Join(tmpDir, "locals.tf"))
+	for _, e := range []string{
+		`dns_view_corp = "view-1"`,
+		`dns_view_main = "view-2"  # Oracle-managed VCN default view`,
+		`dns_zone_example_com = "zone-pub"  # example.com GLOBAL`,
+		`dns_zone_corp_internal = "zone-priv"  # corp.internal PRIVATE, view local.dns_view_corp`,
+		`dns_resolver_main = "resolver-1"  # VCN main, 1 endpoints`,
+		"#   inbound: listening 10.0.1.53 in local.subnet_app",
+	} {
+		if !strings.Contains(string(locals), e) {
+			t.Errorf("locals.tf should contain %q", e)
+		}
+	}
+}
+
+func TestWriteObservability(t *testing.T) {
+	result := &discovery.Result{
+		Tenancy: discovery.TenancyInfo{ID: "ocid1.tenancy.oc1..test", HomeRegion: "us-phoenix-1"},
+		VCNs: []discovery.VCN{
+			{ID: "vcn-1", DisplayName: "main", Subnets: []discovery.Subnet{
+				{ID: "sub-pub", DisplayName: "public", IsPublic: true},
+				{ID: "sub-priv", DisplayName: "app"},
+			}},
+		},
+		LogGroups:          []discovery.LogGroup{{ID: "lg-1", Name: "app-logs"}},
+		NotificationTopics: []discovery.NotificationTopic{{ID: "topic-1", Name: "ops-alerts"}},
+		Alarms:             []discovery.Alarm{{ID: "alarm-1", Name: "cpu-high", Severity: "CRITICAL", Namespace: "oci_computeagent", IsEnabled: true}},
+	}
+
+	t.Run("with --observability", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		if err := OutputTerraform(result, tmpDir, Options{Observability: true}); err != nil {
+			t.Fatalf("OutputTerraform failed: %v", err)
+		}
+
+		locals, _ := os.ReadFile(filepath.Join(tmpDir, "locals.tf"))
+		for _, e := range []string{
+			`log_group_app_logs = "lg-1"`,
+			`ons_topic_ops_alerts = "topic-1"`,
+			`alarm_cpu_high = "alarm-1"  # CRITICAL, oci_computeagent, enabled`,
+		} {
+			if !strings.Contains(string(locals), e) {
+				t.Errorf("locals.tf should contain %q", e)
+			}
+		}
+
+		content, err := os.ReadFile(filepath.Join(tmpDir, "observability.tf"))
+		if err != nil {
+			t.Fatalf("failed to read observability.tf: %v", err)
+		}
+		for _, e := range []string{
+			`variable "alert_email" {`,
+			`resource "oci_logging_log_group" "bootstrap" {`,
+			`resource "oci_logging_log" "flow_public" {`,
+			"      resource    = local.subnet_app",
+			`      service     = "flowlogs"`,
+			"  endpoint       = var.alert_email",
+			`resource "oci_monitoring_alarm" "example_cpu" {`,
+			`  query                 = "CpuUtilization[1m]{resourceId = \"${oci_core_instance.example.id}\"}.mean() > 80"`,
+			`resource "oci_monitoring_alarm" "example_memory" {`,
+		} {
+			if !strings.Contains(string(content), e) {
+				t.Errorf("observability.tf should contain %q", e)
+			}
+		}
+	})
+
+	t.Run("always-free alarms target the free instance", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		if err := OutputTerraform(result, tmpDir, Options{Observability: true, AlwaysFree: true}); err != nil {
+			t.Fatalf("OutputTerraform failed: %v", err)
+		}
+		content, _ := os.ReadFile(filepath.Join(tmpDir, "observability.tf"))
+		if !strings.Contains(string(content), "oci_core_instance.always_free.id") {
+			t.Error("alarms should reference oci_core_instance.always_free in always-free mode")
+		}
+	})
+
+	t.Run("without --observability", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		if err := OutputTerraform(result, tmpDir, Options{}); err != nil {
+			t.Fatalf("OutputTerraform failed: %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(tmpDir, "observability.tf")); !os.IsNotExist(err) {
+			t.Error("observability.tf should only be generated with --observability")
+		}
+	})
+}
+
+func TestWriteBudget(t *testing.T) {
+	result := &discovery.Result{
+		Tenancy:       discovery.TenancyInfo{ID: "ocid1.tenancy.oc1..test", HomeRegion: "us-phoenix-1"},
+		CompartmentID: "comp-dev",
+		Compartments: []discovery.Compartment{
+			{ID: "comp-eng", Name: "eng", ParentID: "ocid1.tenancy.oc1..test"},
+			{ID: "comp-dev", Name: "dev", ParentID: "comp-eng"},
+		},
+		Shapes: []discovery.Shape{{Name: "VM.Standard.A1.Flex"}},
+		Budgets: []discovery.Budget{
+			{ID: "budget-1", Name: "eng-monthly", Amount: 500, ResetPeriod: "MONTHLY", TargetType: "COMPARTMENT", ActualSpend: 42.5,
+				AlertRules: []discovery.BudgetAlertRule{{ID: "rule-1", Type: "ACTUAL", Threshold: 90}}},
+		},
+		Quotas: []discovery.Quota{
+			{ID: "quota-1", Name: "eng-limits", Statements: []string{"zero database quotas in compartment eng"}},
+		},
+	}
+
+	t.Run("with --budget", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		if err := OutputTerraform(result, tmpDir, Options{Budget: 75}); err != nil {
+			t.Fatalf("OutputTerraform failed: %v", err)
+		}
+
+		locals, _ := os.ReadFile(filepath.Join(tmpDir, "locals.tf"))
+		for _, e := range []string{
+			`budget_eng_monthly = "budget-1"  # 500/MONTHLY, COMPARTMENT target, 1 alert rules, spent 42.5`,
+			`quota_eng_limits = "quota-1"  # 1 statements`,
+			"  #   zero database quotas in compartment eng",
+		} {
+			if !strings.Contains(string(locals), e) {
+				t.Errorf("locals.tf should contain %q", e)
+			}
+		}
+
+		content, err := os.ReadFile(filepath.Join(tmpDir, "budget.tf"))
+		if err != nil {
+			t.Fatalf("failed to read budget.tf: %v", err)
+		}
+		for _, e := range []string{
+			`variable "budget_alert_email" {`,
+			`resource "oci_budget_budget" "bootstrap" {`,
+			"  amount         = 75",
+			"  targets        = [local.compartment_ocid]",
+			`resource "oci_budget_alert_rule" "actual_80" {`,
+			`resource "oci_budget_alert_rule" "forecast_100" {`,
+			`  message        = "Forecast spend has reached 100% of the bootstrap budget"`,
+			`resource "oci_limits_quota" "bootstrap" {`,
+			`    "zero compute-core quotas in compartment eng:dev",`,
+			`    "set compute-core quota standard-e4-core-count to 8 in compartment eng:dev",`,
+			`    "set compute-memory quota standard-e4-memory-count to 128 in compartment eng:dev",`,
+		} {
+			if !strings.Contains(string(content), e) {
+				t.Errorf("budget.tf should contain %q", e)
+			}
+		}
+	})
+
+	t.Run("with tags", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		if err := OutputTerraform(result, tmpDir, Options{Budget: 75, Tags: map[string]string{"Operations.CostCenter": "eng"}}); err != nil {
+			t.Fatalf("OutputTerraform failed: %v", err)
+		}
+		content, _ := os.ReadFile(filepath.Join(tmpDir, "budget.tf"))
+		// budget, 3 alert rules, quota
+		if got := strings.Count(string(content), "defined_tags = local.common_tags"); got != 5 {
+			t.Errorf("budget.tf: expected 5 defined_tags lines, got %d", got)
+		}
+	})
+
+	t.Run("always-free caps the free shapes", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		if err := OutputTerraform(result, tmpDir, Options{Budget: 1, AlwaysFree: true}); err != nil {
+			t.Fatalf("OutputTerraform failed: %v", err)
+		}
+		content, _ := os.ReadFile(filepath.Join(tmpDir, "budget.tf"))
+		for _, e := range []string{
+			`"set compute-core quota standard-a1-core-count to 4 in compartment eng:dev",`,
+			`"set compute-memory quota standard-a1-memory-count to 24 in compartment eng:dev",`,
+		} {
+			if !strings.Contains(string(content), e) {
+				t.Errorf("budget.tf should contain %q", e)
+			}
+		}
+		if strings.Contains(string(content), "standard-e4") {
+			t.Error("always-free quota should not allow E4.Flex")
+		}
+	})
+
+	t.Run("unknown compartment comments out the quota", func(t *testing.T) {
+		unknown := *result
+		unknown.CompartmentID = "comp-missing"
+		tmpDir := t.TempDir()
+		if err := OutputTerraform(&unknown, tmpDir, Options{Budget: 75}); err != nil {
+			t.Fatalf("OutputTerraform failed: %v", err)
+		}
+		content, _ := os.ReadFile(filepath.Join(tmpDir, "budget.tf"))
+		if !strings.Contains(string(content), `# resource "oci_limits_quota" "bootstrap" {`) {
+			t.Error("quota should be commented out when the compartment path is unknown")
+		}
+	})
+
+	t.Run("without --budget", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		if err := OutputTerraform(result, tmpDir, Options{}); err != nil {
+			t.Fatalf("OutputTerraform failed: %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(tmpDir, "budget.tf")); !os.IsNotExist(err) {
+			t.Error("budget.tf should only be generated with --budget")
+		}
+	})
+}
+
+func TestEstimateCost(t *testing.T) {
+	prices := &pricing.Catalog{
+		Version:       "test",
+		Currency:      "USD",
+		HoursPerMonth: 100,
+		Shapes: map[string]pricing.ShapePrice{
+			"VM.Standard.A1.Flex":    {OCPUHour: 0.01, MemoryGBHour: 0.001},
+			"VM.Standard.E2.1.Micro": {},
+			"VM.Standard.E4.Flex":    {OCPUHour: 0.1, MemoryGBHour: 0.01},
+		},
+		BlockVolume: pricing.BlockVolumePrice{GBMonth: 0.1, VPUGBMonth: 0.01},
+		Kubernetes:  pricing.KubernetesPrice{EnhancedClusterHour: 1, VirtualNodeHour: 0.5},
+		AlwaysFree:  pricing.AlwaysFreeAllowance{A1OCPUs: 4, A1MemoryGBs: 24, MicroInstances: 2, BlockVolumeGBs: 200},
+	}
+	okeImages := []discovery.OKEImage{
+		{ID: "img-arm", SourceName: "Oracle-Linux-8.10-aarch64-2025.11.20-0-OKE-1.31.10-1345", KubernetesVersion: "1.31.10", Architecture: "aarch64"},
+		{ID: "img-x86", SourceName: "Oracle-Linux-8.10-2025.11.20-0-OKE-1.31.10-1345", KubernetesVersion: "1.31.10", Architecture: "x86_64"},
+	}
+	shapes := []discovery.Shape{{Name: "VM.Standard.A1.Flex"}, {Name: "VM.Standard.E4.Flex"}}
+
+	t.Run("standard instance", func(t *testing.T) {
+		est := EstimateCost(&discovery.Result{}, Options{Prices: prices})
+		if len(est.Items) != 2 {
+			t.Fatalf("expected instance and boot volume items, got %+v", est.Items)
+		}
+		// 1 OCPU / 6 GB E4 = 16, 47 GB boot = 9.4
+		if math.Abs(est.Total-25.4) > 1e-9 {
+			t.Errorf("expected total 25.4, got %v", est.Total)
+		}
+		if est.CatalogVersion != "test" {
+			t.Errorf("expected catalog version test, got %q", est.CatalogVersion)
+		}
+
+		// The data volume is only created with a backup policy; 50 GB block = 10
+		est = EstimateCost(&discovery.Result{}, Options{Prices: prices, BackupPolicy: "bronze"})
+		if len(est.Items) != 3 || est.Items[2].Resource != "oci_core_volume.example_data" || math.Abs(est.Total-35.4) > 1e-9 {
+			t.Errorf("expected the block volume priced at 10, got %+v", est.Items)
+		}
+	})
+
+	t.Run("always-free allowance", func(t *testing.T) {
+		result := &discovery.Result{Shapes: shapes, OKEImages: okeImages, OKESupportedVersions: []string{"v1.31.10"}}
+		est := EstimateCost(result, Options{Prices: prices, AlwaysFree: true})
+		// A1 instance and ARM pool use the 4 OCPU / 24 GB allowance and the three
+		// 50 GB boot volumes fit in the 200 GB; only the x86 pool's 2 OCPU / 16 GB is charged.
+		if math.Abs(est.Total-36) > 1e-9 {
+			t.Errorf("expected always-free total 36, got %v: %+v", est.Total, est.Items)
+		}
+		for _, item := range est.Items {
+			charged := item.Resource == "oci_containerengine_node_pool.x86_pool_v1_31_10" && strings.HasPrefix(item.Description, "VM.Standard.E4.Flex")
+			if item.AlwaysFree == charged && item.Resource != "oci_containerengine_cluster.oke" {
+				t.Errorf("%s (%s): always_free = %v", item.Resource, item.Description, item.AlwaysFree)
+			}
+		}
+	})
+
+	t.Run("spread instances", func(t *testing.T) {
+		est := EstimateCost(&discovery.Result{}, Options{Prices: prices, Instances: 3})
+		// Example instance and boot volume (25.4) plus three E4 instances with 47 GB boot volumes
+		if math.Abs(est.Total-101.6) > 1e-9 {
+			t.Errorf("expected total 101.6, got %v", est.Total)
+		}
+		if est.Items[len(est.Items)-1].Resource != "oci_core_instance.spread[2]" {
+			t.Errorf("expected spread instances to be itemized, got %+v", est.Items)
+		}
+	})
+
+	t.Run("only files that are written", func(t *testing.T) {
+		result := &discovery.Result{Shapes: shapes, OKEImages: okeImages, OKESupportedVersions: []string{"v1.31.10"}}
+		est := EstimateCost(result, Options{Prices: prices, Instances: 3, Kinds: []string{"shapes"}})
+		if len(est.Items) != 0 || est.Total != 0 {
+			t.Errorf("expected nothing priced without the instance and OKE files, got %+v", est.Items)
+		}
+	})
+
+	t.Run("virtual nodes", func(t *testing.T) {
+		result := &discovery.Result{Shapes: shapes, OKEImages: okeImages, OKESupportedVersions: []string{"v1.31.10"}}
+		est := EstimateCost(result, Options{Prices: prices, VirtualNodes: true})
+		var cluster, virtual float64
+		for _, item := range est.Items {
+			switch item.Resource {
+			case "oci_containerengine_cluster.oke":
+				cluster = item.Monthly
+			case "oci_containerengine_virtual_node_pool.virtual":
+				virtual = item.Monthly
+			}
+		}
+		if cluster != 100 || virtual != 50 {
+			t.Errorf("expected enhanced cluster 100 and virtual node 50, got %v and %v", cluster, virtual)
+		}
+	})
+
+	t.Run("unpriced shapes are excluded", func(t *testing.T) {
+		result := &discovery.Result{Shapes: []discovery.Shape{{Name: "VM.Standard.E5.Flex"}}, OKEImages: okeImages[1:], OKESupportedVersions: []string{"v1.31.10"}}
+		tmpDir := t.TempDir()
+		if err := OutputTerraform(result, tmpDir, Options{Prices: prices}); err != nil {
+			t.Fatalf("OutputTerraform failed: %v", err)
+		}
+		content, err := os.ReadFile(filepath.Join(tmpDir, "cost_estimate.md"))
+		if err != nil {
+			t.Fatalf("failed to read cost_estimate.md: %v", err)
+		}
+		for _, e := range []string{
+			"price catalog `test`",
+			"| `oci_core_instance.example` | VM.Standard.E4.Flex, 1 OCPU / 6 GB | 16.00 |",
+			"| `oci_containerengine_node_pool.x86_pool_v1_31_10` | VM.Standard.E5.Flex, 2 OCPU / 16 GB (not in price catalog) | unknown |",
+			"Not included in the total: `oci_containerengine_node_pool.x86_pool_v1_31_10`.",
+		} {
+			if !strings.Contains(string(content), e) {
+				t.Errorf("cost_estimate.md should contain %q", e)
+			}
+		}
+	})
+}
+
+func TestWriteInstanceExampleCapacity(t *testing.T) {
+	base := discovery.Result{
+		Tenancy: discovery.TenancyInfo{ID: "ocid1.tenancy.oc1..test", HomeRegion: "us-phoenix-1"},
+		AvailabilityDomains: []discovery.AvailabilityDomain{
+			{Name: "Uocm:PHX-AD-1"}, {Name: "Uocm:PHX-AD-2"}, {Name: "Uocm:PHX-AD-3"},
+		},
+		Shapes: []discovery.Shape{{Name: "VM.Standard.A1.Flex"}},
+	}
+
+	render := func(t *testing.T, result *discovery.Result) string {
+		t.Helper()
+		tmpDir := t.TempDir()
+		if err := writeInstanceExample(result, tmpDir, Options{AlwaysFree: true}); err != nil {
+			t.Fatalf("writeInstanceExample failed: %v", err)
+		}
+		content, err := os.ReadFile(filepath.Join(tmpDir, "instance_example.tf"))
+		if err != nil {
+			t.Fatalf("failed to read instance_example.tf: %v", err)
+		}
+		return string(content)
+	}
+
+	t.Run("places the instance where capacity was reported", func(t *testing.T) {
+		result := base
+		result.A1Capacity = &discovery.CapacityReport{
+			Shape: "VM.Standard.A1.Flex", OCPUs: 2, MemoryGB: 12,
+			Domains: []discovery.DomainCapacity{
+				{AvailabilityDomain: "Uocm:PHX-AD-1", FaultDomain: "FAULT-DOMAIN-1", Status: "OUT_OF_HOST_CAPACITY"},
+				{AvailabilityDomain: "Uocm:PHX-AD-2", FaultDomain: "FAULT-DOMAIN-3", Status: "AVAILABLE", AvailableCount: 5},
+			},
+			SelectedAD:          "Uocm:PHX-AD-2",
+			SelectedFaultDomain: "FAULT-DOMAIN-3",
+		}
+		content := render(t, &result)
+		for _, e := range []string{
+			"# A1.Flex capacity for 2 OCPU / 12 GB at discovery time:",
+			"OUT_OF_HOST_CAPACITY",
+			"AVAILABLE (5)",
+			"  availability_domain = local.ad_2",
+			`  fault_domain        = "FAULT-DOMAIN-3"`,
+		} {
+			if !strings.Contains(content, e) {
+				t.Errorf("instance_example.tf should contain %q", e)
+			}
+		}
+		if strings.Contains(content, "try changing availability_domain") {
+			t.Error("the manual AD hint should be replaced by the capacity report")
+		}
+	})
+
+	t.Run("no capacity keeps the first AD with a warning", func(t *testing.T) {
+		result := base
+		result.A1Capacity = &discovery.CapacityReport{
+			Shape: "VM.Standard.A1.Flex", OCPUs: 2, MemoryGB: 12,
+			Domains: []discovery.DomainCapacity{{AvailabilityDomain: "Uocm:PHX-AD-1", Status: "OUT_OF_HOST_CAPACITY"}},
+		}
+		content := render(t, &result)
+		if !strings.Contains(content, "  availability_domain = local.ad_1") || strings.Contains(content, "fault_domain") {
+			t.Error("expected local.ad_1 without a fault domain")
+		}
+		if !strings.Contains(content, "no availability domain reported capacity") {
+			t.Error("expected a no-capacity warning")
+		}
+	})
+
+	t.Run("without a report", func(t *testing.T) {
+		content := render(t, &base)
+		if !strings.Contains(content, "try changing availability_domain to ad_2 or ad_3") {
+			t.Error("expected the manual AD hint without a capacity report")
+		}
+	})
+}
+
+func TestTopologyPlacements(t *testing.T) {
+	result := &discovery.Result{
+		AvailabilityDomains: []discovery.AvailabilityDomain{
+			{Name: "AD-1", FaultDomains: []string{"FAULT-DOMAIN-1", "FAULT-DOMAIN-2"}},
+			{Name: "AD-2", FaultDomains: []string{"FAULT-DOMAIN-1", "FAULT-DOMAIN-2"}},
+		},
+	}
+	const bootstrap = "oci_core_subnet.public.id"
+	want := []placement{
+		{"local.ad_1", "FAULT-DOMAIN-1", bootstrap, true},
+		{"local.ad_2", "FAULT-DOMAIN-1", bootstrap, true},
+		{"local.ad_1", "FAULT-DOMAIN-2", bootstrap, true},
+		{"local.ad_2", "FAULT-DOMAIN-2", bootstrap, true},
+		{"local.ad_1", "FAULT-DOMAIN-1", bootstrap, true},
+	}
+	if got, _ := topologyPlacements(result, 5); !slices.Equal(got, want) {
+		t.Errorf("topologyPlacements() = %v, want %v", got, want)
+	}
+
+	// Without discovered ADs, instances spread over the default fault domains of ad_1
+	got, _ := topologyPlacements(&discovery.Result{}, 4)
+	for i, fd := range []string{"FAULT-DOMAIN-1", "FAULT-DOMAIN-2", "FAULT-DOMAIN-3", "FAULT-DOMAIN-1"} {
+		if got[i] != (placement{"local.ad_1", fd, bootstrap, true}) {
+			t.Errorf("placement %d = %v, want local.ad_1 %s", i, got[i], fd)
+		}
+	}
+
+	t.Run("AD-specific subnets", func(t *testing.T) {
+		result := *result
+		result.AvailabilityDomains = append(slices.Clone(result.AvailabilityDomains), discovery.AvailabilityDomain{Name: "AD-3"})
+		result.VCNs = []discovery.VCN{{Subnets: []discovery.Subnet{
+			{ID: "subnet-1", DisplayName: "web", AvailabilityDomain: "AD-1", IsPublic: true},
+			{ID: "subnet-2", DisplayName: "web", AvailabilityDomain: "AD-2", IsPublic: true},
+			{ID: "subnet-3", DisplayName: "app", AvailabilityDomain: "AD-2"},
+		}}}
+		got, used := topologyPlacements(&result, 3)
+		want := []placement{
+			{"local.ad_1", "FAULT-DOMAIN-1", "local.subnet_web", true},
+			{"local.ad_2", "FAULT-DOMAIN-1", "local.subnet_web_2", true},
+			{"local.ad_1", "FAULT-DOMAIN-2", "local.subnet_web", true},
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("topologyPlacements() = %v, want %v", got, want)
+		}
+		if len(used) != 2 {
+			t.Errorf("expected AD-3 without a subnet to be skipped, got %v", used)
+		}
+	})
+
+	t.Run("regional subnet", func(t *testing.T) {
+		result := *result
+		result.VCNs = []discovery.VCN{{Subnets: []discovery.Subnet{
+			{ID: "subnet-1", DisplayName: "ad1", AvailabilityDomain: "AD-1"},
+			{ID: "subnet-2", DisplayName: "regional"},
+		}}}
+		got, _ := topologyPlacements(&result, 2)
+		for _, p := range got {
+			if p.subnet != "local.subnet_regional" || p.publicIP {
+				t.Errorf("expected the regional subnet in every AD, got %v", got)
+			}
+		}
+	})
+
+	t.Run("no subnet in any AD", func(t *testing.T) {
+		result := *result
+		result.VCNs = []discovery.VCN{{Subnets: []discovery.Subnet{{ID: "subnet-1", AvailabilityDomain: "AD-9"}}}}
+		if got, _ := topologyPlacements(&result, 2); len(got) != 0 {
+			t.Errorf("expected no placements, got %v", got)
+		}
+	})
+}
+
+func TestWriteTopology(t *testing.T) {
+	result := &discovery.Result{
+		AvailabilityDomains: []discovery.AvailabilityDomain{
+			{Name: "AD-1", FaultDomains: []string{"FAULT-DOMAIN-1", "FAULT-DOMAIN-2", "FAULT-DOMAIN-3"}},
+			{Name: "AD-2", FaultDomains: []string{"FAULT-DOMAIN-1", "FAULT-DOMAIN-2", "FAULT-DOMAIN-3"}},
+		},
+		Images: []discovery.Image{{OS: "Oracle Linux", OSVersion: "9", ID: "ocid1.image.oc1..ol9"}},
+		VCNs: []discovery.VCN{{
+			DisplayName: "main",
+			Subnets:     []discovery.Subnet{{ID: "subnet-1", DisplayName: "private", IsPublic: false}},
+		}},
+	}
+
+	renderResult := func(t *testing.T, result *discovery.Result, opts Options) string {
+		t.Helper()
+		tmpDir := t.TempDir()
+		if err := OutputTerraform(result, tmpDir, opts); err != nil {
+			t.Fatalf("OutputTerraform failed: %v", err)
+		}
+		content, err := os.ReadFile(filepath.Join(tmpDir, "instances.tf"))
+		if err != nil {
+			t.Fatalf("failed to read instances.tf: %v", err)
+		}
+		return string(content)
+	}
+	render := func(t *testing.T, opts Options) string {
+		t.Helper()
+		return renderResult(t, result, opts)
+	}
+
+	t.Run("counted instances", func(t *testing.T) {
+		content := render(t, Options{Instances: 3})
+		for _, e := range []string{
+			`{ availability_domain = local.ad_1, fault_domain = "FAULT-DOMAIN-1", subnet_id = local.subnet_private, assign_public_ip = false },`,
+			`{ availability_domain = local.ad_2, fault_domain = "FAULT-DOMAIN-1", subnet_id = local.subnet_private, assign_public_ip = false },`,
+			`{ availability_domain = local.ad_1, fault_domain = "FAULT-DOMAIN-2", subnet_id = local.subnet_private, assign_public_ip = false },`,
+			`resource "oci_core_instance" "spread" {`,
+			"  fault_domain        = local.instance_placements[count.index].fault_domain",
+			`  shape = "VM.Standard.E4.Flex"`,
+			"    subnet_id        = local.instance_placements[count.index].subnet_id",
+			"    assign_public_ip = local.instance_placements[count.index].assign_public_ip",
+		} {
+			if !strings.Contains(content, e) {
+				t.Errorf("instances.tf should contain %q", e)
+			}
+		}
+		if strings.Contains(content, "oci_core_instance_pool") {
+			t.Error("instances.tf should not contain a pool without --instance-pool")
+		}
+	})
+
+	t.Run("instance pool", func(t *testing.T) {
+		content := render(t, Options{Instances: 4, InstancePool: true})
+		for _, e := range []string{
+			`resource "oci_core_instance_configuration" "spread" {`,
+			`resource "oci_core_instance_pool" "spread" {`,
+			"  size                      = 4",
+			"    availability_domain = local.ad_1\n    fault_domains       = [\"FAULT-DOMAIN-1\", \"FAULT-DOMAIN-2\"]",
+			"    availability_domain = local.ad_2\n    fault_domains       = [\"FAULT-DOMAIN-1\", \"FAULT-DOMAIN-2\"]",
+		} {
+			if !strings.Contains(content, e) {
+				t.Errorf("instances.tf should contain %q", e)
+			}
+		}
+		if strings.Contains(content, `resource "oci_core_instance" "spread"`) {
+			t.Error("instance pool should not also generate counted instances")
+		}
+	})
+
+	t.Run("AD-specific subnets", func(t *testing.T) {
+		result := *result
+		result.AvailabilityDomains = append(slices.Clone(result.AvailabilityDomains), discovery.AvailabilityDomain{Name: "AD-3"})
+		result.VCNs = []discovery.VCN{{DisplayName: "main", Subnets: []discovery.Subnet{
+			{ID: "subnet-1", DisplayName: "ad1", AvailabilityDomain: "AD-1", IsPublic: true},
+			{ID: "subnet-2", DisplayName: "ad2", AvailabilityDomain: "AD-2"},
+		}}}
+		content := renderResult(t, &result, Options{Instances: 4, InstancePool: true})
+		for _, e := range []string{
+			"    availability_domain = local.ad_1\n    fault_domains       = [\"FAULT-DOMAIN-1\", \"FAULT-DOMAIN-2\"]\n    primary_subnet_id   = local.subnet_ad1",
+			"    availability_domain = local.ad_2\n    fault_domains       = [\"FAULT-DOMAIN-1\", \"FAULT-DOMAIN-2\"]\n    primary_subnet_id   = local.subnet_ad2",
+			"        assign_public_ip = false",
+			"no regional subnet or subnet in AD-3",
+		} {
+			if !strings.Contains(content, e) {
+				t.Errorf("instances.tf should contain %q", e)
+			}
+		}
+		if strings.Contains(content, "local.ad_3") {
+			t.Error("instances should not be placed in an AD without a subnet")
+		}
+	})
+
+	t.Run("not written for a single instance", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		if err := OutputTerraform(result, tmpDir, Options{Instances: 1}); err != nil {
+			t.Fatalf("OutputTerraform failed: %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(tmpDir, "instances.tf")); !os.IsNotExist(err) {
+			t.Error("instances.tf should not be written for a single instance")
+		}
+	})
+}
+
+func TestWriteFunctionsExample(t *testing.T) {
+	registry := &discovery.Registry{
+		Namespace: "axabc123",
+		RegionKey: "FRA",
+		Endpoint:  "fra.ocir.io",
+		Repositories: []discovery.ContainerRepository{
+			{ID: "repo-1", Name: "team/api", ImageCount: 4},
+			{ID: "repo-2", Name: "web", IsPublic: true, ImageCount: 1},
+		},
+	}
+	vcns := []discovery.VCN{
+		{
+			ID: "vcn-1", DisplayName: "main",
+			Subnets: []discovery.Subnet{
+				{ID: "sub-pub", DisplayName: "public", IsPublic: true},
+				{ID: "sub-priv", DisplayName: "app-private"},
+			},
+		},
+	}
+
+	t.Run("binds to private subnet and writes registry locals", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		result := &discovery.Result{
+			Tenancy:  discovery.TenancyInfo{ID: "ocid1.tenancy.oc1..test", HomeRegion: "eu-frankfurt-1"},
+			VCNs:     vcns,
+			Registry: registry,
+			FunctionsApplications: []discovery.FunctionsApplication{
+				{ID: "fnapp-1", Name: "billing", Shape: "GENERIC_ARM", SubnetIDs: []string{"sub-priv"}},
+			},
+		}
+		if err := OutputTerraform(result, tmpDir, Options{}); err != nil {
+			t.Fatalf("OutputTerraform failed: %v", err)
+		}
+
+		locals, _ := os.ReadFile(filepath.Join(tmpDir, "locals.tf"))
+		for _, e := range []string{
+			`tenancy_namespace = "axabc123"`,
+			`ocir_registry     = "fra.ocir.io/axabc123"`,
+			`ocir_repo_team_api = "fra.ocir.io/axabc123/team/api"  # 4 images, private`,
+			`ocir_repo_web = "fra.ocir.io/axabc123/web"  # 1 images, public`,
+			`fn_app_billing = "fnapp-1"  # GENERIC_ARM, 1 subnets`,
+		} {
+			if !strings.Contains(string(locals), e) {
+				t.Errorf("locals.tf should contain %q", e)
+			}
+		}
+
+		content, err := os.ReadFile(filepath.Join(tmpDir, "functions_example.tf"))
+		if err != nil {
+			t.Fatalf("failed to read functions_example.tf: %v", err)
+		}
+		for _, e := range []string{
+			`resource "oci_functions_application" "app" {`,
+			"  subnet_ids     = [local.subnet_app_private]",
+			"docker login fra.ocir.io -u 'axabc123/<username>'",
+			`#   image          = "${local.ocir_repo_team_api}:latest"`,
+			"#   local.fn_app_billing  # GENERIC_ARM",
+		} {
+			if !strings.Contains(string(content), e) {
+				t.Errorf("functions_example.tf should contain %q", e)
+			}
+		}
+	})
+
+	t.Run("commented out without a private subnet", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		result := &discovery.Result{
+			Tenancy:  discovery.TenancyInfo{ID: "ocid1.tenancy.oc1..test", HomeRegion: "eu-frankfurt-1"},
+			VCNs:     vcns[:0],
+			Registry: &discovery.Registry{Namespace: "axabc123", Endpoint: "fra.ocir.io"},
+		}
+		if err := OutputTerraform(result, tmpDir, Options{}); err != nil {
+			t.Fatalf("OutputTerraform failed: %v", err)
+		}
+		content, _ := os.ReadFile(filepath.Join(tmpDir, "functions_example.tf"))
+		if !strings.Contains(string(content), `# resource "oci_functions_application" "app" {`) {
+			t.Error("application should be commented out without a private subnet")
+		}
+		if !strings.Contains(string(content), `"${local.ocir_registry}/my-function:latest"`) {
+			t.Error("function image should fall back to the registry URL without repositories")
+		}
+	})
+
+	t.Run("skipped without registry or applications", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		result := &discovery.Result{
+			Tenancy: discovery.TenancyInfo{ID: "ocid1.tenancy.oc1..test", HomeRegion: "eu-frankfurt-1"},
+			VCNs:    vcns,
+		}
+		if err := OutputTerraform(result, tmpDir, Options{}); err != nil {
+			t.Fatalf("OutputTerraform failed: %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(tmpDir, "functions_example.tf")); !os.IsNotExist(err) {
+			t.Error("functions_example.tf should not be created without registry or applications")
+		}
+	})
+}
+
 // Integration tests: validate generated TF with tofu
 
 func TestTerraformValidation(t *testing.T) {
@@ -889,9 +2006,13 @@ func TestTerraformValidation(t *testing.T) {
 			{ID: "ocid1.image.oc1..oke-arm", SourceName: "Oracle-Linux-8.10-aarch64-2025.11.20-0-OKE-1.31.10-1345", KubernetesVersion: "1.31.10", Architecture: "aarch64"},
 			{ID: "ocid1.image.oc1..oke-x86", SourceName: "Oracle-Linux-8.10-2025.11.20-0-OKE-1.31.10-1345", KubernetesVersion: "1.31.10", Architecture: "x86_64"},
 		},
+		Registry: &discovery.Registry{
+			Namespace: "testns", RegionKey: "PHX", Endpoint: "phx.ocir.io",
+			Repositories: []discovery.ContainerRepository{{ID: "ocid1.containerrepo.oc1..test", Name: "team/api", ImageCount: 2}},
+		},
 	}
 
-	opts := Options{AlwaysFree: false}
+	opts := Options{AlwaysFree: false, Observability: true, Budget: 50, Instances: 3}
 	if err := OutputTerraform(result, tmpDir, opts); err != nil {
 		t.Fatalf("OutputTerraform failed: %v", err)
 	}
@@ -952,7 +2073,7 @@ func TestTerraformValidationAlwaysFree(t *testing.T) {
 		VCNs: []discovery.VCN{}, // No existing VCNs triggers network.tf generation
 	}
 
-	opts := Options{AlwaysFree: true}
+	opts := Options{AlwaysFree: true, Instances: 2, InstancePool: true}
 	if err := OutputTerraform(result, tmpDir, opts); err != nil {
 		t.Fatalf("OutputTerraform with AlwaysFree failed: %v", err)
 	}
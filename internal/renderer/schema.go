@@ -0,0 +1,141 @@
+package renderer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/larsenclose/oci-tf-bootstrap/internal/discovery"
+)
+
+// Schema returns a JSON Schema (draft 2020-12) describing the JSON written by
+// OutputJSON, generated from the discovery.Result types. Fields always written
+// are required; slices, maps and pointers without omitempty may be null, as
+// encoding/json writes them when nothing was discovered. Unlisted fields are
+// allowed and format_version need only have FormatVersion's major version, so
+// output from later minor versions still validates.
+func Schema() map[string]any {
+	g := &schemaGenerator{defs: make(map[string]any)}
+	root := g.object(reflect.TypeFor[discovery.Result]())
+
+	major, _, _ := strings.Cut(FormatVersion, ".")
+	root["properties"].(map[string]any)["format_version"] = map[string]any{
+		"type":    "string",
+		"pattern": "^" + regexp.QuoteMeta(major) + `\.[0-9]+\.[0-9]+$`,
+	}
+
+	root["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	root["$id"] = "urn:oci-tf-bootstrap:result:" + FormatVersion
+	root["title"] = "oci-tf-bootstrap discovery result"
+	root["description"] = fmt.Sprintf("Output of oci-tf-bootstrap --json, format version %s.", FormatVersion)
+	root["$defs"] = g.defs
+	return root
+}
+
+// WriteSchema writes Schema as indented JSON.
+func WriteSchema(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(Schema())
+}
+
+type schemaGenerator struct {
+	defs map[string]any // Named struct types by name, referenced as #/$defs/Name
+}
+
+// schema returns the schema for values of type t. Nullable adds null to the
+// types allowed, for values encoding/json may write as null.
+func (g *schemaGenerator) schema(t reflect.Type, nullable bool) map[string]any {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return g.schema(t.Elem(), nullable)
+	case reflect.Struct:
+		s := map[string]any{"$ref": g.ref(t)}
+		if nullable {
+			return map[string]any{"anyOf": []any{s, map[string]any{"type": "null"}}}
+		}
+		return s
+	case reflect.Slice, reflect.Array:
+		return withType(map[string]any{"items": g.schema(t.Elem(), false)}, "array", nullable)
+	case reflect.Map:
+		return withType(map[string]any{"additionalProperties": g.schema(t.Elem(), false)}, "object", nullable)
+	case reflect.String:
+		return withType(map[string]any{}, "string", nullable)
+	case reflect.Bool:
+		return withType(map[string]any{}, "boolean", nullable)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return withType(map[string]any{}, "integer", nullable)
+	case reflect.Float32, reflect.Float64:
+		return withType(map[string]any{}, "number", nullable)
+	}
+	return map[string]any{} // Interfaces hold any value
+}
+
+func withType(s map[string]any, typ string, nullable bool) map[string]any {
+	if nullable {
+		s["type"] = []string{typ, "null"}
+	} else {
+		s["type"] = typ
+	}
+	return s
+}
+
+// ref adds the named struct type t to the definitions and returns its reference.
+func (g *schemaGenerator) ref(t reflect.Type) string {
+	if _, ok := g.defs[t.Name()]; !ok {
+		g.defs[t.Name()] = nil // Placeholder for recursive types
+		g.defs[t.Name()] = g.object(t)
+	}
+	return "#/$defs/" + t.Name()
+}
+
+// object returns the schema for struct type t, with the fields of embedded
+// structs inlined as encoding/json does.
+func (g *schemaGenerator) object(t reflect.Type) map[string]any {
+	properties := make(map[string]any)
+	required := []string{}
+	g.fields(t, properties, &required)
+	return map[string]any{
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+}
+
+func (g *schemaGenerator) fields(t reflect.Type, properties map[string]any, required *[]string) {
+	for f := range t.Fields() {
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			g.fields(f.Type, properties, required)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		omitempty := strings.Contains(opts, "omitempty")
+		properties[name] = g.schema(f.Type, !omitempty && nullable(f.Type))
+		if !omitempty {
+			*required = append(*required, name)
+		}
+	}
+}
+
+// nullable reports whether encoding/json writes the zero value of t as null.
+func nullable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+		return true
+	}
+	return false
+}
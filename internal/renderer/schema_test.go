@@ -0,0 +1,174 @@
+package renderer
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/larsenclose/oci-tf-bootstrap/internal/discovery"
+)
+
+func TestSchema(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteSchema(&buf); err != nil {
+		t.Fatalf("WriteSchema failed: %v", err)
+	}
+
+	t.Run("checked-in copy is current", func(t *testing.T) {
+		checkedIn, err := os.ReadFile("../../schema/result.schema.json")
+		if err != nil {
+			t.Fatalf("failed to read schema/result.schema.json: %v", err)
+		}
+		if !bytes.Equal(checkedIn, buf.Bytes()) {
+			t.Error("schema/result.schema.json is out of date: run `make schema` and bump FormatVersion if the JSON output changed")
+		}
+	})
+
+	var schema map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &schema); err != nil {
+		t.Fatalf("WriteSchema produced invalid JSON: %v", err)
+	}
+	defs := schema["$defs"].(map[string]any)
+
+	validate := func(t *testing.T, result *discovery.Result) {
+		t.Helper()
+		var out bytes.Buffer
+		if err := OutputJSON(result, &out); err != nil {
+			t.Fatalf("OutputJSON failed: %v", err)
+		}
+		var doc any
+		if err := json.Unmarshal(out.Bytes(), &doc); err != nil {
+			t.Fatalf("OutputJSON produced invalid JSON: %v", err)
+		}
+		for _, problem := range check(schema, defs, doc, "") {
+			t.Error(problem)
+		}
+	}
+
+	t.Run("empty result validates", func(t *testing.T) {
+		validate(t, &discovery.Result{})
+	})
+
+	t.Run("populated result validates", func(t *testing.T) {
+		validate(t, &discovery.Result{
+			Tenancy: discovery.TenancyInfo{ID: "ocid1.tenancy.oc1..test", Name: "test"},
+			Shapes:  []discovery.Shape{{Name: "VM.Standard.A1.Flex", IsFlexible: true, OCPUs: 4, MemoryGB: 24}},
+			Images: []discovery.Image{{
+				ID: "ocid1.image.oc1..test", OS: "Oracle Linux", OSVersion: "9", SizeGB: 47.5,
+				CompatibleShapes: []string{"VM.Standard.A1.Flex"},
+				Tags:             discovery.Tags{FreeformTags: map[string]string{"env": "dev"}},
+			}},
+			VCNs: []discovery.VCN{{
+				ID:              "ocid1.vcn.oc1..test",
+				Subnets:         []discovery.Subnet{{ID: "ocid1.subnet.oc1..test", IsPublic: true}},
+				SecurityLists:   []discovery.SecurityList{{ID: "ocid1.securitylist.oc1..test", IngressRules: []discovery.SecurityRule{{Protocol: "6", PortMin: 22, PortMax: 22}}}},
+				InternetGateway: &discovery.InternetGateway{ID: "ocid1.internetgateway.oc1..test", IsEnabled: true},
+			}},
+			Limits:     []discovery.ServiceLimit{{ServiceName: "compute", LimitName: "standard-a1-core-count", Value: 4}},
+			Registry:   &discovery.Registry{Namespace: "axaxnpcrorw5"},
+			Extensions: map[string]any{"buckets": []any{"logs"}},
+		})
+	})
+
+	t.Run("format_version", func(t *testing.T) {
+		version := schema["properties"].(map[string]any)["format_version"].(map[string]any)
+		pattern := regexp.MustCompile(version["pattern"].(string))
+		major, _, _ := strings.Cut(FormatVersion, ".")
+		if !pattern.MatchString(FormatVersion) || !pattern.MatchString(major+".99.0") {
+			t.Errorf("expected %s to accept minor versions of %s", pattern, FormatVersion)
+		}
+		if pattern.MatchString("0.1.0") || pattern.MatchString("99.0.0") {
+			t.Errorf("expected %s to reject other major versions", pattern)
+		}
+	})
+}
+
+// check validates v against the subset of JSON Schema that Schema generates
+// and returns the problems found.
+func check(schema, defs map[string]any, v any, path string) []string {
+	if ref, ok := schema["$ref"].(string); ok {
+		def, ok := defs[strings.TrimPrefix(ref, "#/$defs/")].(map[string]any)
+		if !ok {
+			return []string{path + ": unresolved reference " + ref}
+		}
+		return check(def, defs, v, path)
+	}
+	if anyOf, ok := schema["anyOf"].([]any); ok {
+		for _, s := range anyOf {
+			if len(check(s.(map[string]any), defs, v, path)) == 0 {
+				return nil
+			}
+		}
+		return []string{path + ": matches no alternative"}
+	}
+
+	var types []string
+	switch typ := schema["type"].(type) {
+	case string:
+		types = []string{typ}
+	case []any:
+		for _, s := range typ {
+			types = append(types, s.(string))
+		}
+	default:
+		return nil // Any value
+	}
+	if !slices.Contains(types, jsonType(v)) && !(jsonType(v) == "integer" && slices.Contains(types, "number")) {
+		return []string{path + ": got " + jsonType(v) + ", want " + strings.Join(types, " or ")}
+	}
+
+	var problems []string
+	switch v := v.(type) {
+	case string:
+		if pattern, ok := schema["pattern"].(string); ok && !regexp.MustCompile(pattern).MatchString(v) {
+			problems = append(problems, path+": "+v+" does not match "+pattern)
+		}
+	case []any:
+		for _, item := range v {
+			problems = append(problems, check(schema["items"].(map[string]any), defs, item, path+"[]")...)
+		}
+	case map[string]any:
+		properties, _ := schema["properties"].(map[string]any)
+		required, _ := schema["required"].([]any)
+		for _, name := range required {
+			if _, ok := v[name.(string)]; !ok {
+				problems = append(problems, path+"."+name.(string)+": required but missing")
+			}
+		}
+		for name, value := range v {
+			s, ok := properties[name].(map[string]any)
+			if !ok {
+				s, ok = schema["additionalProperties"].(map[string]any)
+			}
+			if !ok {
+				problems = append(problems, path+"."+name+": not in the schema")
+				continue
+			}
+			problems = append(problems, check(s, defs, value, path+"."+name)...)
+		}
+	}
+	return problems
+}
+
+func jsonType(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == float64(int64(v)) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	}
+	return "object"
+}
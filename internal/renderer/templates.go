@@ -26,6 +26,17 @@ func toTFName(s string) string {
 	return s
 }
 
+// writeDefinedTags stamps local.common_tags onto the resource block being
+// written when --tags was given.
+func writeDefinedTags(f *os.File, opts Options, commented bool) {
+	if len(opts.Tags) == 0 {
+		return
+	}
+	p := lineWriter(commented)
+	p(f, "")
+	p(f, "  defined_tags = local.common_tags")
+}
+
 var providerTmpl = `terraform {
   required_providers {
     oci = {
@@ -0,0 +1,273 @@
+package renderer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/larsenclose/oci-tf-bootstrap/internal/discovery"
+)
+
+// defaultFaultDomains is used for ADs whose fault domains were not discovered.
+var defaultFaultDomains = []string{"FAULT-DOMAIN-1", "FAULT-DOMAIN-2", "FAULT-DOMAIN-3"}
+
+// placement is the availability domain, fault domain and subnet of one instance.
+type placement struct {
+	ad          string // locals.tf reference, e.g. local.ad_2
+	faultDomain string
+	subnet      string // subnet reachable from ad, e.g. local.subnet_public
+	publicIP    bool
+}
+
+// placementSubnet returns the subnet instances in the named AD launch in: a
+// subnet of the example instance's VCN that is regional or in that AD, public
+// ones first and then regional ones. ok is false when the VCN has no such
+// subnet. Without discovered subnets the regional subnet from network.tf is
+// used, and without discovered ADs any subnet.
+func placementSubnet(result *discovery.Result, names map[string]string, ad string) (subnet string, public, ok bool) {
+	if len(result.VCNs) == 0 || len(result.VCNs[0].Subnets) == 0 {
+		return "oci_core_subnet.public.id", true, true
+	}
+	best, bestRank := -1, -1
+	for i, s := range result.VCNs[0].Subnets {
+		if ad != "" && s.AvailabilityDomain != "" && s.AvailabilityDomain != ad {
+			continue
+		}
+		rank := 0
+		if s.IsPublic {
+			rank += 2
+		}
+		if s.AvailabilityDomain == "" {
+			rank++
+		}
+		if rank > bestRank {
+			best, bestRank = i, rank
+		}
+	}
+	if best < 0 {
+		return "", false, false
+	}
+	s := result.VCNs[0].Subnets[best]
+	return "local." + names[s.ID], s.IsPublic, true
+}
+
+// topologyPlacements spreads n instances across the discovered ADs first and
+// then across the fault domains within each AD, so consecutive instances never
+// share a fault domain until every domain is in use. ADs without a subnet to
+// launch in are skipped, and the ADs used are returned with the placements.
+func topologyPlacements(result *discovery.Result, n int) (placements []placement, used []discovery.AvailabilityDomain) {
+	ads := result.AvailabilityDomains
+	if len(ads) == 0 {
+		ads = []discovery.AvailabilityDomain{{}}
+	}
+
+	names := subnetLocalNames(result)
+	var candidates []placement
+	for i, ad := range ads {
+		subnet, public, ok := placementSubnet(result, names, ad.Name)
+		if !ok {
+			continue
+		}
+		candidates = append(candidates, placement{ad: fmt.Sprintf("local.ad_%d", i+1), subnet: subnet, publicIP: public})
+		used = append(used, ad)
+	}
+	if len(used) == 0 {
+		return nil, nil
+	}
+
+	placements = make([]placement, n)
+	for i := range placements {
+		adIndex := i % len(used)
+		fds := used[adIndex].FaultDomains
+		if len(fds) == 0 {
+			fds = defaultFaultDomains
+		}
+		placements[i] = candidates[adIndex]
+		placements[i].faultDomain = fds[(i/len(used))%len(fds)]
+	}
+	return placements, used
+}
+
+// writeTopology generates instances.tf: N copies of the example instance, or an
+// instance configuration and pool of size N, each placed in an explicit fault
+// domain and subnet across the discovered availability domains.
+func writeTopology(result *discovery.Result, outputDir string, opts Options) (err error) {
+	f, err := os.Create(filepath.Join(outputDir, "instances.tf")) // #nosec G304 -- outputDir is user-specified CLI flag
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	n := max(opts.Instances, 1)
+	placements, used := topologyPlacements(result, n)
+	sizing := exampleSizing(result, opts)
+	imageKey := exampleImageKey(result, opts.AlwaysFree)
+
+	fmt.Fprintln(f, "# Generated by oci-tf-bootstrap")
+	if opts.InstancePool {
+		fmt.Fprintf(f, "# Instance pool of %d spread across availability and fault domains\n", n)
+	} else {
+		fmt.Fprintf(f, "# %d instances spread across availability and fault domains\n", n)
+	}
+	fmt.Fprintln(f, "#")
+	fmt.Fprintln(f, "# Each instance has an explicit fault domain, so a hardware failure or")
+	fmt.Fprintln(f, "# maintenance event takes down at most one of them. Instances fill every")
+	fmt.Fprintln(f, "# availability domain before sharing one, then every fault domain.")
+	if len(result.AvailabilityDomains) <= 1 {
+		fmt.Fprintln(f, "#")
+		fmt.Fprintln(f, "# This region has a single availability domain; fault domains are the only")
+		fmt.Fprintln(f, "# isolation available.")
+	}
+	var skipped []string
+	for _, ad := range result.AvailabilityDomains {
+		if !slices.ContainsFunc(used, func(u discovery.AvailabilityDomain) bool { return u.Name == ad.Name }) {
+			skipped = append(skipped, ad.Name)
+		}
+	}
+	if len(skipped) > 0 {
+		fmt.Fprintln(f, "#")
+		fmt.Fprintf(f, "# The instance VCN has no regional subnet or subnet in %s, so no\n", strings.Join(skipped, ", "))
+		fmt.Fprintln(f, "# instances are placed there.")
+	}
+	if len(placements) == 0 {
+		fmt.Fprintln(f, "#")
+		fmt.Fprintln(f, "# No discovered subnet is in a discovered availability domain; add")
+		fmt.Fprintln(f, "# placements with a subnet in their availability domain below.")
+	}
+	if opts.AlwaysFree {
+		fmt.Fprintln(f, "#")
+		fmt.Fprintln(f, "# These instances share the always-free allowance with always_free in")
+		fmt.Fprintln(f, "# instance_example.tf; anything beyond it is billed (see cost_estimate.md).")
+	}
+	fmt.Fprintln(f, "")
+
+	if opts.InstancePool && len(placements) > 0 {
+		writeInstancePool(f, opts, placements, sizing, imageKey)
+		return nil
+	}
+
+	fmt.Fprintln(f, "locals {")
+	fmt.Fprintln(f, "  instance_placements = [")
+	for _, p := range placements {
+		fmt.Fprintf(f, "    { availability_domain = %s, fault_domain = %q, subnet_id = %s, assign_public_ip = %t },\n", p.ad, p.faultDomain, p.subnet, p.publicIP)
+	}
+	fmt.Fprintln(f, "  ]")
+	fmt.Fprintln(f, "}")
+	fmt.Fprintln(f, "")
+
+	fmt.Fprintln(f, `resource "oci_core_instance" "spread" {`)
+	fmt.Fprintln(f, "  count               = length(local.instance_placements)")
+	fmt.Fprintln(f, "  compartment_id      = local.compartment_ocid")
+	fmt.Fprintln(f, "  availability_domain = local.instance_placements[count.index].availability_domain")
+	fmt.Fprintln(f, "  fault_domain        = local.instance_placements[count.index].fault_domain")
+	fmt.Fprintln(f, `  display_name        = "spread-${count.index + 1}"`)
+	fmt.Fprintln(f, "")
+	if imageKey != "" {
+		fmt.Fprintln(f, "  source_details {")
+		fmt.Fprintf(f, "    source_id   = data.oci_core_images.%s.images[0].id\n", imageKey)
+		fmt.Fprintln(f, `    source_type = "image"`)
+		fmt.Fprintln(f, "  }")
+		fmt.Fprintln(f, "")
+	}
+	writeShape(f, "  ", sizing)
+	fmt.Fprintln(f, "  create_vnic_details {")
+	fmt.Fprintln(f, "    assign_public_ip = local.instance_placements[count.index].assign_public_ip")
+	fmt.Fprintln(f, "    subnet_id        = local.instance_placements[count.index].subnet_id")
+	fmt.Fprintln(f, "  }")
+	writeDefinedTags(f, opts, false)
+	fmt.Fprintln(f, "}")
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, `output "spread_instances" {`)
+	fmt.Fprintln(f, "  value = { for i in oci_core_instance.spread : i.display_name => \"${i.availability_domain} ${i.fault_domain}\" }")
+	fmt.Fprintln(f, "}")
+	return nil
+}
+
+// writeShape writes the shape and, for flexible shapes, its shape_config block.
+func writeShape(f *os.File, indent string, sizing computeSizing) {
+	fmt.Fprintf(f, "%sshape = %q\n", indent, sizing.shape)
+	fmt.Fprintln(f, "")
+	if sizing.ocpus > 0 {
+		fmt.Fprintf(f, "%sshape_config {\n", indent)
+		fmt.Fprintf(f, "%s  ocpus         = %g\n", indent, sizing.ocpus)
+		fmt.Fprintf(f, "%s  memory_in_gbs = %g\n", indent, sizing.memGB)
+		fmt.Fprintf(f, "%s}\n", indent)
+		fmt.Fprintln(f, "")
+	}
+}
+
+// writeInstancePool writes an instance configuration cloned from the example
+// instance and a pool with one placement configuration per AD, listing the
+// fault domains its instances are spread over and the subnet they launch in.
+// Instances get a public IP only when every placement subnet is public.
+func writeInstancePool(f *os.File, opts Options, placements []placement, sizing computeSizing, imageKey string) {
+	var ads []placement
+	faultDomains := make(map[string][]string)
+	publicIP := true
+	for _, p := range placements {
+		if _, ok := faultDomains[p.ad]; !ok {
+			ads = append(ads, p)
+			publicIP = publicIP && p.publicIP
+		}
+		if !slices.Contains(faultDomains[p.ad], p.faultDomain) {
+			faultDomains[p.ad] = append(faultDomains[p.ad], p.faultDomain)
+		}
+	}
+
+	image := `""  # No image discovered; set an image OCID`
+	if imageKey != "" {
+		image = fmt.Sprintf("data.oci_core_images.%s.images[0].id", imageKey)
+	}
+
+	fmt.Fprintln(f, `resource "oci_core_instance_configuration" "spread" {`)
+	fmt.Fprintln(f, "  compartment_id = local.compartment_ocid")
+	fmt.Fprintln(f, `  display_name   = "spread-config"`)
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "  instance_details {")
+	fmt.Fprintln(f, `    instance_type = "compute"`)
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "    launch_details {")
+	fmt.Fprintln(f, "      compartment_id = local.compartment_ocid")
+	fmt.Fprintln(f, "")
+	writeShape(f, "      ", sizing)
+	fmt.Fprintln(f, "      source_details {")
+	fmt.Fprintln(f, `        source_type = "image"`)
+	fmt.Fprintf(f, "        image_id    = %s\n", image)
+	fmt.Fprintln(f, "      }")
+	fmt.Fprintln(f, "")
+	fmt.Fprintln(f, "      create_vnic_details {")
+	fmt.Fprintf(f, "        assign_public_ip = %t\n", publicIP)
+	fmt.Fprintf(f, "        subnet_id        = %s\n", placements[0].subnet)
+	fmt.Fprintln(f, "      }")
+	fmt.Fprintln(f, "    }")
+	fmt.Fprintln(f, "  }")
+	writeDefinedTags(f, opts, false)
+	fmt.Fprintln(f, "}")
+	fmt.Fprintln(f, "")
+
+	fmt.Fprintln(f, `resource "oci_core_instance_pool" "spread" {`)
+	fmt.Fprintln(f, "  compartment_id            = local.compartment_ocid")
+	fmt.Fprintln(f, "  instance_configuration_id = oci_core_instance_configuration.spread.id")
+	fmt.Fprintln(f, `  display_name              = "spread-pool"`)
+	fmt.Fprintf(f, "  size                      = %d\n", len(placements))
+	for _, ad := range ads {
+		quoted := make([]string, len(faultDomains[ad.ad]))
+		for i, fd := range faultDomains[ad.ad] {
+			quoted[i] = fmt.Sprintf("%q", fd)
+		}
+		fmt.Fprintln(f, "")
+		fmt.Fprintln(f, "  placement_configurations {")
+		fmt.Fprintf(f, "    availability_domain = %s\n", ad.ad)
+		fmt.Fprintf(f, "    fault_domains       = [%s]\n", strings.Join(quoted, ", "))
+		fmt.Fprintf(f, "    primary_subnet_id   = %s\n", ad.subnet)
+		fmt.Fprintln(f, "  }")
+	}
+	writeDefinedTags(f, opts, false)
+	fmt.Fprintln(f, "}")
+}
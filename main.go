@@ -1,14 +1,22 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"runtime/debug"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/larsenclose/oci-tf-bootstrap/internal/discovery"
+	"github.com/larsenclose/oci-tf-bootstrap/internal/pricing"
+	"github.com/larsenclose/oci-tf-bootstrap/internal/progress"
+	"github.com/larsenclose/oci-tf-bootstrap/internal/redact"
 	"github.com/larsenclose/oci-tf-bootstrap/internal/renderer"
 )
 
@@ -74,17 +82,41 @@ func versionInfo() (ver, com, dat string) {
 }
 
 var (
-	profile     = flag.String("profile", "", "OCI config profile name (default: $OCI_CLI_PROFILE or DEFAULT)")
-	configDir   = flag.String("config", "", "OCI config directory (default: $OCI_CLI_CONFIG_FILE directory or ~/.oci)")
-	configFile  = flag.String("config-file", "", "OCI config file path (default: $OCI_CLI_CONFIG_FILE or ~/.oci/config)")
-	outputDir   = flag.String("output", "./terraform", "Output directory for generated TF files")
-	region      = flag.String("region", "", "Override region (default: from config)")
-	compartment = flag.String("compartment", "", "Target compartment OCID (default: tenancy root)")
-	jsonOut     = flag.Bool("json", false, "Output raw discovery as JSON instead of TF")
-	alwaysFree  = flag.Bool("always-free", false, "Filter output to always-free tier eligible resources only")
-	oke         = flag.Bool("oke", false, "Include OKE (Oracle Kubernetes Engine) node image discovery")
-	dryRun      = flag.Bool("dry-run", false, "Show what would be generated without writing files")
-	showVersion = flag.Bool("version", false, "Print version information and exit")
+	profile       = flag.String("profile", "", "OCI config profile name (default: $OCI_CLI_PROFILE or DEFAULT)")
+	configDir     = flag.String("config", "", "OCI config directory (default: $OCI_CLI_CONFIG_FILE directory or ~/.oci)")
+	configFile    = flag.String("config-file", "", "OCI config file path (default: $OCI_CLI_CONFIG_FILE or ~/.oci/config)")
+	outputDir     = flag.String("output", "./terraform", "Output directory for generated TF files")
+	region        = flag.String("region", "", "Override region (default: from config)")
+	compartment   = flag.String("compartment", "", "Target compartment OCID (default: tenancy root)")
+	jsonOut       = flag.Bool("json", false, "Output raw discovery as JSON instead of TF")
+	alwaysFree    = flag.Bool("always-free", false, "Filter output to always-free tier eligible resources only")
+	oke           = flag.Bool("oke", false, "Include OKE (Oracle Kubernetes Engine) cluster and node image discovery")
+	virtualNodes  = flag.Bool("oke-virtual-nodes", false, "Generate an enhanced OKE cluster with VCN-native pod networking and a virtual node pool")
+	observability = flag.Bool("observability", false, "Generate VCN flow logs, an email alert topic and CPU/memory alarms for the example instance")
+	priceCatalog  = flag.String("price-catalog", "", "Price catalog JSON for cost_estimate.md (default: catalog shipped with the binary)")
+	budget        = flag.Float64("budget", 0, "Generate a monthly budget of this amount with alert rules and a shape quota for the target compartment")
+	instances     = flag.Int("instances", 1, "Generate this many instances spread across availability and fault domains in instances.tf")
+	instancePool  = flag.Bool("instance-pool", false, "Generate instances.tf as an instance configuration and pool spread across availability and fault domains")
+	maxRetries    = flag.Int("max-retries", 4, "Retry throttled (429) and failed (5xx, network) API calls this many times with exponential backoff; 0 disables")
+	maxInFlight   = flag.Int("max-in-flight", 8, "Maximum concurrent OCI API calls across all services; 0 is unlimited")
+	rps           = flag.Float64("rps", 10, "Maximum OCI API requests per second across all services; 0 is unlimited")
+	timeout       = flag.Duration("timeout", 0, "Abandon discovery after this long (e.g. 5m) and print what was gathered; 0 is no limit")
+	reqTimeout    = flag.Duration("request-timeout", time.Minute, "Deadline for each OCI API request; timed-out requests are retried")
+	cacheTTL      = flag.Duration("cache-ttl", 15*time.Minute, "Reuse cached discovery output younger than this; 0 disables the cache")
+	cacheDir      = flag.String("cache-dir", "", "Discovery cache directory (default: oci-tf-bootstrap in the user cache directory)")
+	refresh       = flag.Bool("refresh", false, "Ignore cached discovery output and query the API, refreshing the cache")
+	dryRun        = flag.Bool("dry-run", false, "Show what would be generated without writing files")
+	backupPolicy  = flag.String("backup-policy", "", "Volume backup policy to assign to example volumes (e.g. gold, silver, bronze)")
+	policy        = flag.Bool("policy", false, "Write the least-privilege IAM policy for discovery instead of running it")
+	policyGroup   = flag.String("policy-group", "oci-tf-bootstrap", "IAM group named in the --policy statements")
+	include       = flag.String("include", "", "Only discover these resource kinds, comma-separated (e.g. availability-domains,images)")
+	exclude       = flag.String("exclude", "", "Do not discover these resource kinds, comma-separated (e.g. compartments,policies)")
+	filterTag     = flag.String("filter-tag", "", "Only discover resources carrying these tags (Namespace.Key=Value or freeform Key=Value, comma-separated)")
+	tags          = flag.String("tags", "", "Defined tags stamped on every generated resource (e.g. Operations.CostCenter=42,Operations.Owner=alice)")
+	redactOut     = flag.Bool("redact", false, "Replace OCIDs, names, namespaces, emails and public IPs in all output with stable pseudonyms, for sharing")
+	redactKey     = flag.String("redact-key", "", "Secret the --redact pseudonyms are derived from (default: $OCI_TF_BOOTSTRAP_REDACT_KEY, else a random key stored in the user config directory)")
+	progressMode  = flag.String("progress", "auto", "Discovery progress: table (redrawn in place), text (a line per event), json (JSON lines, for CI) or auto (table on a terminal, else text)")
+	showVersion   = flag.Bool("version", false, "Print version information and exit")
 )
 
 // resolveConfigPath determines the OCI config file path from flags and environment variables.
@@ -141,6 +173,31 @@ func main() {
 		os.Exit(0)
 	}
 
+	if flag.Arg(0) == "schema" {
+		if flag.NArg() > 1 {
+			fmt.Fprintf(os.Stderr, "Error: schema: unexpected arguments %v\n", flag.Args()[1:])
+			os.Exit(1)
+		}
+		if err := renderer.WriteSchema(os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// diff exits 1 on drift, so errors get their own status
+	if flag.Arg(0) == "diff" {
+		drifted, err := runDiff(flag.Args()[1:])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(2)
+		}
+		if drifted {
+			os.Exit(1)
+		}
+		return
+	}
+
 	if err := run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
@@ -152,10 +209,11 @@ func run() error {
 	ociProfile := resolveProfile()
 
 	// When --json, diagnostics go to stderr so stdout is pure JSON
-	diag := os.Stdout
+	diagFile := os.Stdout
 	if *jsonOut {
-		diag = os.Stderr
+		diagFile = os.Stderr
 	}
+	var diag io.Writer = diagFile
 
 	fmt.Fprintf(diag, "oci-tf-bootstrap\n")
 	fmt.Fprintf(diag, "  Profile:    %s\n", ociProfile)
@@ -168,34 +226,73 @@ func run() error {
 		fmt.Fprintf(diag, "  Dry run:    yes (no files will be written)\n")
 	}
 
-	// Check if config file exists and provide helpful error message
-	if _, err := os.Stat(ociConfigPath); os.IsNotExist(err) {
-		printSetupHelp(ociConfigPath)
-		return fmt.Errorf("OCI config file not found at %s", ociConfigPath)
+	commonTags, err := discovery.ParseDefinedTags(*tags)
+	if err != nil {
+		return fmt.Errorf("--tags: %w", err)
 	}
-
-	ctx, err := discovery.NewContext(ociProfile, ociConfigPath, *region, *compartment, *alwaysFree, *oke)
+	if *budget < 0 {
+		return fmt.Errorf("--budget: amount must be positive, got %g", *budget)
+	}
+	if *instances < 1 {
+		return fmt.Errorf("--instances: count must be at least 1, got %d", *instances)
+	}
+	prices, err := pricing.Load(*priceCatalog)
 	if err != nil {
-		if strings.Contains(err.Error(), "can not read") || strings.Contains(err.Error(), "configuration") {
-			printSetupHelp(ociConfigPath)
-		}
-		return fmt.Errorf("failed to initialize OCI context: %w", err)
+		return fmt.Errorf("--price-catalog: %w", err)
 	}
 
-	ctx.ProgressWriter = diag
+	redactor, err := newRedactor()
+	if err != nil {
+		return err
+	}
+	diag = redactor.Writer(diag)
+	dctx, err := newDiscoveryContext(ociConfigPath, ociProfile, diag)
+	if err != nil {
+		return err
+	}
+	kinds := make([]string, len(dctx.Discoverers))
+	for i, d := range dctx.Discoverers {
+		kinds[i] = d.Name()
+	}
 
-	fmt.Fprintf(diag, "  Tenancy:    %s\n", ctx.TenancyID)
-	fmt.Fprintf(diag, "  Region:     %s\n", ctx.Region)
-	if *compartment != "" {
-		fmt.Fprintf(diag, "  Compartment: %s\n", *compartment)
+	if *policy {
+		return runPolicy(dctx, redactor, diag)
 	}
-	fmt.Fprintln(diag)
 
-	result, err := discovery.Run(ctx)
+	ctx, cancel := discoveryDeadline()
+	defer cancel()
+
+	stopProgress := startProgress(dctx, diagFile)
+	result, err := discovery.Run(ctx, dctx)
+	stopProgress()
+	if result != nil {
+		redactor.Result(result)
+	}
 	if err != nil {
-		return fmt.Errorf("discovery failed: %w", err)
+		if result == nil {
+			return fmt.Errorf("discovery failed: %w", err)
+		}
+		return printPartialResult(result, err, diag)
+	}
+
+	if len(commonTags) > 0 {
+		for _, w := range discovery.ValidateTags(result, commonTags) {
+			fmt.Fprintf(diag, "  ⚠ --tags: %s\n", w)
+		}
 	}
 
+	opts := renderer.Options{
+		AlwaysFree:    *alwaysFree,
+		BackupPolicy:  *backupPolicy,
+		Tags:          commonTags,
+		VirtualNodes:  *virtualNodes,
+		Observability: *observability,
+		Budget:        *budget,
+		Prices:        prices,
+		Instances:     *instances,
+		InstancePool:  *instancePool,
+		Kinds:         kinds,
+	}
 	if *jsonOut {
 		if err := renderer.OutputJSON(result, os.Stdout); err != nil {
 			return fmt.Errorf("failed to output JSON: %w", err)
@@ -207,7 +304,6 @@ func run() error {
 		}
 		defer os.RemoveAll(tmpDir)
 
-		opts := renderer.Options{AlwaysFree: *alwaysFree}
 		err = renderer.OutputTerraform(result, tmpDir, opts)
 		if err != nil {
 			return fmt.Errorf("failed to render terraform: %w", err)
@@ -228,27 +324,269 @@ func run() error {
 			fmt.Fprintf(diag, "  %s (%d bytes)\n", entry.Name(), info.Size())
 		}
 
-		fmt.Fprintf(diag, "\nDiscovered resources:\n")
-		fmt.Fprintf(diag, "  Compartments:         %d\n", len(result.Compartments))
-		fmt.Fprintf(diag, "  Availability Domains: %d\n", len(result.AvailabilityDomains))
-		fmt.Fprintf(diag, "  Shapes:               %d\n", len(result.Shapes))
-		fmt.Fprintf(diag, "  Images:               %d\n", len(result.Images))
-		fmt.Fprintf(diag, "  VCNs:                 %d\n", len(result.VCNs))
-		fmt.Fprintf(diag, "  Block Volumes:        %d\n", len(result.BlockVolumes))
-		if len(result.OKEImages) > 0 {
-			fmt.Fprintf(diag, "  OKE Node Images:      %d\n", len(result.OKEImages))
+		if opts.Renders("cost_estimate.md") {
+			est := renderer.EstimateCost(result, opts)
+			fmt.Fprintf(diag, "\nEstimated monthly cost: %.2f %s (price catalog %s)\n", est.Total, est.Currency, est.CatalogVersion)
 		}
-		fmt.Fprintf(diag, "  Service Limits:       %d\n", len(result.Limits))
+
+		printResourceCounts(diag, result)
 	} else {
-		opts := renderer.Options{
-			AlwaysFree: *alwaysFree,
-		}
 		if err := renderer.OutputTerraform(result, *outputDir, opts); err != nil {
 			return fmt.Errorf("failed to render terraform: %w", err)
 		}
 		fmt.Fprintf(diag, "Generated terraform files in %s\n", *outputDir)
+		if opts.Renders("cost_estimate.md") {
+			est := renderer.EstimateCost(result, opts)
+			fmt.Fprintf(diag, "Estimated monthly cost: %.2f %s (see cost_estimate.md)\n", est.Total, est.Currency)
+		}
+	}
+
+	return nil
+}
+
+// newDiscoveryContext validates the discovery flags and builds the discovery
+// context for the profile, printing the tenancy and scope to diag.
+func newDiscoveryContext(ociConfigPath, ociProfile string, diag io.Writer) (*discovery.Context, error) {
+	tagFilters, err := discovery.ParseTagFilters(*filterTag)
+	if err != nil {
+		return nil, fmt.Errorf("--filter-tag: %w", err)
+	}
+	discoverers, err := discovery.SelectDiscoverers(splitList(*include), splitList(*exclude))
+	if err != nil {
+		return nil, fmt.Errorf("--include/--exclude: %w", err)
+	}
+	if *maxRetries < 0 {
+		return nil, fmt.Errorf("--max-retries: must not be negative, got %d", *maxRetries)
+	}
+	if *timeout < 0 {
+		return nil, fmt.Errorf("--timeout: must not be negative, got %v", *timeout)
+	}
+	if *reqTimeout < 0 {
+		return nil, fmt.Errorf("--request-timeout: must not be negative, got %v", *reqTimeout)
+	}
+	if *cacheTTL < 0 {
+		return nil, fmt.Errorf("--cache-ttl: must not be negative, got %v", *cacheTTL)
+	}
+	if *maxInFlight < 0 {
+		return nil, fmt.Errorf("--max-in-flight: must not be negative, got %d", *maxInFlight)
+	}
+	if *rps < 0 {
+		return nil, fmt.Errorf("--rps: must not be negative, got %g", *rps)
+	}
+	if !slices.Contains([]string{"auto", "table", "text", "json"}, *progressMode) {
+		return nil, fmt.Errorf("--progress: must be auto, table, text or json, got %q", *progressMode)
+	}
+
+	// Check if config file exists and provide helpful error message
+	if _, err := os.Stat(ociConfigPath); os.IsNotExist(err) {
+		printSetupHelp(ociConfigPath)
+		return nil, fmt.Errorf("OCI config file not found at %s", ociConfigPath)
+	}
+
+	dctx, err := discovery.NewContext(ociProfile, ociConfigPath, *region, *compartment, *alwaysFree, *oke)
+	if err != nil {
+		if strings.Contains(err.Error(), "can not read") || strings.Contains(err.Error(), "configuration") {
+			printSetupHelp(ociConfigPath)
+		}
+		return nil, fmt.Errorf("failed to initialize OCI context: %w", err)
+	}
+
+	dctx.ProgressWriter = diag
+	dctx.TagFilters = tagFilters
+	dctx.Discoverers = discoverers
+	dctx.Retry.MaxRetries = *maxRetries
+	dctx.Retry.RequestTimeout = *reqTimeout
+	dctx.Schedule = discovery.SchedulePolicy{MaxInFlight: *maxInFlight, RequestsPerSecond: *rps}
+	if *cacheTTL > 0 {
+		dir := *cacheDir
+		if dir == "" {
+			if dir, err = discovery.DefaultCacheDir(); err != nil {
+				return nil, fmt.Errorf("failed to locate cache directory (set --cache-dir or --cache-ttl 0): %w", err)
+			}
+		}
+		dctx.Cache = &discovery.Cache{Dir: dir, TTL: *cacheTTL, Refresh: *refresh}
 	}
 
+	fmt.Fprintf(diag, "  Tenancy:    %s\n", dctx.TenancyID)
+	fmt.Fprintf(diag, "  Region:     %s\n", dctx.Region)
+	if *compartment != "" {
+		fmt.Fprintf(diag, "  Compartment: %s\n", *compartment)
+	}
+	for _, f := range tagFilters {
+		fmt.Fprintf(diag, "  Tag filter: %s\n", f)
+	}
+	fmt.Fprintln(diag)
+	return dctx, nil
+}
+
+// startProgress sets dctx.Progress to the --progress renderer, drawing to
+// dctx.ProgressWriter, and returns a function to call when discovery ends.
+// auto picks the table when out is a terminal.
+func startProgress(dctx *discovery.Context, out *os.File) (stop func()) {
+	mode := *progressMode
+	if mode == "auto" {
+		mode = "text"
+		if isTerminal(out) {
+			mode = "table"
+		}
+	}
+	switch mode {
+	case "table":
+		table := progress.NewTable(dctx.ProgressWriter)
+		dctx.Progress = table.Handle
+		return table.Close
+	case "json":
+		dctx.Progress = progress.JSONLines(dctx.ProgressWriter)
+	default:
+		dctx.Progress = progress.Text(dctx.ProgressWriter)
+	}
+	return func() {}
+}
+
+// isTerminal reports whether f is a terminal that can redraw the table.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0 && os.Getenv("TERM") != "dumb"
+}
+
+// newRedactor returns the redactor for --redact, or nil without it.
+// Its key comes from --redact-key, $OCI_TF_BOOTSTRAP_REDACT_KEY or the key file
+// in the user config directory, created on first use, so pseudonyms are the
+// same on every run with the same key.
+func newRedactor() (*redact.Redactor, error) {
+	if !*redactOut {
+		return nil, nil
+	}
+	if *redactKey != "" {
+		return redact.New(*redactKey), nil
+	}
+	if envKey := os.Getenv("OCI_TF_BOOTSTRAP_REDACT_KEY"); envKey != "" {
+		return redact.New(envKey), nil
+	}
+	path, err := redact.DefaultKeyFile()
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate redaction key file (set --redact-key): %w", err)
+	}
+	key, err := redact.LoadKey(path)
+	if err != nil {
+		return nil, fmt.Errorf("redaction key: %w", err)
+	}
+	return redact.New(key), nil
+}
+
+// discoveryDeadline returns the context discovery runs under: canceled by
+// Ctrl-C or when --timeout passes. After the first Ctrl-C the signal is no
+// longer caught, so a second one exits immediately.
+func discoveryDeadline() (context.Context, context.CancelFunc) {
+	sigCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	go func() {
+		<-sigCtx.Done()
+		stop()
+	}()
+	if *timeout <= 0 {
+		return sigCtx, stop
+	}
+	ctx, cancel := context.WithTimeout(sigCtx, *timeout)
+	return ctx, func() {
+		cancel()
+		stop()
+	}
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries.
+func splitList(s string) []string {
+	var items []string
+	for item := range strings.SplitSeq(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// printResourceCounts lists how many of each resource type were discovered.
+func printResourceCounts(w io.Writer, result *discovery.Result) {
+	fmt.Fprintf(w, "\nDiscovered resources:\n")
+	fmt.Fprintf(w, "  Compartments:         %d\n", len(result.Compartments))
+	fmt.Fprintf(w, "  Availability Domains: %d\n", len(result.AvailabilityDomains))
+	fmt.Fprintf(w, "  Shapes:               %d\n", len(result.Shapes))
+	fmt.Fprintf(w, "  Images:               %d\n", len(result.Images))
+	fmt.Fprintf(w, "  VCNs:                 %d\n", len(result.VCNs))
+	fmt.Fprintf(w, "  Block Volumes:        %d\n", len(result.BlockVolumes))
+	fmt.Fprintf(w, "  Boot Volumes:         %d\n", len(result.BootVolumes))
+	fmt.Fprintf(w, "  Volume Groups:        %d\n", len(result.VolumeGroups))
+	fmt.Fprintf(w, "  Backup Policies:      %d\n", len(result.BackupPolicies))
+	if len(result.OKEImages) > 0 {
+		fmt.Fprintf(w, "  OKE Clusters:         %d\n", len(result.OKEClusters))
+		fmt.Fprintf(w, "  OKE Versions Behind:  %d\n", countBehind(result.OKEVersions))
+		fmt.Fprintf(w, "  OKE Node Images:      %d\n", len(result.OKEImages))
+	}
+	fmt.Fprintf(w, "  Service Limits:       %d\n", len(result.Limits))
+	fmt.Fprintf(w, "  IAM Groups:           %d\n", len(result.Groups))
+	fmt.Fprintf(w, "  Dynamic Groups:       %d\n", len(result.DynamicGroups))
+	fmt.Fprintf(w, "  IAM Policies:         %d\n", len(result.Policies))
+	fmt.Fprintf(w, "  Tag Namespaces:       %d\n", len(result.TagNamespaces))
+	if result.Registry != nil {
+		fmt.Fprintf(w, "  OCIR Repositories:    %d\n", len(result.Registry.Repositories))
+	}
+	fmt.Fprintf(w, "  Functions Apps:       %d\n", len(result.FunctionsApplications))
+	fmt.Fprintf(w, "  DNS Zones:            %d\n", len(result.DNSZones))
+	fmt.Fprintf(w, "  DNS Views:            %d\n", len(result.DNSViews))
+	fmt.Fprintf(w, "  DNS Resolvers:        %d\n", len(result.DNSResolvers))
+	fmt.Fprintf(w, "  Log Groups:           %d\n", len(result.LogGroups))
+	fmt.Fprintf(w, "  Notification Topics:  %d\n", len(result.NotificationTopics))
+	fmt.Fprintf(w, "  Alarms:               %d\n", len(result.Alarms))
+	fmt.Fprintf(w, "  Budgets:              %d\n", len(result.Budgets))
+	fmt.Fprintf(w, "  Quotas:               %d\n", len(result.Quotas))
+}
+
+// printPartialResult reports a discovery cut short by Ctrl-C or --timeout:
+// the resource counts gathered so far and, with --json, the partial result.
+// No Terraform is generated from a partial result.
+func printPartialResult(result *discovery.Result, err error, diag io.Writer) error {
+	fmt.Fprintf(diag, "\n⚠ %v\n", err)
+	printResourceCounts(diag, result)
+	if *jsonOut {
+		if err := renderer.OutputJSON(result, os.Stdout); err != nil {
+			return fmt.Errorf("failed to output JSON: %w", err)
+		}
+	}
+	return fmt.Errorf("discovery incomplete, no Terraform generated: %w", err)
+}
+
+// countBehind returns how many clusters and node pools are behind their target version.
+func countBehind(versions []discovery.OKEVersionStatus) int {
+	var n int
+	for _, v := range versions {
+		if v.IsBehind {
+			n++
+		}
+	}
+	return n
+}
+
+// runPolicy writes the least-privilege IAM policy for the enabled discovery
+// scopes without calling any OCI APIs. With --redact the tenancy and
+// compartment OCIDs in it are pseudonyms.
+func runPolicy(dctx *discovery.Context, redactor *redact.Redactor, diag io.Writer) error {
+	stmts := discovery.RequiredPolicy(dctx)
+	opts := renderer.PolicyOptions{
+		Group:         *policyGroup,
+		TenancyID:     redactor.String(dctx.TenancyID),
+		CompartmentID: redactor.String(dctx.CompartmentID),
+	}
+
+	renderer.WritePolicyText(diag, stmts, opts)
+	fmt.Fprintln(diag)
+
+	if *dryRun {
+		fmt.Fprintf(diag, "Would write bootstrap_policy.tf and bootstrap_policy.txt to %s\n", *outputDir)
+		return nil
+	}
+	if err := renderer.OutputPolicy(stmts, *outputDir, opts); err != nil {
+		return fmt.Errorf("failed to write policy: %w", err)
+	}
+	fmt.Fprintf(diag, "Generated bootstrap_policy.tf and bootstrap_policy.txt in %s\n", *outputDir)
 	return nil
 }
 
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/larsenclose/oci-tf-bootstrap/internal/discovery"
+)
+
+func TestRunPolicyRedact(t *testing.T) {
+	dir := t.TempDir()
+	config := filepath.Join(dir, "config")
+	if err := os.WriteFile(config, []byte(`[DEFAULT]
+user=ocid1.user.oc1..aaaaaaaauser
+fingerprint=00:11:22:33:44:55:66:77:88:99:aa:bb:cc:dd:ee:ff
+tenancy=ocid1.tenancy.oc1..aaaaaaaatenancy
+region=us-ashburn-1
+key_file=`+filepath.Join(dir, "key.pem")+`
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("OCI_TF_BOOTSTRAP_REDACT_KEY", "test-key")
+	out := filepath.Join(dir, "out")
+	for name, value := range map[string]string{
+		"config-file": config,
+		"compartment": "ocid1.compartment.oc1..aaaaaaaaprod",
+		"output":      out,
+		"policy":      "true",
+		"redact":      "true",
+	} {
+		if err := flag.Set(name, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stdout, err := os.Create(filepath.Join(dir, "stdout"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := os.Stdout
+	os.Stdout = stdout
+	err = run()
+	os.Stdout = saved
+	stdout.Close()
+	if err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+
+	for _, name := range []string{"stdout", "out/bootstrap_policy.tf", "out/bootstrap_policy.txt"} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(data), "aaaaaaaa") {
+			t.Errorf("%s still contains a real OCID:\n%s", name, data)
+		}
+		if !strings.Contains(string(data), "oc1..redacted") {
+			t.Errorf("expected OCID pseudonyms in %s:\n%s", name, data)
+		}
+	}
+}
+
+func TestNotRun(t *testing.T) {
+	selected, err := discovery.SelectDiscoverers([]string{"vcns", "oke-images"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	skipped := notRun(&discovery.Context{Discoverers: selected})
+	if slices.Contains(skipped, "vcns") || !slices.Contains(skipped, "shapes") || !slices.Contains(skipped, "oke-images") {
+		t.Errorf("expected discoverers left out or not enabled without --oke, got %v", skipped)
+	}
+	if skipped := notRun(&discovery.Context{OKE: true}); slices.Contains(skipped, "oke-images") || !slices.Contains(skipped, "a1-capacity") {
+		t.Errorf("expected only discoverers not enabled by the flags without a selection, got %v", skipped)
+	}
+}